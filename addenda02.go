@@ -19,7 +19,6 @@ package ach
 
 import (
 	"strings"
-	"unicode/utf8"
 )
 
 // Addenda02 is a Addendumer addenda which provides business transaction information for Addenda Type
@@ -63,6 +62,8 @@ type Addenda02 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 // NewAddenda02 returns a new Addenda02 with default values for none exported fields
@@ -76,93 +77,60 @@ func NewAddenda02() *Addenda02 {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (addenda02 *Addenda02) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			// 2-3 Always 02
-			addenda02.TypeCode = reset()
-		case 10:
-			// 4-10 Based on the information entered (04-10) 7 alphanumeric
-			addenda02.ReferenceInformationOne = strings.TrimSpace(reset())
-		case 13:
-			// 11-13 Based on the information entered (11-13) 3 alphanumeric
-			addenda02.ReferenceInformationTwo = strings.TrimSpace(reset())
-		case 19:
-			// 14-19
-			addenda02.TerminalIdentificationCode = strings.TrimSpace(reset())
-		case 25:
-			// 20-25
-			addenda02.TransactionSerialNumber = strings.TrimSpace(reset())
-		case 29:
-			// 26-29
-			addenda02.TransactionDate = strings.TrimSpace(reset())
-		case 35:
-			// 30-35
-			addenda02.AuthorizationCodeOrExpireDate = strings.TrimSpace(reset())
-		case 62:
-			// 36-62
-			addenda02.TerminalLocation = strings.TrimSpace(reset())
-		case 77:
-			// 63-77
-			addenda02.TerminalCity = strings.TrimSpace(reset())
-		case 79:
-			// 78-79
-			addenda02.TerminalState = strings.TrimSpace(reset())
-		case 94:
-			// 80-94
-			addenda02.TraceNumber = strings.TrimSpace(reset())
-		}
-	}
+	// 1-1 Always 7
+	// 2-3 Always 02
+	addenda02.TypeCode = fields.field(1, 3)
+	// 4-10 Based on the information entered (04-10) 7 alphanumeric
+	addenda02.ReferenceInformationOne = strings.TrimSpace(fields.field(3, 10))
+	// 11-13 Based on the information entered (11-13) 3 alphanumeric
+	addenda02.ReferenceInformationTwo = strings.TrimSpace(fields.field(10, 13))
+	// 14-19
+	addenda02.TerminalIdentificationCode = strings.TrimSpace(fields.field(13, 19))
+	// 20-25
+	addenda02.TransactionSerialNumber = strings.TrimSpace(fields.field(19, 25))
+	// 26-29
+	addenda02.TransactionDate = strings.TrimSpace(fields.field(25, 29))
+	// 30-35
+	addenda02.AuthorizationCodeOrExpireDate = strings.TrimSpace(fields.field(29, 35))
+	// 36-62
+	addenda02.TerminalLocation = strings.TrimSpace(fields.field(35, 62))
+	// 63-77
+	addenda02.TerminalCity = strings.TrimSpace(fields.field(62, 77))
+	// 78-79
+	addenda02.TerminalState = strings.TrimSpace(fields.field(77, 79))
+	// 80-94
+	addenda02.TraceNumber = strings.TrimSpace(fields.field(79, 94))
 }
 
 // String writes the Addenda02 struct to a 94 character string.
 func (addenda02 *Addenda02) String() string {
+	return string(addenda02.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the Addenda02 record to dst and returns the extended buffer.
+func (addenda02 *Addenda02) AppendTo(dst []byte) []byte {
 	if addenda02 == nil {
-		return ""
+		return dst
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(addenda02.TypeCode)
-	buf.WriteString(addenda02.ReferenceInformationOneField())
-	buf.WriteString(addenda02.ReferenceInformationTwoField())
-	buf.WriteString(addenda02.TerminalIdentificationCodeField())
-	buf.WriteString(addenda02.TransactionSerialNumberField())
-	buf.WriteString(addenda02.TransactionDateField())
-	buf.WriteString(addenda02.AuthorizationCodeOrExpireDateField())
-	buf.WriteString(addenda02.TerminalLocationField())
-	buf.WriteString(addenda02.TerminalCityField())
-	buf.WriteString(addenda02.TerminalStateField())
-	buf.WriteString(addenda02.TraceNumberField())
-
-	return buf.String()
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, addenda02.TypeCode...)
+	dst = addenda02.appendAlphaField(dst, addenda02.ReferenceInformationOne, 7)
+	dst = addenda02.appendAlphaField(dst, addenda02.ReferenceInformationOne, 3)
+	dst = addenda02.appendAlphaField(dst, addenda02.TerminalIdentificationCode, 6)
+	dst = addenda02.appendAlphaField(dst, addenda02.TransactionSerialNumber, 6)
+	dst = addenda02.appendAlphaField(dst, addenda02.TransactionDate, 4)
+	dst = addenda02.appendAlphaField(dst, addenda02.AuthorizationCodeOrExpireDate, 6)
+	dst = addenda02.appendAlphaField(dst, addenda02.TerminalLocation, 27)
+	dst = addenda02.appendAlphaField(dst, addenda02.TerminalCity, 15)
+	dst = addenda02.appendAlphaField(dst, addenda02.TerminalState, 2)
+	dst = addenda02.appendStringField(dst, addenda02.TraceNumber, 15)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -243,6 +211,51 @@ func (addenda02 *Addenda02) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (addenda02 *Addenda02) validateAll() []error {
+	var errs fieldErrors
+	if addenda02.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, addenda02.TypeCode)
+	}
+	if addenda02.TransactionSerialNumber == "" {
+		errs.add("TransactionSerialNumber", ErrFieldRequired, addenda02.TransactionSerialNumber)
+	}
+	if addenda02.TransactionDate == "" {
+		errs.add("TransactionDate", ErrFieldRequired, addenda02.TransactionDate)
+	}
+	if addenda02.TerminalLocation == "" {
+		errs.add("TerminalLocation", ErrFieldRequired, addenda02.TerminalLocation)
+	}
+	if addenda02.TerminalCity == "" {
+		errs.add("TerminalCity", ErrFieldRequired, addenda02.TerminalCity)
+	}
+	if addenda02.TerminalState == "" {
+		errs.add("TerminalState", ErrFieldRequired, addenda02.TerminalState)
+	}
+	errs.add("TypeCode", addenda02.isTypeCode(addenda02.TypeCode), addenda02.TypeCode)
+	if addenda02.TypeCode != "02" {
+		errs.add("TypeCode", ErrAddendaTypeCode, addenda02.TypeCode)
+	}
+	errs.add("ReferenceInformationOne", addenda02.isAlphanumeric(addenda02.ReferenceInformationOne), addenda02.ReferenceInformationOne)
+	errs.add("ReferenceInformationTwo", addenda02.isAlphanumeric(addenda02.ReferenceInformationTwo), addenda02.ReferenceInformationTwo)
+	errs.add("TerminalIdentificationCode", addenda02.isAlphanumeric(addenda02.TerminalIdentificationCode), addenda02.TerminalIdentificationCode)
+	errs.add("TransactionSerialNumber", addenda02.isAlphanumeric(addenda02.TransactionSerialNumber), addenda02.TransactionSerialNumber)
+	mm := addenda02.parseStringField(addenda02.TransactionDateField()[0:2])
+	dd := addenda02.parseStringField(addenda02.TransactionDateField()[2:4])
+	if err := addenda02.isMonth(mm); err != nil {
+		errs.add("TransactionDate", ErrValidMonth, mm)
+	}
+	if err := addenda02.isDay(mm, dd); err != nil {
+		errs.add("TransactionDate", ErrValidDay, mm)
+	}
+	errs.add("AuthorizationCodeOrExpireDate", addenda02.isAlphanumeric(addenda02.AuthorizationCodeOrExpireDate), addenda02.AuthorizationCodeOrExpireDate)
+	errs.add("TerminalLocation", addenda02.isAlphanumeric(addenda02.TerminalLocation), addenda02.TerminalLocation)
+	errs.add("TerminalCity", addenda02.isAlphanumeric(addenda02.TerminalCity), addenda02.TerminalCity)
+	errs.add("TerminalState", addenda02.isAlphanumeric(addenda02.TerminalState), addenda02.TerminalState)
+	return errs
+}
+
 // ReferenceInformationOneField returns a space padded ReferenceInformationOne string
 func (addenda02 *Addenda02) ReferenceInformationOneField() string {
 	return addenda02.alphaField(addenda02.ReferenceInformationOne, 7)
@@ -19,7 +19,6 @@ package ach
 
 import (
 	"strings"
-	"unicode/utf8"
 )
 
 // Addenda05 is a Addendumer addenda which provides business transaction information for Addenda Type
@@ -45,6 +44,8 @@ type Addenda05 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 // NewAddenda05 returns a new Addenda05 with default values for none exported fields
@@ -58,66 +59,40 @@ func NewAddenda05() *Addenda05 {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (addenda05 *Addenda05) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			// 2-3 Always 05
-			addenda05.TypeCode = reset()
-		case 83:
-			// 4-83 Based on the information entered (04-83) 80 alphanumeric
-			addenda05.PaymentRelatedInformation = strings.TrimSpace(reset())
-		case 87:
-			// 84-87 SequenceNumber is consecutively assigned to each Addenda05 Record following
-			// an Entry Detail Record
-			addenda05.SequenceNumber = addenda05.parseNumField(reset())
-		case 94:
-			// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
-			addenda05.EntryDetailSequenceNumber = addenda05.parseNumField(reset())
-		}
-	}
+	// 1-1 Always 7
+	// 2-3 Always 05
+	addenda05.TypeCode = fields.field(1, 3)
+	// 4-83 Based on the information entered (04-83) 80 alphanumeric
+	addenda05.PaymentRelatedInformation = strings.TrimSpace(fields.field(3, 83))
+	// 84-87 SequenceNumber is consecutively assigned to each Addenda05 Record following
+	// an Entry Detail Record
+	addenda05.SequenceNumber = addenda05.parseNumField(fields.field(83, 87))
+	// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
+	addenda05.EntryDetailSequenceNumber = addenda05.parseNumField(fields.field(87, 94))
 }
 
 // String writes the Addenda05 struct to a 94 character string.
 func (addenda05 *Addenda05) String() string {
+	return string(addenda05.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the Addenda05 record to dst and returns the extended buffer.
+func (addenda05 *Addenda05) AppendTo(dst []byte) []byte {
 	if addenda05 == nil {
-		return ""
+		return dst
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(addenda05.TypeCode)
-	buf.WriteString(addenda05.PaymentRelatedInformationField())
-	buf.WriteString(addenda05.SequenceNumberField())
-	buf.WriteString(addenda05.EntryDetailSequenceNumberField())
-
-	return buf.String()
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, addenda05.TypeCode...)
+	dst = addenda05.appendAlphaField(dst, addenda05.PaymentRelatedInformation, 80)
+	dst = addenda05.appendNumericField(dst, addenda05.SequenceNumber, 4)
+	dst = addenda05.appendNumericField(dst, addenda05.EntryDetailSequenceNumber, 7)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -156,6 +131,27 @@ func (addenda05 *Addenda05) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (addenda05 *Addenda05) validateAll() []error {
+	var errs fieldErrors
+	if addenda05.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, addenda05.TypeCode)
+	}
+	if addenda05.SequenceNumber == 0 {
+		errs.add("SequenceNumber", ErrConstructor, addenda05.SequenceNumberField())
+	}
+	if addenda05.EntryDetailSequenceNumber == 0 {
+		errs.add("EntryDetailSequenceNumber", ErrConstructor, addenda05.EntryDetailSequenceNumberField())
+	}
+	errs.add("TypeCode", addenda05.isTypeCode(addenda05.TypeCode), addenda05.TypeCode)
+	if addenda05.TypeCode != "05" {
+		errs.add("TypeCode", ErrAddendaTypeCode, addenda05.TypeCode)
+	}
+	errs.add("PaymentRelatedInformation", addenda05.isAlphanumeric(addenda05.PaymentRelatedInformation), addenda05.PaymentRelatedInformation)
+	return errs
+}
+
 // PaymentRelatedInformationField returns a zero padded PaymentRelatedInformation string
 func (addenda05 *Addenda05) PaymentRelatedInformationField() string {
 	return addenda05.alphaField(addenda05.PaymentRelatedInformation, 80)
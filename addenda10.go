@@ -19,7 +19,6 @@ package ach
 
 import (
 	"strings"
-	"unicode/utf8"
 )
 
 // Addenda10 is an addenda which provides business transaction information for Addenda Type
@@ -56,6 +55,8 @@ type Addenda10 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 // NewAddenda10 returns a new Addenda10 with default values for none exported fields
@@ -69,78 +70,48 @@ func NewAddenda10() *Addenda10 {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (addenda10 *Addenda10) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			// 2-3 Always 10
-			addenda10.TypeCode = reset()
-		case 6:
-			// 04-06 Describes the type of payment
-			addenda10.TransactionTypeCode = reset()
-		case 24:
-			// 07-24 Payment Amount	For inbound IAT payments this field should contain the USD amount or may be blank.
-			addenda10.ForeignPaymentAmount = addenda10.parseNumField(reset())
-		case 46:
-			//  25-46 Insert blanks or zeros
-			addenda10.ForeignTraceNumber = strings.TrimSpace(reset())
-		case 81:
-			// 47-81 Receiving Company Name/Individual Name
-			addenda10.Name = strings.TrimSpace(reset())
-		case 87:
-			// 82-87 reserved - Leave blank
-			reset()
-		case 94:
-			// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
-			addenda10.EntryDetailSequenceNumber = addenda10.parseNumField(reset())
-		}
-	}
+	// 1-1 Always 7
+	// 2-3 Always 10
+	addenda10.TypeCode = fields.field(1, 3)
+	// 04-06 Describes the type of payment
+	addenda10.TransactionTypeCode = fields.field(3, 6)
+	// 07-24 Payment Amount	For inbound IAT payments this field should contain the USD amount or may be blank.
+	addenda10.ForeignPaymentAmount = addenda10.parseNumField(fields.field(6, 24))
+	//  25-46 Insert blanks or zeros
+	addenda10.ForeignTraceNumber = strings.TrimSpace(fields.field(24, 46))
+	// 47-81 Receiving Company Name/Individual Name
+	addenda10.Name = strings.TrimSpace(fields.field(46, 81))
+	// 82-87 reserved - Leave blank
+	// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
+	addenda10.EntryDetailSequenceNumber = addenda10.parseNumField(fields.field(87, 94))
 }
 
 // String writes the Addenda10 struct to a 94 character string.
 func (addenda10 *Addenda10) String() string {
+	return string(addenda10.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the Addenda10 record to dst and returns the extended buffer.
+func (addenda10 *Addenda10) AppendTo(dst []byte) []byte {
 	if addenda10 == nil {
-		return ""
+		return dst
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(addenda10.TypeCode)
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, addenda10.TypeCode...)
 	// TransactionTypeCode Validator
-	buf.WriteString(addenda10.TransactionTypeCode)
-	buf.WriteString(addenda10.ForeignPaymentAmountField())
-	buf.WriteString(addenda10.ForeignTraceNumberField())
-	buf.WriteString(addenda10.NameField())
-	buf.WriteString("      ")
-	buf.WriteString(addenda10.EntryDetailSequenceNumberField())
-
-	return buf.String()
+	dst = append(dst, addenda10.TransactionTypeCode...)
+	dst = addenda10.appendNumericField(dst, addenda10.ForeignPaymentAmount, 18)
+	dst = addenda10.appendAlphaField(dst, addenda10.ForeignTraceNumber, 22)
+	dst = addenda10.appendAlphaField(dst, addenda10.Name, 35)
+	dst = append(dst, "      "...)
+	dst = addenda10.appendNumericField(dst, addenda10.EntryDetailSequenceNumber, 7)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -199,6 +170,36 @@ func (addenda10 *Addenda10) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (addenda10 *Addenda10) validateAll() []error {
+	if addenda10 == nil {
+		return nil
+	}
+
+	var errs fieldErrors
+	if addenda10.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, addenda10.TypeCode)
+	}
+	if addenda10.TransactionTypeCode == "" {
+		errs.add("TransactionTypeCode", ErrFieldRequired, addenda10.TransactionTypeCode)
+	}
+	if addenda10.Name == "" {
+		errs.add("Name", ErrConstructor, addenda10.Name)
+	}
+	if addenda10.EntryDetailSequenceNumber == 0 {
+		errs.add("EntryDetailSequenceNumber", ErrConstructor, addenda10.EntryDetailSequenceNumberField())
+	}
+	errs.add("TypeCode", addenda10.isTypeCode(addenda10.TypeCode), addenda10.TypeCode)
+	if addenda10.TypeCode != "10" {
+		errs.add("TypeCode", ErrAddendaTypeCode, addenda10.TypeCode)
+	}
+	errs.add("TransactionTypeCode", addenda10.isTransactionTypeCode(addenda10.TransactionTypeCode), addenda10.TransactionTypeCode)
+	errs.add("ForeignTraceNumber", addenda10.isAlphanumeric(addenda10.ForeignTraceNumber), addenda10.ForeignTraceNumber)
+	errs.add("Name", addenda10.isAlphanumeric(addenda10.Name), addenda10.Name)
+	return errs
+}
+
 // ForeignPaymentAmountField returns ForeignPaymentAmount zero padded
 // ToDo: Review/Add logic for blank ?
 func (addenda10 *Addenda10) ForeignPaymentAmountField() string {
@@ -19,7 +19,6 @@ package ach
 
 import (
 	"strings"
-	"unicode/utf8"
 )
 
 // Addenda11 is an addenda which provides business transaction information for Addenda Type
@@ -47,6 +46,8 @@ type Addenda11 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 // NewAddenda11 returns a new Addenda11 with default values for none exported fields
@@ -60,69 +61,41 @@ func NewAddenda11() *Addenda11 {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (addenda11 *Addenda11) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			// 2-3 Always 11
-			addenda11.TypeCode = reset()
-		case 38:
-			// 4-38
-			addenda11.OriginatorName = strings.TrimSpace(reset())
-		case 73:
-			// 39-73
-			addenda11.OriginatorStreetAddress = strings.TrimSpace(reset())
-		case 87:
-			// 74-87 reserved - Leave blank
-			reset()
-		case 94:
-			// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
-			addenda11.EntryDetailSequenceNumber = addenda11.parseNumField(reset())
-		}
-	}
+	// 1-1 Always 7
+	// 2-3 Always 11
+	addenda11.TypeCode = fields.field(1, 3)
+	// 4-38
+	addenda11.OriginatorName = strings.TrimSpace(fields.field(3, 38))
+	// 39-73
+	addenda11.OriginatorStreetAddress = strings.TrimSpace(fields.field(38, 73))
+	// 74-87 reserved - Leave blank
+	// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
+	addenda11.EntryDetailSequenceNumber = addenda11.parseNumField(fields.field(87, 94))
 }
 
 // String writes the Addenda11 struct to a 94 character string.
 func (addenda11 *Addenda11) String() string {
+	return string(addenda11.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the Addenda11 record to dst and returns the extended buffer.
+func (addenda11 *Addenda11) AppendTo(dst []byte) []byte {
 	if addenda11 == nil {
-		return ""
+		return dst
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(addenda11.TypeCode)
-	buf.WriteString(addenda11.OriginatorNameField())
-	buf.WriteString(addenda11.OriginatorStreetAddressField())
-	buf.WriteString("              ")
-	buf.WriteString(addenda11.EntryDetailSequenceNumberField())
-
-	return buf.String()
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, addenda11.TypeCode...)
+	dst = addenda11.appendAlphaField(dst, addenda11.OriginatorName, 35)
+	dst = addenda11.appendAlphaField(dst, addenda11.OriginatorStreetAddress, 35)
+	dst = append(dst, "              "...)
+	dst = addenda11.appendNumericField(dst, addenda11.EntryDetailSequenceNumber, 7)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -173,6 +146,35 @@ func (addenda11 *Addenda11) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (addenda11 *Addenda11) validateAll() []error {
+	if addenda11 == nil {
+		return nil
+	}
+
+	var errs fieldErrors
+	if addenda11.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, addenda11.TypeCode)
+	}
+	if addenda11.OriginatorName == "" {
+		errs.add("OriginatorName", ErrConstructor, addenda11.OriginatorName)
+	}
+	if addenda11.OriginatorStreetAddress == "" {
+		errs.add("OriginatorStreetAddress", ErrConstructor, addenda11.OriginatorStreetAddress)
+	}
+	if addenda11.EntryDetailSequenceNumber == 0 {
+		errs.add("EntryDetailSequenceNumber", ErrConstructor, addenda11.EntryDetailSequenceNumberField())
+	}
+	errs.add("TypeCode", addenda11.isTypeCode(addenda11.TypeCode), addenda11.TypeCode)
+	if addenda11.TypeCode != "11" {
+		errs.add("TypeCode", ErrAddendaTypeCode, addenda11.TypeCode)
+	}
+	errs.add("OriginatorName", addenda11.isAlphanumeric(addenda11.OriginatorName), addenda11.OriginatorName)
+	errs.add("OriginatorStreetAddress", addenda11.isAlphanumeric(addenda11.OriginatorStreetAddress), addenda11.OriginatorStreetAddress)
+	return errs
+}
+
 // OriginatorNameField gets the OriginatorName field - Originator Company Name/Individual Name left padded
 func (addenda11 *Addenda11) OriginatorNameField() string {
 	return addenda11.alphaField(addenda11.OriginatorName, 35)
@@ -19,7 +19,6 @@ package ach
 
 import (
 	"strings"
-	"unicode/utf8"
 )
 
 // Addenda12 is an addenda which provides business transaction information for Addenda Type
@@ -52,6 +51,8 @@ type Addenda12 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 // NewAddenda12 returns a new Addenda12 with default values for none exported fields
@@ -65,70 +66,42 @@ func NewAddenda12() *Addenda12 {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (addenda12 *Addenda12) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			// 2-3 Always 12
-			addenda12.TypeCode = reset()
-		case 38:
-			// 4-38
-			addenda12.OriginatorCityStateProvince = strings.TrimSpace(reset())
-		case 73:
-			// 39-73
-			addenda12.OriginatorCountryPostalCode = strings.TrimSpace(reset())
-		case 87:
-			// 74-87 reserved - Leave blank
-			reset()
-		case 94:
-			// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
-			addenda12.EntryDetailSequenceNumber = addenda12.parseNumField(reset())
-		}
-	}
+	// 1-1 Always 7
+	// 2-3 Always 12
+	addenda12.TypeCode = fields.field(1, 3)
+	// 4-38
+	addenda12.OriginatorCityStateProvince = strings.TrimSpace(fields.field(3, 38))
+	// 39-73
+	addenda12.OriginatorCountryPostalCode = strings.TrimSpace(fields.field(38, 73))
+	// 74-87 reserved - Leave blank
+	// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
+	addenda12.EntryDetailSequenceNumber = addenda12.parseNumField(fields.field(87, 94))
 }
 
 // String writes the Addenda12 struct to a 94 character string.
 func (addenda12 *Addenda12) String() string {
+	return string(addenda12.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the Addenda12 record to dst and returns the extended buffer.
+func (addenda12 *Addenda12) AppendTo(dst []byte) []byte {
 	if addenda12 == nil {
-		return ""
+		return dst
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(addenda12.TypeCode)
-	buf.WriteString(addenda12.OriginatorCityStateProvinceField())
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, addenda12.TypeCode...)
+	dst = addenda12.appendAlphaField(dst, addenda12.OriginatorCityStateProvince, 35)
 	// ToDo Validator for backslash
-	buf.WriteString(addenda12.OriginatorCountryPostalCodeField())
-	buf.WriteString("              ")
-	buf.WriteString(addenda12.EntryDetailSequenceNumberField())
-
-	return buf.String()
+	dst = addenda12.appendAlphaField(dst, addenda12.OriginatorCountryPostalCode, 35)
+	dst = append(dst, "              "...)
+	dst = addenda12.appendNumericField(dst, addenda12.EntryDetailSequenceNumber, 7)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -179,6 +152,35 @@ func (addenda12 *Addenda12) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (addenda12 *Addenda12) validateAll() []error {
+	if addenda12 == nil {
+		return nil
+	}
+
+	var errs fieldErrors
+	if addenda12.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, addenda12.TypeCode)
+	}
+	if addenda12.OriginatorCityStateProvince == "" {
+		errs.add("OriginatorCityStateProvince", ErrConstructor, addenda12.OriginatorCityStateProvince)
+	}
+	if addenda12.OriginatorCountryPostalCode == "" {
+		errs.add("OriginatorCountryPostalCode", ErrConstructor, addenda12.OriginatorCountryPostalCode)
+	}
+	if addenda12.EntryDetailSequenceNumber == 0 {
+		errs.add("EntryDetailSequenceNumber", ErrConstructor, addenda12.EntryDetailSequenceNumberField())
+	}
+	errs.add("TypeCode", addenda12.isTypeCode(addenda12.TypeCode), addenda12.TypeCode)
+	if addenda12.TypeCode != "12" {
+		errs.add("TypeCode", ErrAddendaTypeCode, addenda12.TypeCode)
+	}
+	errs.add("OriginatorCityStateProvince", addenda12.isAlphanumeric(addenda12.OriginatorCityStateProvince), addenda12.OriginatorCityStateProvince)
+	errs.add("OriginatorCountryPostalCode", addenda12.isAlphanumeric(addenda12.OriginatorCountryPostalCode), addenda12.OriginatorCountryPostalCode)
+	return errs
+}
+
 // OriginatorCityStateProvinceField gets the OriginatorCityStateProvinceField left padded
 func (addenda12 *Addenda12) OriginatorCityStateProvinceField() string {
 	return addenda12.alphaField(addenda12.OriginatorCityStateProvince, 35)
@@ -19,7 +19,6 @@ package ach
 
 import (
 	"strings"
-	"unicode/utf8"
 )
 
 // Addenda13 is an addenda which provides business transaction information for Addenda Type
@@ -68,6 +67,8 @@ type Addenda13 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 // NewAddenda13 returns a new Addenda13 with default values for none exported fields
@@ -81,77 +82,47 @@ func NewAddenda13() *Addenda13 {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (addenda13 *Addenda13) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			// 2-3 Always 13
-			addenda13.TypeCode = reset()
-		case 38:
-			// 4-38 ODFIName
-			addenda13.ODFIName = strings.TrimSpace(reset())
-		case 40:
-			// 39-40 ODFIIDNumberQualifier
-			addenda13.ODFIIDNumberQualifier = reset()
-		case 74:
-			// 41-74 ODFIIdentification
-			addenda13.ODFIIdentification = addenda13.parseStringField(reset())
-		case 77:
-			// 75-77
-			addenda13.ODFIBranchCountryCode = strings.TrimSpace(reset())
-		case 87:
-			// 78-87 reserved - Leave blank
-			reset()
-		case 94:
-			// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
-			addenda13.EntryDetailSequenceNumber = addenda13.parseNumField(reset())
-		}
-	}
+	// 1-1 Always 7
+	// 2-3 Always 13
+	addenda13.TypeCode = fields.field(1, 3)
+	// 4-38 ODFIName
+	addenda13.ODFIName = strings.TrimSpace(fields.field(3, 38))
+	// 39-40 ODFIIDNumberQualifier
+	addenda13.ODFIIDNumberQualifier = fields.field(38, 40)
+	// 41-74 ODFIIdentification
+	addenda13.ODFIIdentification = addenda13.parseStringField(fields.field(40, 74))
+	// 75-77
+	addenda13.ODFIBranchCountryCode = strings.TrimSpace(fields.field(74, 77))
+	// 78-87 reserved - Leave blank
+	// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
+	addenda13.EntryDetailSequenceNumber = addenda13.parseNumField(fields.field(87, 94))
 }
 
 // String writes the Addenda13 struct to a 94 character string.
 func (addenda13 *Addenda13) String() string {
-	if addenda13 == nil {
-		return ""
-	}
-
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(addenda13.TypeCode)
-	buf.WriteString(addenda13.ODFINameField())
-	buf.WriteString(addenda13.ODFIIDNumberQualifierField())
-	buf.WriteString(addenda13.ODFIIdentificationField())
-	buf.WriteString(addenda13.ODFIBranchCountryCodeField())
-	buf.WriteString("          ")
-	buf.WriteString(addenda13.EntryDetailSequenceNumberField())
+	return string(addenda13.AppendTo(make([]byte, 0, RecordLength)))
+}
 
-	return buf.String()
+// AppendTo appends the Addenda13 record to dst and returns the extended buffer.
+func (addenda13 *Addenda13) AppendTo(dst []byte) []byte {
+	if addenda13 == nil {
+		return dst
+	}
+
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, addenda13.TypeCode...)
+	dst = addenda13.appendAlphaField(dst, addenda13.ODFIName, 35)
+	dst = addenda13.appendAlphaField(dst, addenda13.ODFIIDNumberQualifier, 2)
+	dst = addenda13.appendAlphaField(dst, addenda13.ODFIIdentification, 34)
+	dst = addenda13.appendAlphaField(dst, addenda13.ODFIBranchCountryCode, 3)
+	dst = append(dst, "          "...)
+	dst = addenda13.appendNumericField(dst, addenda13.EntryDetailSequenceNumber, 7)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -215,6 +186,43 @@ func (addenda13 *Addenda13) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (addenda13 *Addenda13) validateAll() []error {
+	if addenda13 == nil {
+		return nil
+	}
+
+	var errs fieldErrors
+	if addenda13.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, addenda13.TypeCode)
+	}
+	if addenda13.ODFIName == "" {
+		errs.add("ODFIName", ErrConstructor, addenda13.ODFIName)
+	}
+	if addenda13.ODFIIDNumberQualifier == "" {
+		errs.add("ODFIIDNumberQualifier", ErrConstructor, addenda13.ODFIIDNumberQualifier)
+	}
+	if addenda13.ODFIIdentification == "" {
+		errs.add("ODFIIdentification", ErrConstructor, addenda13.ODFIIdentification)
+	}
+	if addenda13.ODFIBranchCountryCode == "" {
+		errs.add("ODFIBranchCountryCode", ErrConstructor, addenda13.ODFIBranchCountryCode)
+	}
+	if addenda13.EntryDetailSequenceNumber == 0 {
+		errs.add("EntryDetailSequenceNumber", ErrConstructor, addenda13.EntryDetailSequenceNumberField())
+	}
+	errs.add("TypeCode", addenda13.isTypeCode(addenda13.TypeCode), addenda13.TypeCode)
+	if addenda13.TypeCode != "13" {
+		errs.add("TypeCode", ErrAddendaTypeCode, addenda13.TypeCode)
+	}
+	errs.add("ODFIName", addenda13.isAlphanumeric(addenda13.ODFIName), addenda13.ODFIName)
+	errs.add("ODFIIDNumberQualifier", addenda13.isIDNumberQualifier(addenda13.ODFIIDNumberQualifier), addenda13.ODFIIDNumberQualifier)
+	errs.add("ODFIIdentification", addenda13.isAlphanumeric(addenda13.ODFIIdentification), addenda13.ODFIIdentification)
+	errs.add("ODFIBranchCountryCode", addenda13.isAlphanumeric(addenda13.ODFIBranchCountryCode), addenda13.ODFIBranchCountryCode)
+	return errs
+}
+
 // ODFINameField gets the ODFIName field left padded
 func (addenda13 *Addenda13) ODFINameField() string {
 	return addenda13.alphaField(addenda13.ODFIName, 35)
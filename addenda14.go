@@ -19,7 +19,6 @@ package ach
 
 import (
 	"strings"
-	"unicode/utf8"
 )
 
 // Addenda14 is an addenda which provides business transaction information for Addenda Type
@@ -63,6 +62,8 @@ type Addenda14 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 // NewAddenda14 returns a new Addenda14 with default values for none exported fields
@@ -76,77 +77,47 @@ func NewAddenda14() *Addenda14 {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (addenda14 *Addenda14) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			// 2-3 Always 14
-			addenda14.TypeCode = reset()
-		case 38:
-			// 4-38 RDFIName
-			addenda14.RDFIName = strings.TrimSpace(reset())
-		case 40:
-			// 39-40 RDFIIDNumberQualifier
-			addenda14.RDFIIDNumberQualifier = reset()
-		case 74:
-			// 41-74 RDFIIdentification
-			addenda14.RDFIIdentification = addenda14.parseStringField(reset())
-		case 77:
-			// 75-77
-			addenda14.RDFIBranchCountryCode = strings.TrimSpace(reset())
-		case 87:
-			// 78-87 reserved - Leave blank
-			reset()
-		case 94:
-			// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
-			addenda14.EntryDetailSequenceNumber = addenda14.parseNumField(reset())
-		}
-	}
+	// 1-1 Always 7
+	// 2-3 Always 14
+	addenda14.TypeCode = fields.field(1, 3)
+	// 4-38 RDFIName
+	addenda14.RDFIName = strings.TrimSpace(fields.field(3, 38))
+	// 39-40 RDFIIDNumberQualifier
+	addenda14.RDFIIDNumberQualifier = fields.field(38, 40)
+	// 41-74 RDFIIdentification
+	addenda14.RDFIIdentification = addenda14.parseStringField(fields.field(40, 74))
+	// 75-77
+	addenda14.RDFIBranchCountryCode = strings.TrimSpace(fields.field(74, 77))
+	// 78-87 reserved - Leave blank
+	// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
+	addenda14.EntryDetailSequenceNumber = addenda14.parseNumField(fields.field(87, 94))
 }
 
 // String writes the Addenda14 struct to a 94 character string.
 func (addenda14 *Addenda14) String() string {
-	if addenda14 == nil {
-		return ""
-	}
-
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(addenda14.TypeCode)
-	buf.WriteString(addenda14.RDFINameField())
-	buf.WriteString(addenda14.RDFIIDNumberQualifierField())
-	buf.WriteString(addenda14.RDFIIdentificationField())
-	buf.WriteString(addenda14.RDFIBranchCountryCodeField())
-	buf.WriteString("          ")
-	buf.WriteString(addenda14.EntryDetailSequenceNumberField())
+	return string(addenda14.AppendTo(make([]byte, 0, RecordLength)))
+}
 
-	return buf.String()
+// AppendTo appends the Addenda14 record to dst and returns the extended buffer.
+func (addenda14 *Addenda14) AppendTo(dst []byte) []byte {
+	if addenda14 == nil {
+		return dst
+	}
+
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, addenda14.TypeCode...)
+	dst = addenda14.appendAlphaField(dst, addenda14.RDFIName, 35)
+	dst = addenda14.appendAlphaField(dst, addenda14.RDFIIDNumberQualifier, 2)
+	dst = addenda14.appendAlphaField(dst, addenda14.RDFIIdentification, 34)
+	dst = addenda14.appendAlphaField(dst, addenda14.RDFIBranchCountryCode, 3)
+	dst = append(dst, "          "...)
+	dst = addenda14.appendNumericField(dst, addenda14.EntryDetailSequenceNumber, 7)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -210,6 +181,45 @@ func (addenda14 *Addenda14) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (addenda14 *Addenda14) validateAll() []error {
+	if addenda14 == nil {
+		return nil
+	}
+
+	var errs fieldErrors
+	if addenda14.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, addenda14.TypeCode)
+	}
+	if addenda14.RDFIName == "" {
+		errs.add("RDFIName", ErrConstructor, addenda14.RDFIName)
+	}
+	if addenda14.RDFIIDNumberQualifier == "" {
+		errs.add("RDFIIDNumberQualifier", ErrConstructor, addenda14.RDFIIDNumberQualifier)
+	}
+	if addenda14.RDFIIdentification == "" {
+		errs.add("RDFIIdentification", ErrConstructor, addenda14.RDFIIdentification)
+	}
+	if addenda14.RDFIBranchCountryCode == "" {
+		errs.add("RDFIBranchCountryCode", ErrConstructor, addenda14.RDFIBranchCountryCode)
+	}
+	if addenda14.EntryDetailSequenceNumber == 0 {
+		errs.add("EntryDetailSequenceNumber", ErrConstructor, addenda14.EntryDetailSequenceNumberField())
+	}
+	errs.add("TypeCode", addenda14.isTypeCode(addenda14.TypeCode), addenda14.TypeCode)
+	if addenda14.TypeCode != "14" {
+		errs.add("TypeCode", ErrAddendaTypeCode, addenda14.TypeCode)
+	}
+	errs.add("RDFIName", addenda14.isAlphanumeric(addenda14.RDFIName), addenda14.RDFIName)
+	if err := addenda14.isIDNumberQualifier(addenda14.RDFIIDNumberQualifier); err != nil {
+		errs.add("RDFIIDNumberQualifier", ErrIDNumberQualifier, addenda14.RDFIIDNumberQualifier)
+	}
+	errs.add("RDFIIdentification", addenda14.isAlphanumeric(addenda14.RDFIIdentification), addenda14.RDFIIdentification)
+	errs.add("RDFIBranchCountryCode", addenda14.isAlphanumeric(addenda14.RDFIBranchCountryCode), addenda14.RDFIBranchCountryCode)
+	return errs
+}
+
 // RDFINameField gets the RDFIName field left padded
 func (addenda14 *Addenda14) RDFINameField() string {
 	return addenda14.alphaField(addenda14.RDFIName, 35)
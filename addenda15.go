@@ -19,7 +19,6 @@ package ach
 
 import (
 	"strings"
-	"unicode/utf8"
 )
 
 // Addenda15 is an addenda which provides business transaction information for Addenda Type
@@ -48,6 +47,8 @@ type Addenda15 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 // NewAddenda15 returns a new Addenda15 with default values for none exported fields
@@ -61,69 +62,41 @@ func NewAddenda15() *Addenda15 {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (addenda15 *Addenda15) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			// 2-3 Always 15
-			addenda15.TypeCode = reset()
-		case 18:
-			// 4-18
-			addenda15.ReceiverIDNumber = addenda15.parseStringField(reset())
-		case 53:
-			// 19-53
-			addenda15.ReceiverStreetAddress = strings.TrimSpace(reset())
-		case 87:
-			// 54-87 reserved - Leave blank
-			reset()
-		case 94:
-			// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
-			addenda15.EntryDetailSequenceNumber = addenda15.parseNumField(reset())
-		}
-	}
+	// 1-1 Always 7
+	// 2-3 Always 15
+	addenda15.TypeCode = fields.field(1, 3)
+	// 4-18
+	addenda15.ReceiverIDNumber = addenda15.parseStringField(fields.field(3, 18))
+	// 19-53
+	addenda15.ReceiverStreetAddress = strings.TrimSpace(fields.field(18, 53))
+	// 54-87 reserved - Leave blank
+	// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
+	addenda15.EntryDetailSequenceNumber = addenda15.parseNumField(fields.field(87, 94))
 }
 
 // String writes the Addenda15 struct to a 94 character string.
 func (addenda15 *Addenda15) String() string {
+	return string(addenda15.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the Addenda15 record to dst and returns the extended buffer.
+func (addenda15 *Addenda15) AppendTo(dst []byte) []byte {
 	if addenda15 == nil {
-		return ""
+		return dst
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(addenda15.TypeCode)
-	buf.WriteString(addenda15.ReceiverIDNumberField())
-	buf.WriteString(addenda15.ReceiverStreetAddressField())
-	buf.WriteString("                                  ")
-	buf.WriteString(addenda15.EntryDetailSequenceNumberField())
-
-	return buf.String()
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, addenda15.TypeCode...)
+	dst = addenda15.appendAlphaField(dst, addenda15.ReceiverIDNumber, 15)
+	dst = addenda15.appendAlphaField(dst, addenda15.ReceiverStreetAddress, 35)
+	dst = append(dst, "                                  "...)
+	dst = addenda15.appendNumericField(dst, addenda15.EntryDetailSequenceNumber, 7)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -171,6 +144,32 @@ func (addenda15 *Addenda15) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (addenda15 *Addenda15) validateAll() []error {
+	if addenda15 == nil {
+		return nil
+	}
+
+	var errs fieldErrors
+	if addenda15.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, addenda15.TypeCode)
+	}
+	if addenda15.ReceiverStreetAddress == "" {
+		errs.add("ReceiverStreetAddress", ErrConstructor, addenda15.ReceiverStreetAddress)
+	}
+	if addenda15.EntryDetailSequenceNumber == 0 {
+		errs.add("EntryDetailSequenceNumber", ErrConstructor, addenda15.EntryDetailSequenceNumberField())
+	}
+	errs.add("TypeCode", addenda15.isTypeCode(addenda15.TypeCode), addenda15.TypeCode)
+	if addenda15.TypeCode != "15" {
+		errs.add("TypeCode", ErrAddendaTypeCode, addenda15.TypeCode)
+	}
+	errs.add("ReceiverIDNumber", addenda15.isAlphanumeric(addenda15.ReceiverIDNumber), addenda15.ReceiverIDNumber)
+	errs.add("ReceiverStreetAddress", addenda15.isAlphanumeric(addenda15.ReceiverStreetAddress), addenda15.ReceiverStreetAddress)
+	return errs
+}
+
 // ReceiverIDNumberField gets the ReceiverIDNumber field left padded
 func (addenda15 *Addenda15) ReceiverIDNumberField() string {
 	return addenda15.alphaField(addenda15.ReceiverIDNumber, 15)
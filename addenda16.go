@@ -19,7 +19,6 @@ package ach
 
 import (
 	"strings"
-	"unicode/utf8"
 )
 
 // Addenda16 is an addenda which provides business transaction information for Addenda Type
@@ -51,6 +50,8 @@ type Addenda16 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 // NewAddenda16 returns a new Addenda16 with default values for none exported fields
@@ -64,69 +65,41 @@ func NewAddenda16() *Addenda16 {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (addenda16 *Addenda16) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			// 2-3 Always 16
-			addenda16.TypeCode = reset()
-		case 38:
-			// 4-38 ReceiverCityStateProvince
-			addenda16.ReceiverCityStateProvince = strings.TrimSpace(reset())
-		case 73:
-			// 39-73 ReceiverCountryPostalCode
-			addenda16.ReceiverCountryPostalCode = strings.TrimSpace(reset())
-		case 87:
-			// 74-87 reserved - Leave blank
-			reset()
-		case 94:
-			// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
-			addenda16.EntryDetailSequenceNumber = addenda16.parseNumField(reset())
-		}
-	}
+	// 1-1 Always 7
+	// 2-3 Always 16
+	addenda16.TypeCode = fields.field(1, 3)
+	// 4-38 ReceiverCityStateProvince
+	addenda16.ReceiverCityStateProvince = strings.TrimSpace(fields.field(3, 38))
+	// 39-73 ReceiverCountryPostalCode
+	addenda16.ReceiverCountryPostalCode = strings.TrimSpace(fields.field(38, 73))
+	// 74-87 reserved - Leave blank
+	// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
+	addenda16.EntryDetailSequenceNumber = addenda16.parseNumField(fields.field(87, 94))
 }
 
 // String writes the Addenda16 struct to a 94 character string.
 func (addenda16 *Addenda16) String() string {
+	return string(addenda16.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the Addenda16 record to dst and returns the extended buffer.
+func (addenda16 *Addenda16) AppendTo(dst []byte) []byte {
 	if addenda16 == nil {
-		return ""
+		return dst
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(addenda16.TypeCode)
-	buf.WriteString(addenda16.ReceiverCityStateProvinceField())
-	buf.WriteString(addenda16.ReceiverCountryPostalCodeField())
-	buf.WriteString("              ")
-	buf.WriteString(addenda16.EntryDetailSequenceNumberField())
-
-	return buf.String()
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, addenda16.TypeCode...)
+	dst = addenda16.appendAlphaField(dst, addenda16.ReceiverCityStateProvince, 35)
+	dst = addenda16.appendAlphaField(dst, addenda16.ReceiverCountryPostalCode, 35)
+	dst = append(dst, "              "...)
+	dst = addenda16.appendNumericField(dst, addenda16.EntryDetailSequenceNumber, 7)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -177,6 +150,35 @@ func (addenda16 *Addenda16) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (addenda16 *Addenda16) validateAll() []error {
+	if addenda16 == nil {
+		return nil
+	}
+
+	var errs fieldErrors
+	if addenda16.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, addenda16.TypeCode)
+	}
+	if addenda16.ReceiverCityStateProvince == "" {
+		errs.add("ReceiverCityStateProvince", ErrConstructor, addenda16.ReceiverCityStateProvince)
+	}
+	if addenda16.ReceiverCountryPostalCode == "" {
+		errs.add("ReceiverCountryPostalCode", ErrConstructor, addenda16.ReceiverCountryPostalCode)
+	}
+	if addenda16.EntryDetailSequenceNumber == 0 {
+		errs.add("EntryDetailSequenceNumber", ErrConstructor, addenda16.EntryDetailSequenceNumberField())
+	}
+	errs.add("TypeCode", addenda16.isTypeCode(addenda16.TypeCode), addenda16.TypeCode)
+	if addenda16.TypeCode != "16" {
+		errs.add("TypeCode", ErrAddendaTypeCode, addenda16.TypeCode)
+	}
+	errs.add("ReceiverCityStateProvince", addenda16.isAlphanumeric(addenda16.ReceiverCityStateProvince), addenda16.ReceiverCityStateProvince)
+	errs.add("ReceiverCountryPostalCode", addenda16.isAlphanumeric(addenda16.ReceiverCountryPostalCode), addenda16.ReceiverCountryPostalCode)
+	return errs
+}
+
 // ReceiverCityStateProvinceField gets the ReceiverCityStateProvinceField left padded
 func (addenda16 *Addenda16) ReceiverCityStateProvinceField() string {
 	return addenda16.alphaField(addenda16.ReceiverCityStateProvince, 35)
@@ -19,7 +19,6 @@ package ach
 
 import (
 	"strings"
-	"unicode/utf8"
 )
 
 // Addenda17 is an addenda which provides business transaction information for Addenda Type
@@ -49,6 +48,8 @@ type Addenda17 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 // NewAddenda17 returns a new Addenda17 with default values for none exported fields
@@ -62,65 +63,39 @@ func NewAddenda17() *Addenda17 {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (addenda17 *Addenda17) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			// 2-3 Always 17
-			addenda17.TypeCode = reset()
-		case 83:
-			// 4-83 Based on the information entered (04-83) 80 alphanumeric
-			addenda17.PaymentRelatedInformation = strings.TrimSpace(reset())
-		case 87:
-			// 84-87 SequenceNumber is consecutively assigned to each Addenda17 Record following an Entry Detail Record
-			addenda17.SequenceNumber = addenda17.parseNumField(reset())
-		case 94:
-			// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
-			addenda17.EntryDetailSequenceNumber = addenda17.parseNumField(reset())
-		}
-	}
+	// 1-1 Always 7
+	// 2-3 Always 17
+	addenda17.TypeCode = fields.field(1, 3)
+	// 4-83 Based on the information entered (04-83) 80 alphanumeric
+	addenda17.PaymentRelatedInformation = strings.TrimSpace(fields.field(3, 83))
+	// 84-87 SequenceNumber is consecutively assigned to each Addenda17 Record following an Entry Detail Record
+	addenda17.SequenceNumber = addenda17.parseNumField(fields.field(83, 87))
+	// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
+	addenda17.EntryDetailSequenceNumber = addenda17.parseNumField(fields.field(87, 94))
 }
 
 // String writes the Addenda17 struct to a 94 character string.
 func (addenda17 *Addenda17) String() string {
+	return string(addenda17.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the Addenda17 record to dst and returns the extended buffer.
+func (addenda17 *Addenda17) AppendTo(dst []byte) []byte {
 	if addenda17 == nil {
-		return ""
+		return dst
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(addenda17.TypeCode)
-	buf.WriteString(addenda17.PaymentRelatedInformationField())
-	buf.WriteString(addenda17.SequenceNumberField())
-	buf.WriteString(addenda17.EntryDetailSequenceNumberField())
-
-	return buf.String()
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, addenda17.TypeCode...)
+	dst = addenda17.appendAlphaField(dst, addenda17.PaymentRelatedInformation, 80)
+	dst = addenda17.appendNumericField(dst, addenda17.SequenceNumber, 4)
+	dst = addenda17.appendNumericField(dst, addenda17.EntryDetailSequenceNumber, 7)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -158,6 +133,27 @@ func (addenda17 *Addenda17) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (addenda17 *Addenda17) validateAll() []error {
+	var errs fieldErrors
+	if addenda17.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, addenda17.TypeCode)
+	}
+	if addenda17.SequenceNumber == 0 {
+		errs.add("SequenceNumber", ErrConstructor, addenda17.SequenceNumberField())
+	}
+	if addenda17.EntryDetailSequenceNumber == 0 {
+		errs.add("EntryDetailSequenceNumber", ErrConstructor, addenda17.EntryDetailSequenceNumberField())
+	}
+	errs.add("TypeCode", addenda17.isTypeCode(addenda17.TypeCode), addenda17.TypeCode)
+	if addenda17.TypeCode != "17" {
+		errs.add("TypeCode", ErrAddendaTypeCode, addenda17.TypeCode)
+	}
+	errs.add("PaymentRelatedInformation", addenda17.isAlphanumeric(addenda17.PaymentRelatedInformation), addenda17.PaymentRelatedInformation)
+	return errs
+}
+
 // PaymentRelatedInformationField returns a zero padded PaymentRelatedInformation string
 func (addenda17 *Addenda17) PaymentRelatedInformationField() string {
 	return addenda17.alphaField(addenda17.PaymentRelatedInformation, 80)
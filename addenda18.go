@@ -19,7 +19,6 @@ package ach
 
 import (
 	"strings"
-	"unicode/utf8"
 )
 
 // Addenda18 is an addenda which provides business transaction information for Addenda Type
@@ -65,6 +64,8 @@ type Addenda18 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 // NewAddenda18 returns a new Addenda18 with default values for none exported fields
@@ -78,84 +79,53 @@ func NewAddenda18() *Addenda18 {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (addenda18 *Addenda18) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			// 2-3 Always 18
-			addenda18.TypeCode = reset()
-		case 38:
-			// 4-38 Based on the information entered (04-38) 35 alphanumeric
-			addenda18.ForeignCorrespondentBankName = strings.TrimSpace(reset())
-		case 40:
-			// 39-40  Based on the information entered (39-40) 2 alphanumeric
-			// “01” = National Clearing System
-			// “02” = BIC Code
-			// “03” = IBAN Code
-			addenda18.ForeignCorrespondentBankIDNumberQualifier = reset()
-		case 74:
-			// 41-74 Based on the information entered (41-74) 34 alphanumeric
-			addenda18.ForeignCorrespondentBankIDNumber = strings.TrimSpace(reset())
-		case 77:
-			// 75-77 Based on the information entered (75-77) 3 alphanumeric
-			addenda18.ForeignCorrespondentBankBranchCountryCode = strings.TrimSpace(reset())
-		case 83:
-			// 78-83 - Blank space
-			reset()
-		case 87:
-			// 84-87 SequenceNumber is consecutively assigned to each Addenda18 Record following an Entry Detail Record
-			addenda18.SequenceNumber = addenda18.parseNumField(reset())
-		case 94:
-			// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
-			addenda18.EntryDetailSequenceNumber = addenda18.parseNumField(reset())
-		}
-	}
+	// 1-1 Always 7
+	// 2-3 Always 18
+	addenda18.TypeCode = fields.field(1, 3)
+	// 4-38 Based on the information entered (04-38) 35 alphanumeric
+	addenda18.ForeignCorrespondentBankName = strings.TrimSpace(fields.field(3, 38))
+	// 39-40  Based on the information entered (39-40) 2 alphanumeric
+	// “01” = National Clearing System
+	// “02” = BIC Code
+	// “03” = IBAN Code
+	addenda18.ForeignCorrespondentBankIDNumberQualifier = fields.field(38, 40)
+	// 41-74 Based on the information entered (41-74) 34 alphanumeric
+	addenda18.ForeignCorrespondentBankIDNumber = strings.TrimSpace(fields.field(40, 74))
+	// 75-77 Based on the information entered (75-77) 3 alphanumeric
+	addenda18.ForeignCorrespondentBankBranchCountryCode = strings.TrimSpace(fields.field(74, 77))
+	// 78-83 - Blank space
+	// 84-87 SequenceNumber is consecutively assigned to each Addenda18 Record following an Entry Detail Record
+	addenda18.SequenceNumber = addenda18.parseNumField(fields.field(83, 87))
+	// 88-94 Contains the last seven digits of the number entered in the Trace Number field in the corresponding Entry Detail Record
+	addenda18.EntryDetailSequenceNumber = addenda18.parseNumField(fields.field(87, 94))
 }
 
 // String writes the Addenda18 struct to a 94 character string.
 func (addenda18 *Addenda18) String() string {
-	if addenda18 == nil {
-		return ""
-	}
-
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(addenda18.TypeCode)
-	buf.WriteString(addenda18.ForeignCorrespondentBankNameField())
-	buf.WriteString(addenda18.ForeignCorrespondentBankIDNumberQualifierField())
-	buf.WriteString(addenda18.ForeignCorrespondentBankIDNumberField())
-	buf.WriteString(addenda18.ForeignCorrespondentBankBranchCountryCodeField())
-	buf.WriteString("      ")
-	buf.WriteString(addenda18.SequenceNumberField())
-	buf.WriteString(addenda18.EntryDetailSequenceNumberField())
+	return string(addenda18.AppendTo(make([]byte, 0, RecordLength)))
+}
 
-	return buf.String()
+// AppendTo appends the Addenda18 record to dst and returns the extended buffer.
+func (addenda18 *Addenda18) AppendTo(dst []byte) []byte {
+	if addenda18 == nil {
+		return dst
+	}
+
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, addenda18.TypeCode...)
+	dst = addenda18.appendAlphaField(dst, addenda18.ForeignCorrespondentBankName, 35)
+	dst = addenda18.appendAlphaField(dst, addenda18.ForeignCorrespondentBankIDNumberQualifier, 2)
+	dst = addenda18.appendAlphaField(dst, addenda18.ForeignCorrespondentBankIDNumber, 34)
+	dst = addenda18.appendAlphaField(dst, addenda18.ForeignCorrespondentBankBranchCountryCode, 3)
+	dst = append(dst, "      "...)
+	dst = addenda18.appendNumericField(dst, addenda18.SequenceNumber, 4)
+	dst = addenda18.appendNumericField(dst, addenda18.EntryDetailSequenceNumber, 7)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -213,6 +183,42 @@ func (addenda18 *Addenda18) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (addenda18 *Addenda18) validateAll() []error {
+	var errs fieldErrors
+	if addenda18.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, addenda18.TypeCode)
+	}
+	if addenda18.ForeignCorrespondentBankName == "" {
+		errs.add("ForeignCorrespondentBankName", ErrConstructor, addenda18.ForeignCorrespondentBankName)
+	}
+	if addenda18.ForeignCorrespondentBankIDNumberQualifier == "" {
+		errs.add("ForeignCorrespondentBankIDNumberQualifier", ErrConstructor, addenda18.ForeignCorrespondentBankIDNumberQualifier)
+	}
+	if addenda18.ForeignCorrespondentBankIDNumber == "" {
+		errs.add("ForeignCorrespondentBankIDNumber", ErrConstructor, addenda18.ForeignCorrespondentBankIDNumber)
+	}
+	if addenda18.ForeignCorrespondentBankBranchCountryCode == "" {
+		errs.add("ForeignCorrespondentBankBranchCountryCode", ErrConstructor, addenda18.ForeignCorrespondentBankBranchCountryCode)
+	}
+	if addenda18.SequenceNumber == 0 {
+		errs.add("SequenceNumber", ErrConstructor, addenda18.SequenceNumberField())
+	}
+	if addenda18.EntryDetailSequenceNumber == 0 {
+		errs.add("EntryDetailSequenceNumber", ErrConstructor, addenda18.EntryDetailSequenceNumberField())
+	}
+	errs.add("TypeCode", addenda18.isTypeCode(addenda18.TypeCode), addenda18.TypeCode)
+	if addenda18.TypeCode != "18" {
+		errs.add("TypeCode", ErrAddendaTypeCode, addenda18.TypeCode)
+	}
+	errs.add("ForeignCorrespondentBankName", addenda18.isAlphanumeric(addenda18.ForeignCorrespondentBankName), addenda18.ForeignCorrespondentBankName)
+	errs.add("ForeignCorrespondentBankIDNumberQualifier", addenda18.isAlphanumeric(addenda18.ForeignCorrespondentBankIDNumberQualifier), addenda18.ForeignCorrespondentBankIDNumberQualifier)
+	errs.add("ForeignCorrespondentBankIDNumber", addenda18.isAlphanumeric(addenda18.ForeignCorrespondentBankIDNumber), addenda18.ForeignCorrespondentBankIDNumber)
+	errs.add("ForeignCorrespondentBankBranchCountryCode", addenda18.isAlphanumeric(addenda18.ForeignCorrespondentBankBranchCountryCode), addenda18.ForeignCorrespondentBankBranchCountryCode)
+	return errs
+}
+
 // ForeignCorrespondentBankNameField returns a zero padded ForeignCorrespondentBankName string
 func (addenda18 *Addenda18) ForeignCorrespondentBankNameField() string {
 	return addenda18.alphaField(addenda18.ForeignCorrespondentBankName, 35)
@@ -55,6 +55,8 @@ type Addenda98 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 var (
@@ -86,83 +88,51 @@ func NewAddenda98() *Addenda98 {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (addenda98 *Addenda98) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			// 2-3 Always "98"
-			addenda98.TypeCode = reset()
-		case 6:
-			// 4-6
-			addenda98.ChangeCode = reset()
-		case 21:
-			// 7-21
-			addenda98.OriginalTrace = strings.TrimSpace(reset())
-		case 27:
-			reset()
-		case 35:
-			// 28-35
-			addenda98.OriginalDFI = addenda98.parseStringField(reset())
-		case 64:
-			// 36-64
-			addenda98.CorrectedData = strings.TrimSpace(reset())
-		case 70:
-			// 65-70 (Reserved for all except IAT Corrections)
-			addenda98.iatCorrectedData = strings.TrimSpace(reset())
-		case 79:
-			// Reserved
-			reset()
-		case 94:
-			// 80-94
-			addenda98.TraceNumber = strings.TrimSpace(reset())
-		}
-	}
+	// 1-1 Always 7
+	// 2-3 Always "98"
+	addenda98.TypeCode = fields.field(1, 3)
+	// 4-6
+	addenda98.ChangeCode = fields.field(3, 6)
+	// 7-21
+	addenda98.OriginalTrace = strings.TrimSpace(fields.field(6, 21))
+	// 28-35
+	addenda98.OriginalDFI = addenda98.parseStringField(fields.field(27, 35))
+	// 36-64
+	addenda98.CorrectedData = strings.TrimSpace(fields.field(35, 64))
+	// 65-70 (Reserved for all except IAT Corrections)
+	addenda98.iatCorrectedData = strings.TrimSpace(fields.field(64, 70))
+	// 71-79 Reserved
+	// 80-94
+	addenda98.TraceNumber = strings.TrimSpace(fields.field(79, 94))
 }
 
 // String writes the Addenda98 struct to a 94 character string
 func (addenda98 *Addenda98) String() string {
+	return string(addenda98.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the Addenda98 record to dst and returns the extended buffer.
+func (addenda98 *Addenda98) AppendTo(dst []byte) []byte {
 	if addenda98 == nil {
-		return ""
+		return dst
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(addenda98.TypeCode)
-	buf.WriteString(addenda98.ChangeCode)
-	buf.WriteString(addenda98.OriginalTraceField())
-	buf.WriteString("      ") // 6 char reserved field
-	buf.WriteString(addenda98.OriginalDFIField())
-	buf.WriteString(addenda98.CorrectedDataField())
-	buf.WriteString("               ") // 15 char reserved field
-	buf.WriteString(addenda98.TraceNumberField())
-
-	return buf.String()
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, addenda98.TypeCode...)
+	dst = append(dst, addenda98.ChangeCode...)
+	dst = addenda98.appendStringField(dst, addenda98.OriginalTrace, 15)
+	dst = append(dst, "      "...) // 6 char reserved field
+	dst = addenda98.appendStringField(dst, addenda98.OriginalDFI, 8)
+	dst = addenda98.appendAlphaField(dst, addenda98.CorrectedData, correctedDataCharLength)
+	dst = addenda98.appendAlphaField(dst, addenda98.iatCorrectedData, 6) // reserved except for IAT corrected data
+	dst = append(dst, "         "...)                                    // 9 char reserved field
+	dst = addenda98.appendStringField(dst, addenda98.TraceNumber, 15)
+	return dst
 }
 
 // Validate verifies NACHA rules for Addenda98
@@ -182,13 +152,33 @@ func (addenda98 *Addenda98) Validate() error {
 	}
 
 	// Addenda98 Record must contain the corrected information corresponding to the Change Code used
-	if addenda98.CorrectedData == "" {
+	// C13 (Addenda format error) carries no corrected data.
+	if addenda98.CorrectedData == "" && addenda98.ChangeCode != "C13" {
 		return fieldError("CorrectedData", ErrAddenda98CorrectedData, addenda98.CorrectedData)
 	}
 
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (addenda98 *Addenda98) validateAll() []error {
+	var errs fieldErrors
+	if addenda98.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, addenda98.TypeCode)
+	}
+	if addenda98.TypeCode != "98" {
+		errs.add("TypeCode", ErrAddendaTypeCode, addenda98.TypeCode)
+	}
+	if _, ok := changeCodeDict[addenda98.ChangeCode]; !ok {
+		errs.add("ChangeCode", ErrAddenda98ChangeCode, addenda98.ChangeCode)
+	}
+	if addenda98.CorrectedData == "" && addenda98.ChangeCode != "C13" {
+		errs.add("CorrectedData", ErrAddenda98CorrectedData, addenda98.CorrectedData)
+	}
+	return errs
+}
+
 // OriginalTraceField returns a zero padded OriginalTrace string
 func (addenda98 *Addenda98) OriginalTraceField() string {
 	return addenda98.stringField(addenda98.OriginalTrace, 15)
@@ -248,10 +238,12 @@ func makeChangeCodeDict() map[string]*ChangeCode {
 		{"C05", "Incorrect transaction code", "Entry posted to demand account should contain savings transaction codes or vice versa"},
 		{"C06", "Incorrect bank account number and transit code", "Bank account number must be changed and transaction code should indicate posting to another account type (demand/savings)"},
 		{"C07", "Incorrect transit/routing number, bank account number and transaction code", "Changes required in three fields indicated"},
+		{"C08", "Incorrect receiving DFI identification", "Receiving DFI identification of an IAT entry is incorrect"},
 		{"C09", "Incorrect individual ID number", "Individual's ID number is incorrect"},
 		{"C10", "Incorrect company name", "Company name is no longer valid and should be changed."},
 		{"C11", "Incorrect company identification", "Company ID is no longer valid and should be changed"},
 		{"C12", "Incorrect company name and company ID", "Both the company name and company id are no longer valid and must be changed"},
+		{"C13", "Addenda format error", "Entry was posted but information in the addenda record was unclear or formatted incorrectly"},
 		// Change codes used when refusing a Notification of Change
 		{"C61", "Misrouted Notification of Change", ""},
 		{"C62", "Incorrect Trace Number", ""},
@@ -284,10 +276,14 @@ func IsRefusedChangeCode(code string) bool {
 // All fields are optional and a valid code may not have populated data in this struct.
 type CorrectedData struct {
 	AccountNumber   string
-	RoutingNumber   string
+	RoutingNumber   string // or the foreign Receiving DFI Identification for C08
 	Name            string
 	TransactionCode int
 	Identification  string
+
+	// CompanyName and CompanyIdentification are corrections to the BatchHeader (C10, C11 and C12)
+	CompanyName           string
+	CompanyIdentification string
 }
 
 // ParseCorrectedData returns a struct with some fields filled in depending on the Addenda98's
@@ -353,10 +349,29 @@ func (addenda98 *Addenda98) ParseCorrectedData() *CorrectedData {
 		} else {
 			return nil
 		}
+	case "C08": // Incorrect Receiving DFI Identification (IAT only)
+		if v := first(iatCorrectedDataCharLength, data); v != "" {
+			return &CorrectedData{RoutingNumber: v}
+		}
 	case "C09": // Incorrect Individual Identification Number
 		if v := first(22, data); v != "" {
 			return &CorrectedData{Identification: v}
 		}
+	case "C10": // Incorrect Company Name
+		if v := first(16, data); v != "" {
+			return &CorrectedData{CompanyName: v}
+		}
+	case "C11": // Incorrect Company Identification
+		if v := first(10, data); v != "" {
+			return &CorrectedData{CompanyIdentification: v}
+		}
+	case "C12": // Incorrect Company Name and Company Identification
+		if utf8.RuneCountInString(data) > 16 {
+			name, id := first(16, data), first(10, data[16:])
+			if name != "" && id != "" {
+				return &CorrectedData{CompanyName: name, CompanyIdentification: id}
+			}
+		}
 	}
 	// The Code/Correction is either unsupported or wasn't parsed correctly
 	return nil
@@ -375,6 +390,10 @@ func first(size int, data string) string {
 
 const correctedDataCharLength = 29
 
+// iatCorrectedDataCharLength is the length of Corrected Data in IAT Correction Addenda records, which
+// continues into the 6 characters reserved in other Addenda98 records.
+const iatCorrectedDataCharLength = correctedDataCharLength + 6
+
 // ParseCorrectedData returns the string properlty formatted and justified for an
 // Addenda98.CorrectedData field. The code must be an official NACHA change code.
 func WriteCorrectionData(code string, data *CorrectedData) string {
@@ -399,8 +418,16 @@ func WriteCorrectionData(code string, data *CorrectedData) string {
 		txcode := strconv.Itoa(data.TransactionCode)
 		spaces := strings.Repeat(" ", correctedDataCharLength-9-len(data.AccountNumber)-len(txcode))
 		return fmt.Sprintf("%s%s%s%s", data.RoutingNumber, data.AccountNumber, spaces, txcode)
+	case "C08":
+		return pad.alphaField(data.RoutingNumber, correctedDataCharLength)
 	case "C09":
 		return pad.alphaField(data.Identification, correctedDataCharLength)
+	case "C10":
+		return pad.alphaField(data.CompanyName, correctedDataCharLength)
+	case "C11":
+		return pad.alphaField(data.CompanyIdentification, correctedDataCharLength)
+	case "C12":
+		return pad.alphaField(pad.alphaField(data.CompanyName, 16)+data.CompanyIdentification, correctedDataCharLength)
 	}
 	return pad.alphaField("", correctedDataCharLength)
 }
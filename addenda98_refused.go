@@ -19,7 +19,6 @@ package ach
 
 import (
 	"strings"
-	"unicode/utf8"
 )
 
 type Addenda98Refused struct {
@@ -59,6 +58,8 @@ type Addenda98Refused struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 // NewAddenda98Refused returns an reference to an instantiated Addenda98Refused with default values
@@ -73,82 +74,48 @@ func NewAddenda98Refused() *Addenda98Refused {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (addenda98Refused *Addenda98Refused) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			// 2-3 Always "98"
-			addenda98Refused.TypeCode = strings.TrimSpace(reset())
-		case 6:
-			addenda98Refused.RefusedChangeCode = strings.TrimSpace(reset())
-		case 21:
-			addenda98Refused.OriginalTrace = strings.TrimSpace(reset())
-		case 27:
-			// Positions 22-27 are Reserved
-			reset()
-		case 35:
-			addenda98Refused.OriginalDFI = addenda98Refused.parseStringField(reset())
-		case 64:
-			addenda98Refused.CorrectedData = strings.TrimSpace(reset())
-		case 67:
-			addenda98Refused.ChangeCode = strings.TrimSpace(reset())
-		case 74:
-			addenda98Refused.TraceSequenceNumber = strings.TrimSpace(reset())
-		case 79:
-			// Positions 75-79 are Reserved
-			reset()
-		case 94:
-			addenda98Refused.TraceNumber = strings.TrimSpace(reset())
-		}
-	}
+	// 1-1 Always 7
+	// 2-3 Always "98"
+	addenda98Refused.TypeCode = strings.TrimSpace(fields.field(1, 3))
+	addenda98Refused.RefusedChangeCode = strings.TrimSpace(fields.field(3, 6))
+	addenda98Refused.OriginalTrace = strings.TrimSpace(fields.field(6, 21))
+	// Positions 22-27 are Reserved
+	addenda98Refused.OriginalDFI = addenda98Refused.parseStringField(fields.field(27, 35))
+	addenda98Refused.CorrectedData = strings.TrimSpace(fields.field(35, 64))
+	addenda98Refused.ChangeCode = strings.TrimSpace(fields.field(64, 67))
+	addenda98Refused.TraceSequenceNumber = strings.TrimSpace(fields.field(67, 74))
+	// Positions 75-79 are Reserved
+	addenda98Refused.TraceNumber = strings.TrimSpace(fields.field(79, 94))
 }
 
 // String writes the Addenda98 struct to a 94 character string
 func (addenda98Refused *Addenda98Refused) String() string {
+	return string(addenda98Refused.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the Addenda98Refused record to dst and returns the extended buffer.
+func (addenda98Refused *Addenda98Refused) AppendTo(dst []byte) []byte {
 	if addenda98Refused == nil {
-		return ""
+		return dst
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(addenda98Refused.TypeCode)
-	buf.WriteString(addenda98Refused.RefusedChangeCode)
-	buf.WriteString(addenda98Refused.OriginalTraceField())
-	buf.WriteString(strings.Repeat(" ", 6))
-	buf.WriteString(addenda98Refused.OriginalDFIField())
-	buf.WriteString(addenda98Refused.CorrectedDataField())
-	buf.WriteString(addenda98Refused.ChangeCode)
-	buf.WriteString(addenda98Refused.TraceSequenceNumberField())
-	buf.WriteString(strings.Repeat(" ", 5))
-	buf.WriteString(addenda98Refused.TraceNumberField())
-
-	return buf.String()
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, addenda98Refused.TypeCode...)
+	dst = append(dst, addenda98Refused.RefusedChangeCode...)
+	dst = addenda98Refused.appendStringField(dst, addenda98Refused.OriginalTrace, 15)
+	dst = append(dst, "      "...)
+	dst = addenda98Refused.appendStringField(dst, addenda98Refused.OriginalDFI, 8)
+	dst = addenda98Refused.appendAlphaField(dst, addenda98Refused.CorrectedData, 29)
+	dst = append(dst, addenda98Refused.ChangeCode...)
+	dst = addenda98Refused.appendStringField(dst, addenda98Refused.TraceSequenceNumber, 7)
+	dst = append(dst, "     "...)
+	dst = addenda98Refused.appendStringField(dst, addenda98Refused.TraceNumber, 15)
+	return dst
 }
 
 // Validate verifies NACHA rules for Addenda98
@@ -186,6 +153,31 @@ func (addenda98Refused *Addenda98Refused) Validate() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (addenda98Refused *Addenda98Refused) validateAll() []error {
+	var errs fieldErrors
+	if addenda98Refused.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, addenda98Refused.TypeCode)
+	}
+	if addenda98Refused.TypeCode != "98" {
+		errs.add("TypeCode", ErrAddendaTypeCode, addenda98Refused.TypeCode)
+	}
+	if _, ok := changeCodeDict[addenda98Refused.RefusedChangeCode]; !ok {
+		errs.add("RefusedChangeCode", ErrAddenda98RefusedChangeCode, addenda98Refused.RefusedChangeCode)
+	}
+	if addenda98Refused.CorrectedData == "" {
+		errs.add("CorrectedData", ErrAddenda98CorrectedData, addenda98Refused.CorrectedData)
+	}
+	if _, ok := changeCodeDict[addenda98Refused.ChangeCode]; !ok {
+		errs.add("ChangeCode", ErrAddenda98ChangeCode, addenda98Refused.ChangeCode)
+	}
+	if addenda98Refused.TraceSequenceNumber == "" {
+		errs.add("TraceSequenceNumber", ErrAddenda98RefusedTraceSequenceNumber, addenda98Refused.TraceSequenceNumber)
+	}
+	return errs
+}
+
 func (addenda98Refused *Addenda98Refused) RefusedChangeCodeField() *ChangeCode {
 	code, ok := changeCodeDict[addenda98Refused.RefusedChangeCode]
 	if ok {
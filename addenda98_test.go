@@ -404,6 +404,15 @@ func TestCorrectedData__ParseCorrectedData(t *testing.T) {
 	if v := run("C09", "21345678    "); v.Identification != "21345678" {
 		t.Errorf("%#v", v)
 	}
+	if v := run("C10", "Acme Corp       "); v.CompanyName != "Acme Corp" {
+		t.Errorf("%#v", v)
+	}
+	if v := run("C11", "1234567890"); v.CompanyIdentification != "1234567890" {
+		t.Errorf("%#v", v)
+	}
+	if v := run("C12", "Acme Corp       1234567890"); v.CompanyName != "Acme Corp" || v.CompanyIdentification != "1234567890" {
+		t.Errorf("%#v", v)
+	}
 	if v := run("C99", "    "); v != nil {
 		t.Error("expected nil CorrectedData")
 	}
@@ -434,6 +443,11 @@ func TestCorrectedData__WriteCorrectionData(t *testing.T) {
 	data = &CorrectedData{Identification: "FooBar"}
 	require.Equal(t, "FooBar                       ", WriteCorrectionData("C09", data))
 
+	data = &CorrectedData{CompanyName: "Acme Corp", CompanyIdentification: "1234567890"}
+	require.Equal(t, "Acme Corp                    ", WriteCorrectionData("C10", data))
+	require.Equal(t, "1234567890                   ", WriteCorrectionData("C11", data))
+	require.Equal(t, "Acme Corp       1234567890   ", WriteCorrectionData("C12", data))
+
 }
 
 func TestIATCorrectedData(t *testing.T) {
@@ -462,6 +476,21 @@ func TestIATCorrectedData(t *testing.T) {
 		require.Equal(t, line, addenda98.String())
 	})
 
+	t.Run("C08", func(t *testing.T) {
+		// the foreign Receiving DFI Identification uses all 35 characters of IAT corrected data
+		id := "FR7630006000011234567890189" + "ABCDEFGH"
+		require.Len(t, id, iatCorrectedDataCharLength)
+		line := "798C08123456780000001      34567891" + id + "         345678910963842"
+		addenda98 := NewAddenda98()
+		addenda98.Parse(line)
+
+		parsed := addenda98.ParseCorrectedData()
+		require.NotNil(t, parsed)
+		require.Equal(t, id, parsed.RoutingNumber)
+		require.NoError(t, ValidateCorrectedData("C08", parsed))
+		require.Equal(t, line, addenda98.String())
+	})
+
 	t.Run("C07", func(t *testing.T) {
 		addenda98 := NewAddenda98()
 		line := "798C07123456780000001      34567891227-13569                                   345678910963842"
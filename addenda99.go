@@ -20,7 +20,6 @@ package ach
 import (
 	"fmt"
 	"strings"
-	"unicode/utf8"
 )
 
 // When a Return Entry is prepared, the original Company/Batch Header Record, the original Entry Detail Record,
@@ -68,6 +67,8 @@ type Addenda99 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 
 	validateOpts *ValidateOpts
 }
@@ -93,77 +94,48 @@ func NewAddenda99() *Addenda99 {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (Addenda99 *Addenda99) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			// 2-3 Defines the specific explanation and format for the addenda information contained in the same record
-			Addenda99.TypeCode = reset()
-		case 6:
-			// 4-6
-			Addenda99.ReturnCode = reset()
-		case 21:
-			// 7-21
-			Addenda99.OriginalTrace = strings.TrimSpace(reset())
-		case 27:
-			// 22-27, might be a date or blank
-			Addenda99.DateOfDeath = Addenda99.validateSimpleDate(reset())
-		case 35:
-			// 28-35
-			Addenda99.OriginalDFI = Addenda99.parseStringField(reset())
-		case 79:
-			// 36-79
-			Addenda99.AddendaInformation = reset()
-		case 94:
-			// 80-94
-			Addenda99.TraceNumber = strings.TrimSpace(reset())
-		}
-	}
+	// 1-1 Always 7
+	// 2-3 Defines the specific explanation and format for the addenda information contained in the same record
+	Addenda99.TypeCode = fields.field(1, 3)
+	// 4-6
+	Addenda99.ReturnCode = fields.field(3, 6)
+	// 7-21
+	Addenda99.OriginalTrace = strings.TrimSpace(fields.field(6, 21))
+	// 22-27, might be a date or blank
+	Addenda99.DateOfDeath = Addenda99.validateSimpleDate(fields.field(21, 27))
+	// 28-35
+	Addenda99.OriginalDFI = Addenda99.parseStringField(fields.field(27, 35))
+	// 36-79
+	Addenda99.AddendaInformation = fields.field(35, 79)
+	// 80-94
+	Addenda99.TraceNumber = strings.TrimSpace(fields.field(79, 94))
 }
 
 // String writes the Addenda99 struct to a 94 character string
 func (Addenda99 *Addenda99) String() string {
+	return string(Addenda99.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the Addenda99 record to dst and returns the extended buffer.
+func (Addenda99 *Addenda99) AppendTo(dst []byte) []byte {
 	if Addenda99 == nil {
-		return ""
+		return dst
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(Addenda99.TypeCode)
-	buf.WriteString(Addenda99.ReturnCode)
-	buf.WriteString(Addenda99.OriginalTraceField())
-	buf.WriteString(Addenda99.DateOfDeathField())
-	buf.WriteString(Addenda99.OriginalDFIField())
-	buf.WriteString(Addenda99.AddendaInformationField())
-	buf.WriteString(Addenda99.TraceNumberField())
-
-	return buf.String()
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, Addenda99.TypeCode...)
+	dst = append(dst, Addenda99.ReturnCode...)
+	dst = Addenda99.appendStringField(dst, Addenda99.OriginalTrace, 15)
+	dst = append(dst, Addenda99.DateOfDeathField()...)
+	dst = Addenda99.appendStringField(dst, Addenda99.OriginalDFI, 8)
+	dst = Addenda99.appendAlphaField(dst, Addenda99.AddendaInformation, 44)
+	dst = Addenda99.appendStringField(dst, Addenda99.TraceNumber, 15)
+	return dst
 }
 
 // Validate verifies NACHA rules for Addenda99
@@ -186,6 +158,24 @@ func (Addenda99 *Addenda99) Validate() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (Addenda99 *Addenda99) validateAll() []error {
+	var errs fieldErrors
+	if Addenda99.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, Addenda99.TypeCode)
+	}
+	if Addenda99.TypeCode != "99" {
+		errs.add("TypeCode", ErrAddendaTypeCode, Addenda99.TypeCode)
+	}
+	if Addenda99.validateOpts == nil || !Addenda99.validateOpts.CustomReturnCodes {
+		if _, ok := returnCodeDict[Addenda99.ReturnCode]; !ok {
+			errs.add("ReturnCode", ErrAddenda99ReturnCode, Addenda99.ReturnCode)
+		}
+	}
+	return errs
+}
+
 // SetValidation stores ValidateOpts on the Batch which are to be used to override
 // the default NACHA validation rules.
 func (Addenda99 *Addenda99) SetValidation(opts *ValidateOpts) {
@@ -17,10 +17,6 @@
 
 package ach
 
-import (
-	"unicode/utf8"
-)
-
 type Addenda99Contested struct {
 	// ID is a client defined string used as a reference to this record.
 	ID string `json:"id"`
@@ -68,6 +64,8 @@ type Addenda99Contested struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 
 	validateOpts *ValidateOpts
 }
@@ -81,90 +79,54 @@ func NewAddenda99Contested() *Addenda99Contested {
 }
 
 func (Addenda99Contested *Addenda99Contested) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			Addenda99Contested.TypeCode = reset()
-		case 6:
-			Addenda99Contested.ContestedReturnCode = reset()
-		case 21:
-			Addenda99Contested.OriginalEntryTraceNumber = reset()
-		case 27:
-			Addenda99Contested.DateOriginalEntryReturned = reset()
-		case 35:
-			Addenda99Contested.OriginalReceivingDFIIdentification = reset()
-		case 38:
-			Addenda99Contested.OriginalSettlementDate = reset()
-		case 53:
-			Addenda99Contested.ReturnTraceNumber = reset()
-		case 56:
-			Addenda99Contested.ReturnSettlementDate = reset()
-		case 58:
-			Addenda99Contested.ReturnReasonCode = reset()
-		case 73:
-			Addenda99Contested.DishonoredReturnTraceNumber = reset()
-		case 76:
-			Addenda99Contested.DishonoredReturnSettlementDate = reset()
-		case 78:
-			Addenda99Contested.DishonoredReturnReasonCode = reset()
-		case 79:
-			reset() // reserved
-		case 94:
-			Addenda99Contested.TraceNumber = reset()
-		}
-	}
+	// 1-1 Always 7
+	Addenda99Contested.TypeCode = fields.field(1, 3)
+	Addenda99Contested.ContestedReturnCode = fields.field(3, 6)
+	Addenda99Contested.OriginalEntryTraceNumber = fields.field(6, 21)
+	Addenda99Contested.DateOriginalEntryReturned = fields.field(21, 27)
+	Addenda99Contested.OriginalReceivingDFIIdentification = fields.field(27, 35)
+	Addenda99Contested.OriginalSettlementDate = fields.field(35, 38)
+	Addenda99Contested.ReturnTraceNumber = fields.field(38, 53)
+	Addenda99Contested.ReturnSettlementDate = fields.field(53, 56)
+	Addenda99Contested.ReturnReasonCode = fields.field(56, 58)
+	Addenda99Contested.DishonoredReturnTraceNumber = fields.field(58, 73)
+	Addenda99Contested.DishonoredReturnSettlementDate = fields.field(73, 76)
+	Addenda99Contested.DishonoredReturnReasonCode = fields.field(76, 78)
+	// 79-79 reserved
+	Addenda99Contested.TraceNumber = fields.field(79, 94)
 }
 
 func (Addenda99Contested *Addenda99Contested) String() string {
+	return string(Addenda99Contested.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the Addenda99Contested record to dst and returns the extended buffer.
+func (Addenda99Contested *Addenda99Contested) AppendTo(dst []byte) []byte {
 	if Addenda99Contested == nil {
-		return ""
+		return dst
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(Addenda99Contested.TypeCode)
-	buf.WriteString(Addenda99Contested.ContestedReturnCodeField())
-	buf.WriteString(Addenda99Contested.OriginalEntryTraceNumberField())
-	buf.WriteString(Addenda99Contested.DateOriginalEntryReturnedField())
-	buf.WriteString(Addenda99Contested.OriginalReceivingDFIIdentificationField())
-	buf.WriteString(Addenda99Contested.OriginalSettlementDateField())
-	buf.WriteString(Addenda99Contested.ReturnTraceNumberField())
-	buf.WriteString(Addenda99Contested.ReturnSettlementDateField())
-	buf.WriteString(Addenda99Contested.ReturnReasonCodeField())
-	buf.WriteString(Addenda99Contested.DishonoredReturnTraceNumberField())
-	buf.WriteString(Addenda99Contested.DishonoredReturnSettlementDateField())
-	buf.WriteString(Addenda99Contested.DishonoredReturnReasonCodeField())
-	buf.WriteString(" ")
-	buf.WriteString(Addenda99Contested.TraceNumberField())
-
-	return buf.String()
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, Addenda99Contested.TypeCode...)
+	dst = Addenda99Contested.appendStringField(dst, Addenda99Contested.ContestedReturnCode, 3)
+	dst = Addenda99Contested.appendStringField(dst, Addenda99Contested.OriginalEntryTraceNumber, 15)
+	dst = Addenda99Contested.appendStringField(dst, Addenda99Contested.DateOriginalEntryReturned, 6)
+	dst = Addenda99Contested.appendStringField(dst, Addenda99Contested.OriginalReceivingDFIIdentification, 8)
+	dst = Addenda99Contested.appendStringField(dst, Addenda99Contested.OriginalSettlementDate, 3)
+	dst = Addenda99Contested.appendStringField(dst, Addenda99Contested.ReturnTraceNumber, 15)
+	dst = Addenda99Contested.appendStringField(dst, Addenda99Contested.ReturnSettlementDate, 3)
+	dst = Addenda99Contested.appendStringField(dst, Addenda99Contested.ReturnReasonCode, 2)
+	dst = Addenda99Contested.appendStringField(dst, Addenda99Contested.DishonoredReturnTraceNumber, 15)
+	dst = Addenda99Contested.appendStringField(dst, Addenda99Contested.DishonoredReturnSettlementDate, 3)
+	dst = Addenda99Contested.appendStringField(dst, Addenda99Contested.DishonoredReturnReasonCode, 2)
+	dst = append(dst, " "...)
+	dst = Addenda99Contested.appendStringField(dst, Addenda99Contested.TraceNumber, 15)
+	return dst
 }
 
 // SetValidation stores ValidateOpts on the Batch which are to be used to override
@@ -196,9 +158,27 @@ func (Addenda99Contested *Addenda99Contested) Validate() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (Addenda99Contested *Addenda99Contested) validateAll() []error {
+	var errs fieldErrors
+	if Addenda99Contested.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, Addenda99Contested.TypeCode)
+	}
+	if Addenda99Contested.TypeCode != "99" {
+		errs.add("TypeCode", ErrAddendaTypeCode, Addenda99Contested.TypeCode)
+	}
+	if Addenda99Contested.validateOpts == nil || !Addenda99Contested.validateOpts.CustomReturnCodes {
+		if !IsContestedReturnCode(Addenda99Contested.ContestedReturnCode) {
+			errs.add("ContestedReturnCode", ErrAddenda99ContestedReturnCode, Addenda99Contested.ContestedReturnCode)
+		}
+	}
+	return errs
+}
+
 func IsContestedReturnCode(code string) bool {
 	switch code {
-	case "R71", "R72", "R73", "R74", "R75", "R76":
+	case "R71", "R72", "R73", "R74", "R75", "R76", "R77":
 		return true
 	}
 	return false
@@ -17,10 +17,6 @@
 
 package ach
 
-import (
-	"unicode/utf8"
-)
-
 type Addenda99Dishonored struct {
 	// ID is a client defined string used as a reference to this record.
 	ID string `json:"id"`
@@ -56,6 +52,8 @@ type Addenda99Dishonored struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 
 	validateOpts *ValidateOpts
 }
@@ -69,81 +67,48 @@ func NewAddenda99Dishonored() *Addenda99Dishonored {
 }
 
 func (Addenda99Dishonored *Addenda99Dishonored) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always 7
-			reset()
-		case 3:
-			Addenda99Dishonored.TypeCode = reset()
-		case 6:
-			Addenda99Dishonored.DishonoredReturnReasonCode = reset()
-		case 21:
-			Addenda99Dishonored.OriginalEntryTraceNumber = reset()
-		case 27:
-			reset() // reserved
-		case 38:
-			reset() // reserved
-		case 35:
-			Addenda99Dishonored.OriginalReceivingDFIIdentification = reset()
-		case 53:
-			Addenda99Dishonored.ReturnTraceNumber = reset()
-		case 56:
-			Addenda99Dishonored.ReturnSettlementDate = reset()
-		case 58:
-			Addenda99Dishonored.ReturnReasonCode = reset()
-		case 79:
-			Addenda99Dishonored.AddendaInformation = reset()
-		case 94:
-			Addenda99Dishonored.TraceNumber = reset()
-		}
-	}
+	// 1-1 Always 7
+	Addenda99Dishonored.TypeCode = fields.field(1, 3)
+	Addenda99Dishonored.DishonoredReturnReasonCode = fields.field(3, 6)
+	Addenda99Dishonored.OriginalEntryTraceNumber = fields.field(6, 21)
+	// 22-27 reserved
+	Addenda99Dishonored.OriginalReceivingDFIIdentification = fields.field(27, 35)
+	// 36-38 reserved
+	Addenda99Dishonored.ReturnTraceNumber = fields.field(38, 53)
+	Addenda99Dishonored.ReturnSettlementDate = fields.field(53, 56)
+	Addenda99Dishonored.ReturnReasonCode = fields.field(56, 58)
+	Addenda99Dishonored.AddendaInformation = fields.field(58, 79)
+	Addenda99Dishonored.TraceNumber = fields.field(79, 94)
 }
 
 func (Addenda99Dishonored *Addenda99Dishonored) String() string {
+	return string(Addenda99Dishonored.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the Addenda99Dishonored record to dst and returns the extended buffer.
+func (Addenda99Dishonored *Addenda99Dishonored) AppendTo(dst []byte) []byte {
 	if Addenda99Dishonored == nil {
-		return ""
+		return dst
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryAddendaPos)
-	buf.WriteString(Addenda99Dishonored.TypeCode)
-	buf.WriteString(Addenda99Dishonored.DishonoredReturnReasonCodeField())
-	buf.WriteString(Addenda99Dishonored.OriginalEntryTraceNumberField())
-	buf.WriteString("      ")
-	buf.WriteString(Addenda99Dishonored.OriginalReceivingDFIIdentificationField())
-	buf.WriteString("   ")
-	buf.WriteString(Addenda99Dishonored.ReturnTraceNumberField())
-	buf.WriteString(Addenda99Dishonored.ReturnSettlementDateField())
-	buf.WriteString(Addenda99Dishonored.ReturnReasonCodeField())
-	buf.WriteString(Addenda99Dishonored.AddendaInformationField())
-	buf.WriteString(Addenda99Dishonored.TraceNumberField())
-
-	return buf.String()
+	dst = append(dst, entryAddendaPos...)
+	dst = append(dst, Addenda99Dishonored.TypeCode...)
+	dst = Addenda99Dishonored.appendStringField(dst, Addenda99Dishonored.DishonoredReturnReasonCode, 3)
+	dst = Addenda99Dishonored.appendStringField(dst, Addenda99Dishonored.OriginalEntryTraceNumber, 15)
+	dst = append(dst, "      "...)
+	dst = Addenda99Dishonored.appendStringField(dst, Addenda99Dishonored.OriginalReceivingDFIIdentification, 8)
+	dst = append(dst, "   "...)
+	dst = Addenda99Dishonored.appendStringField(dst, Addenda99Dishonored.ReturnTraceNumber, 15)
+	dst = Addenda99Dishonored.appendStringField(dst, Addenda99Dishonored.ReturnSettlementDate, 3)
+	dst = Addenda99Dishonored.appendStringField(dst, Addenda99Dishonored.ReturnReasonCode, 2)
+	dst = Addenda99Dishonored.appendAlphaField(dst, Addenda99Dishonored.AddendaInformation, 21)
+	dst = Addenda99Dishonored.appendStringField(dst, Addenda99Dishonored.TraceNumber, 15)
+	return dst
 }
 
 // SetValidation stores ValidateOpts on the Batch which are to be used to override
@@ -183,6 +148,24 @@ func (Addenda99Dishonored *Addenda99Dishonored) Validate() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (Addenda99Dishonored *Addenda99Dishonored) validateAll() []error {
+	var errs fieldErrors
+	if Addenda99Dishonored.TypeCode == "" {
+		errs.add("TypeCode", ErrConstructor, Addenda99Dishonored.TypeCode)
+	}
+	if Addenda99Dishonored.TypeCode != "99" {
+		errs.add("TypeCode", ErrAddendaTypeCode, Addenda99Dishonored.TypeCode)
+	}
+	if Addenda99Dishonored.validateOpts == nil || !Addenda99Dishonored.validateOpts.CustomReturnCodes {
+		if !IsDishonoredReturnCode(Addenda99Dishonored.DishonoredReturnReasonCode) {
+			errs.add("DishonoredReturnReasonCode", ErrAddenda99DishonoredReturnCode, Addenda99Dishonored.DishonoredReturnReasonCode)
+		}
+	}
+	return errs
+}
+
 func (Addenda99Dishonored *Addenda99Dishonored) DishonoredReturnReasonCodeField() string {
 	return Addenda99Dishonored.stringField(Addenda99Dishonored.DishonoredReturnReasonCode, 3)
 }
@@ -18,10 +18,8 @@
 package ach
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
-	"unicode/utf8"
 )
 
 // ADVBatchControl contains entry counts, dollar total and has totals for all
@@ -58,64 +56,35 @@ type ADVBatchControl struct {
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 // Parse takes the input record string and parses the EntryDetail values
 func (bc *ADVBatchControl) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 1:
-			// 1-1 Always "8"
-			reset()
-		case 4:
-			// 2-4 This is the same as the "Service code" field in previous Batch Header Record
-			bc.ServiceClassCode = bc.parseNumField(reset())
-		case 10:
-			// 5-10 Total number of Entry Detail Record in the batch
-			bc.EntryAddendaCount = bc.parseNumField(reset())
-		case 20:
-			// 11-20 Total of all positions 4-11 on each Entry Detail Record in the batch. This is essentially the sum of all the RDFI routing numbers in the batch.
-			// If the sum exceeds 10 digits (because you have lots of Entry Detail Records), lop off the most significant digits of the sum until there are only 10
-			bc.EntryHash = bc.parseNumField(reset())
-		case 40:
-			// 21-32 Number of cents of debit entries within the batch
-			bc.TotalDebitEntryDollarAmount = bc.parseNumField(reset())
-		case 60:
-			// 33-44 Number of cents of credit entries within the batch
-			bc.TotalCreditEntryDollarAmount = bc.parseNumField(reset())
-		case 79:
-			// 45-54 ACH Operator Data
-			bc.ACHOperatorData = strings.TrimSpace(reset())
-		case 87:
-			// 80-87 This is the same as the "ODFI identification" field in previous Batch Header Record
-			bc.ODFIIdentification = bc.parseStringField(reset())
-		case 94:
-			// 88-94 This is the same as the "Batch number" field in previous Batch Header Record
-			bc.BatchNumber = bc.parseNumField(reset())
-		}
-	}
+	// 1-1 Always "8"
+	// 2-4 This is the same as the "Service code" field in previous Batch Header Record
+	bc.ServiceClassCode = bc.parseNumField(fields.field(1, 4))
+	// 5-10 Total number of Entry Detail Record in the batch
+	bc.EntryAddendaCount = bc.parseNumField(fields.field(4, 10))
+	// 11-20 Total of all positions 4-11 on each Entry Detail Record in the batch. This is essentially the sum of all the RDFI routing numbers in the batch.
+	// If the sum exceeds 10 digits (because you have lots of Entry Detail Records), lop off the most significant digits of the sum until there are only 10
+	bc.EntryHash = bc.parseNumField(fields.field(10, 20))
+	// 21-32 Number of cents of debit entries within the batch
+	bc.TotalDebitEntryDollarAmount = bc.parseNumField(fields.field(20, 40))
+	// 33-44 Number of cents of credit entries within the batch
+	bc.TotalCreditEntryDollarAmount = bc.parseNumField(fields.field(40, 60))
+	// 45-54 ACH Operator Data
+	bc.ACHOperatorData = strings.TrimSpace(fields.field(60, 79))
+	// 80-87 This is the same as the "ODFI identification" field in previous Batch Header Record
+	bc.ODFIIdentification = bc.parseStringField(fields.field(79, 87))
+	// 88-94 This is the same as the "Batch number" field in previous Batch Header Record
+	bc.BatchNumber = bc.parseNumField(fields.field(87, 94))
 }
 
 // NewADVBatchControl returns a new ADVBatchControl with default values for none exported fields
@@ -129,20 +98,21 @@ func NewADVBatchControl() *ADVBatchControl {
 
 // String writes the ADVBatchControl struct to a 94 character string.
 func (bc *ADVBatchControl) String() string {
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(batchControlPos)
-	buf.WriteString(fmt.Sprintf("%v", bc.ServiceClassCode))
-	buf.WriteString(bc.EntryAddendaCountField())
-	buf.WriteString(bc.EntryHashField())
-	buf.WriteString(bc.TotalDebitEntryDollarAmountField())
-	buf.WriteString(bc.TotalCreditEntryDollarAmountField())
-	buf.WriteString(bc.ACHOperatorDataField())
-	buf.WriteString(bc.ODFIIdentificationField())
-	buf.WriteString(bc.BatchNumberField())
-
-	return buf.String()
+	return string(bc.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the ADVBatchControl record to dst and returns the extended buffer.
+func (bc *ADVBatchControl) AppendTo(dst []byte) []byte {
+	dst = append(dst, batchControlPos...)
+	dst = strconv.AppendInt(dst, int64(bc.ServiceClassCode), 10)
+	dst = bc.appendNumericField(dst, bc.EntryAddendaCount, 6)
+	dst = bc.appendNumericField(dst, bc.EntryHash, 10)
+	dst = bc.appendNumericField(dst, bc.TotalDebitEntryDollarAmount, 20)
+	dst = bc.appendNumericField(dst, bc.TotalCreditEntryDollarAmount, 20)
+	dst = bc.appendAlphaField(dst, bc.ACHOperatorData, 19)
+	dst = bc.appendStringField(dst, bc.ODFIIdentification, 8)
+	dst = bc.appendNumericField(dst, bc.BatchNumber, 7)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -173,6 +143,21 @@ func (bc *ADVBatchControl) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (bc *ADVBatchControl) validateAll() []error {
+	var errs fieldErrors
+	if bc.ServiceClassCode == 0 {
+		errs.add("ServiceClassCode", ErrConstructor, strconv.Itoa(bc.ServiceClassCode))
+	}
+	if bc.ODFIIdentification == "000000000" || bc.ODFIIdentification == "" {
+		errs.add("ODFIIdentification", ErrConstructor, bc.ODFIIdentificationField())
+	}
+	errs.add("ServiceClassCode", bc.isServiceClass(bc.ServiceClassCode), strconv.Itoa(bc.ServiceClassCode))
+	errs.add("ACHOperatorData", bc.isAlphanumeric(bc.ACHOperatorData), bc.ACHOperatorData)
+	return errs
+}
+
 // EntryAddendaCountField gets a string of the addenda count zero padded
 func (bc *ADVBatchControl) EntryAddendaCountField() string {
 	return bc.numericField(bc.EntryAddendaCount, 6)
@@ -18,9 +18,7 @@
 package ach
 
 import (
-	"fmt"
 	"strconv"
-	"unicode/utf8"
 )
 
 // ADVEntryDetail contains the actual transaction data for an individual entry.
@@ -83,6 +81,8 @@ type ADVEntryDetail struct {
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 const (
@@ -122,64 +122,65 @@ func NewADVEntryDetail() *ADVEntryDetail {
 
 // Parse ADVEntryDetail
 func (ed *ADVEntryDetail) Parse(record string) {
-	if utf8.RuneCountInString(record) != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
-	runes := []rune(record)
 
 	// 1-1 Always "6"
 	// 2-3 is checking credit 22 debit 27 savings credit 32 debit 37
-	ed.TransactionCode = ed.parseNumField(string(runes[1:3]))
+	ed.TransactionCode = ed.parseNumField(fields.field(1, 3))
 	// 4-11 the RDFI's routing number without the last digit.
-	ed.RDFIIdentification = ed.parseStringField(string(runes[3:11]))
+	ed.RDFIIdentification = ed.parseStringField(fields.field(3, 11))
 	// 12-12 The last digit of the RDFI's routing number
-	ed.CheckDigit = ed.parseStringField(string(runes[11:12]))
+	ed.CheckDigit = ed.parseStringField(fields.field(11, 12))
 	// 13-27 The receiver's bank account number you are crediting/debiting
-	ed.DFIAccountNumber = string(runes[12:27])
+	ed.DFIAccountNumber = fields.field(12, 27)
 	// 28-39 Number of cents you are debiting/crediting this account
-	ed.Amount = ed.parseNumField(string(runes[27:39]))
+	ed.Amount = ed.parseNumField(fields.field(27, 39))
 	// 40-48 Advice Routing Number
-	ed.AdviceRoutingNumber = ed.parseStringField(string(runes[39:48]))
+	ed.AdviceRoutingNumber = ed.parseStringField(fields.field(39, 48))
 	// 49-53 File Identification
-	ed.FileIdentification = ed.parseStringField(string(runes[48:53]))
+	ed.FileIdentification = ed.parseStringField(fields.field(48, 53))
 	// 54-54 ACH Operator Data
-	ed.ACHOperatorData = ed.parseStringField(string(runes[53:54]))
+	ed.ACHOperatorData = ed.parseStringField(fields.field(53, 54))
 	// 55-76 Individual Name
-	ed.IndividualName = string(runes[54:76])
+	ed.IndividualName = fields.field(54, 76)
 	// 77-78 allows ODFIs to include codes of significance only to them, normally blank
-	ed.DiscretionaryData = string(runes[76:78])
+	ed.DiscretionaryData = fields.field(76, 78)
 	// 79-79 1 if addenda exists 0 if it does not
-	ed.AddendaRecordIndicator = ed.parseNumField(string(runes[78:79]))
+	ed.AddendaRecordIndicator = ed.parseNumField(fields.field(78, 79))
 	// 80-87
-	ed.ACHOperatorRoutingNumber = ed.parseStringField(string(runes[79:87]))
+	ed.ACHOperatorRoutingNumber = ed.parseStringField(fields.field(79, 87))
 	// 88-90
-	ed.JulianDay = ed.parseNumField(string(runes[87:90]))
+	ed.JulianDay = ed.parseNumField(fields.field(87, 90))
 	// 91-94
-	ed.SequenceNumber = ed.parseNumField(string(runes[90:94]))
+	ed.SequenceNumber = ed.parseNumField(fields.field(90, 94))
 }
 
 // String writes the ADVEntryDetail struct to a 94 character string.
 func (ed *ADVEntryDetail) String() string {
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryDetailPos)
-	buf.WriteString(fmt.Sprintf("%v", ed.TransactionCode))
-	buf.WriteString(ed.RDFIIdentificationField())
-	buf.WriteString(ed.CheckDigit)
-	buf.WriteString(ed.DFIAccountNumberField())
-	buf.WriteString(ed.AmountField())
-	buf.WriteString(ed.AdviceRoutingNumberField())
-	buf.WriteString(ed.FileIdentificationField())
-	buf.WriteString(ed.ACHOperatorDataField())
-	buf.WriteString(ed.IndividualNameField())
-	buf.WriteString(ed.DiscretionaryDataField())
-	buf.WriteString(fmt.Sprintf("%v", ed.AddendaRecordIndicator))
-	buf.WriteString(ed.ACHOperatorRoutingNumberField())
-	buf.WriteString(ed.JulianDateDayField())
-	buf.WriteString(ed.SequenceNumberField())
+	return string(ed.AppendTo(make([]byte, 0, RecordLength)))
+}
 
-	return buf.String()
+// AppendTo appends the ADVEntryDetail record to dst and returns the extended buffer.
+func (ed *ADVEntryDetail) AppendTo(dst []byte) []byte {
+	dst = append(dst, entryDetailPos...)
+	dst = strconv.AppendInt(dst, int64(ed.TransactionCode), 10)
+	dst = ed.appendStringField(dst, ed.RDFIIdentification, 8)
+	dst = append(dst, ed.CheckDigit...)
+	dst = ed.appendAlphaField(dst, ed.DFIAccountNumber, 15)
+	dst = ed.appendNumericField(dst, ed.Amount, 12)
+	dst = ed.appendStringField(dst, ed.AdviceRoutingNumber, 9)
+	dst = ed.appendAlphaField(dst, ed.FileIdentification, 5)
+	dst = ed.appendAlphaField(dst, ed.ACHOperatorData, 1)
+	dst = ed.appendAlphaField(dst, ed.IndividualName, 22)
+	dst = ed.appendAlphaField(dst, ed.DiscretionaryData, 2)
+	dst = strconv.AppendInt(dst, int64(ed.AddendaRecordIndicator), 10)
+	dst = ed.appendAlphaField(dst, ed.ACHOperatorRoutingNumber, 8)
+	dst = ed.appendNumericField(dst, ed.JulianDay, 3)
+	dst = ed.appendNumericField(dst, ed.SequenceNumber, 4)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -247,6 +248,48 @@ func (ed *ADVEntryDetail) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (ed *ADVEntryDetail) validateAll() []error {
+	var errs fieldErrors
+	if ed.TransactionCode == 0 {
+		errs.add("TransactionCode", ErrConstructor, strconv.Itoa(ed.TransactionCode))
+	}
+	if ed.RDFIIdentification == "" {
+		errs.add("RDFIIdentification", ErrConstructor, ed.RDFIIdentificationField())
+	}
+	if ed.DFIAccountNumber == "" {
+		errs.add("DFIAccountNumber", ErrConstructor, ed.DFIAccountNumber)
+	}
+	if ed.AdviceRoutingNumber == "" {
+		errs.add("AdviceRoutingNumber", ErrConstructor, ed.AdviceRoutingNumber)
+	}
+	if ed.IndividualName == "" {
+		errs.add("IndividualName", ErrFieldRequired, ed.IndividualName)
+	}
+	if ed.ACHOperatorRoutingNumber == "" {
+		errs.add("ACHOperatorRoutingNumber", ErrConstructor, ed.ACHOperatorRoutingNumber)
+	}
+	if ed.JulianDay <= 0 {
+		errs.add("JulianDay", ErrConstructor, strconv.Itoa(ed.JulianDay))
+	}
+	if ed.SequenceNumber == 0 {
+		errs.add("SequenceNumber", ErrConstructor, strconv.Itoa(ed.SequenceNumber))
+	}
+	errs.add("TransactionCode", ed.isTransactionCode(ed.TransactionCode), strconv.Itoa(ed.TransactionCode))
+	errs.add("DFIAccountNumber", ed.isAlphanumeric(ed.DFIAccountNumber), ed.DFIAccountNumber)
+	errs.add("AdviceRoutingNumber", ed.isAlphanumeric(ed.AdviceRoutingNumber), ed.AdviceRoutingNumber)
+	errs.add("IndividualName", ed.isAlphanumeric(ed.IndividualName), ed.IndividualName)
+	errs.add("DiscretionaryData", ed.isAlphanumeric(ed.DiscretionaryData), ed.DiscretionaryData)
+	errs.add("ACHOperatorRoutingNumber", ed.isAlphanumeric(ed.ACHOperatorRoutingNumber), ed.ACHOperatorRoutingNumber)
+	calculated := CalculateCheckDigit(ed.RDFIIdentificationField())
+	edCheckDigit, _ := strconv.Atoi(ed.CheckDigit)
+	if calculated != edCheckDigit {
+		errs.add("RDFIIdentification", NewErrValidCheckDigit(calculated), ed.CheckDigit)
+	}
+	return errs
+}
+
 // SetRDFI takes the 9 digit RDFI account number and separates it for RDFIIdentification and CheckDigit
 func (ed *ADVEntryDetail) SetRDFI(rdfi string) *ADVEntryDetail {
 	s := ed.stringField(rdfi, 9)
@@ -49,6 +49,8 @@ type ADVFileControl struct {
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 // Parse takes the input record string and parses the FileControl values
@@ -84,19 +86,20 @@ func NewADVFileControl() ADVFileControl {
 
 // String writes the ADVFileControl struct to a 94 character string.
 func (fc *ADVFileControl) String() string {
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(fileControlPos)
-	buf.WriteString(fc.BatchCountField())
-	buf.WriteString(fc.BlockCountField())
-	buf.WriteString(fc.EntryAddendaCountField())
-	buf.WriteString(fc.EntryHashField())
-	buf.WriteString(fc.TotalDebitEntryDollarAmountInFileField())
-	buf.WriteString(fc.TotalCreditEntryDollarAmountInFileField())
-	buf.WriteString("                       ")
-
-	return buf.String()
+	return string(fc.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the ADVFileControl record to dst and returns the extended buffer.
+func (fc *ADVFileControl) AppendTo(dst []byte) []byte {
+	dst = append(dst, fileControlPos...)
+	dst = fc.appendNumericField(dst, fc.BatchCount, 6)
+	dst = fc.appendNumericField(dst, fc.BlockCount, 6)
+	dst = fc.appendNumericField(dst, fc.EntryAddendaCount, 8)
+	dst = fc.appendNumericField(dst, fc.EntryHash, 10)
+	dst = fc.appendNumericField(dst, fc.TotalDebitEntryDollarAmountInFile, 20)
+	dst = fc.appendNumericField(dst, fc.TotalCreditEntryDollarAmountInFile, 20)
+	dst = append(dst, "                       "...)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -126,6 +129,25 @@ func (fc *ADVFileControl) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (fc *ADVFileControl) validateAll() []error {
+	var errs fieldErrors
+	if fc.BatchCount == 0 {
+		errs.add("BatchCount", ErrConstructor, fc.BatchCountField())
+	}
+	if fc.BlockCount == 0 {
+		errs.add("BlockCount", ErrConstructor, fc.BlockCountField())
+	}
+	if fc.EntryAddendaCount == 0 {
+		errs.add("EntryAddendaCount", ErrConstructor, fc.EntryAddendaCountField())
+	}
+	if fc.EntryHash == 0 {
+		errs.add("EntryHash", ErrConstructor, fc.EntryHashField())
+	}
+	return errs
+}
+
 // BatchCountField gets a string of the batch count zero padded
 func (fc *ADVFileControl) BatchCountField() string {
 	return fc.numericField(fc.BatchCount, 6)
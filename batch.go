@@ -40,8 +40,13 @@ type Batch struct {
 	// balances the batch by debiting or crediting the sum of amounts in the batch.
 	offset *Offset
 
+	// traceNumbers allocates trace numbers for entries (and offsets) which don't have one
+	traceNumbers TraceNumberAllocator
+
 	// category defines if the entry is a Forward, Return, or NOC
 	category string
+	// streaming is set when the batch holds one entry at a time for ValidateStream
+	streaming bool
 	// Converters is composed for ACH to GoLang Converters
 	converters
 
@@ -313,12 +318,41 @@ func (batch *Batch) verify() error {
 	if len(batch.Entries) <= 0 && len(batch.ADVEntries) <= 0 {
 		return batch.Error("entries", ErrBatchNoEntries)
 	}
+	if !batch.hasControl() {
+		return batch.Error("Control", ErrBatchControl)
+	}
 	// verify field inclusion in all the records of the batch.
 	if err := batch.isFieldInclusion(); err != nil {
 		// convert the field error in to a batch error for a consistent api
 		return batch.Error("FieldError", err)
 	}
 
+	for _, check := range batch.checks() {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checks returns the rules verify applies to a batch after its records are valid, in order.
+// Custom rules added with RegisterRule and RegisterEntryRule run later, see verifyRules.
+func (batch *Batch) checks() []func() error {
+	customTraceNumbers := batch.validateOpts != nil && batch.validateOpts.CustomTraceNumbers
+
+	checks := []func() error{batch.isHeaderControlEquality, batch.isBatchEntryCount}
+	if !customTraceNumbers {
+		checks = append(checks, batch.isSequenceAscending)
+	}
+	checks = append(checks, batch.isBatchAmount, batch.isEntryHash, batch.isOriginatorDNE)
+	if !customTraceNumbers {
+		checks = append(checks, batch.isTraceNumberODFI, batch.isAddendaSequence)
+	}
+	return append(checks, batch.isCategory)
+}
+
+// isHeaderControlEquality validates the fields shared by the batch header and control match
+func (batch *Batch) isHeaderControlEquality() error {
 	if !batch.IsADV() {
 		// validate batch header and control codes are the same
 		if (batch.validateOpts == nil || !batch.validateOpts.UnequalServiceClassCode) &&
@@ -360,35 +394,6 @@ func (batch *Batch) verify() error {
 				NewErrBatchHeaderControlEquality(batch.Header.BatchNumber, batch.ADVControl.BatchNumber))
 		}
 	}
-
-	if err := batch.isBatchEntryCount(); err != nil {
-		return err
-	}
-	if batch.validateOpts == nil || !batch.validateOpts.CustomTraceNumbers {
-		if err := batch.isSequenceAscending(); err != nil {
-			return err
-		}
-	}
-	if err := batch.isBatchAmount(); err != nil {
-		return err
-	}
-	if err := batch.isEntryHash(); err != nil {
-		return err
-	}
-	if err := batch.isOriginatorDNE(); err != nil {
-		return err
-	}
-	if batch.validateOpts == nil || !batch.validateOpts.CustomTraceNumbers {
-		if err := batch.isTraceNumberODFI(); err != nil {
-			return err
-		}
-		if err := batch.isAddendaSequence(); err != nil {
-			return err
-		}
-	}
-	if err := batch.isCategory(); err != nil {
-		return err
-	}
 	return nil
 }
 
@@ -423,11 +428,15 @@ func (batch *Batch) build() error {
 			// Add a sequenced TraceNumber if one is not already set. Have to keep original trance number Return and NOC entries
 			if currentTraceNumberODFI != batchHeaderODFI {
 				if opts := batch.validateOpts; opts == nil {
-					entry.SetTraceNumber(batch.Header.ODFIIdentification, seq)
+					if err := batch.setTraceNumber(entry, seq); err != nil {
+						return err
+					}
 				} else {
 					// Automatically set the TraceNumber if we are validating Origin and don't have custom trace numbers
 					if !opts.BypassOriginValidation && !opts.CustomTraceNumbers {
-						entry.SetTraceNumber(batch.Header.ODFIIdentification, seq)
+						if err := batch.setTraceNumber(entry, seq); err != nil {
+							return err
+						}
 					}
 				}
 			}
@@ -571,66 +580,66 @@ func (batch *Batch) SetID(id string) {
 // isFieldInclusion iterates through all the records in the batch and verifies against default fields
 func (batch *Batch) isFieldInclusion() error {
 	if err := batch.Header.Validate(); err != nil {
-		return err
+		return atLine(err, batch.Header.SourceLine())
 	}
 
 	if !batch.IsADV() {
 		for _, entry := range batch.Entries {
 			if err := entry.Validate(); err != nil {
-				return err
+				return atLine(err, entry.SourceLine())
 			}
 
 			if entry.Addenda02 != nil {
 				if err := entry.Addenda02.Validate(); err != nil {
-					return err
+					return atLine(err, entry.Addenda02.SourceLine())
 				}
 			}
 			for _, addenda05 := range entry.Addenda05 {
 				if err := addenda05.Validate(); err != nil {
-					return err
+					return atLine(err, addenda05.SourceLine())
 				}
 			}
 			if entry.Addenda98 != nil {
 				if err := entry.Addenda98.Validate(); err != nil {
-					return err
+					return atLine(err, entry.Addenda98.SourceLine())
 				}
 			}
 			if entry.Addenda98Refused != nil {
 				if err := entry.Addenda98Refused.Validate(); err != nil {
-					return err
+					return atLine(err, entry.Addenda98Refused.SourceLine())
 				}
 			}
 			if entry.Addenda99 != nil {
 				if err := entry.Addenda99.Validate(); err != nil {
-					return err
+					return atLine(err, entry.Addenda99.SourceLine())
 				}
 			}
 			if entry.Addenda99Dishonored != nil {
 				if err := entry.Addenda99Dishonored.Validate(); err != nil {
-					return err
+					return atLine(err, entry.Addenda99Dishonored.SourceLine())
 				}
 			}
 			if entry.Addenda99Contested != nil {
 				if err := entry.Addenda99Contested.Validate(); err != nil {
-					return err
+					return atLine(err, entry.Addenda99Contested.SourceLine())
 				}
 			}
 
 		}
-		return batch.Control.Validate()
+		return atLine(batch.Control.Validate(), batch.Control.SourceLine())
 	}
 	// ADV File/Batch
 	for _, entry := range batch.ADVEntries {
 		if err := entry.Validate(); err != nil {
-			return err
+			return atLine(err, entry.SourceLine())
 		}
 		if entry.Addenda99 != nil {
 			if err := entry.Addenda99.Validate(); err != nil {
-				return err
+				return atLine(err, entry.Addenda99.SourceLine())
 			}
 		}
 	}
-	return batch.ADVControl.Validate()
+	return atLine(batch.ADVControl.Validate(), batch.ADVControl.SourceLine())
 }
 
 // isBatchEntryCount validate Entry count is accurate
@@ -707,21 +716,29 @@ func (batch *Batch) isBatchAmount() error {
 
 func (batch *Batch) calculateBatchAmounts() (credit int, debit int) {
 	for _, entry := range batch.Entries {
-		switch entry.TransactionCode {
-		case CheckingCredit, CheckingReturnNOCCredit, CheckingPrenoteCredit, CheckingZeroDollarRemittanceCredit,
-			SavingsCredit, SavingsReturnNOCCredit, SavingsPrenoteCredit, SavingsZeroDollarRemittanceCredit, GLCredit,
-			GLReturnNOCCredit, GLPrenoteCredit, GLZeroDollarRemittanceCredit, LoanCredit, LoanReturnNOCCredit,
-			LoanPrenoteCredit, LoanZeroDollarRemittanceCredit:
-			credit = credit + entry.Amount
-		case CheckingDebit, CheckingReturnNOCDebit, CheckingPrenoteDebit, CheckingZeroDollarRemittanceDebit,
-			SavingsDebit, SavingsReturnNOCDebit, SavingsPrenoteDebit, SavingsZeroDollarRemittanceDebit, GLDebit,
-			GLReturnNOCDebit, GLPrenoteDebit, GLZeroDollarRemittanceDebit, LoanDebit, LoanReturnNOCDebit:
-			debit = debit + entry.Amount
-		}
+		entryCredit, entryDebit := entryAmounts(entry)
+		credit += entryCredit
+		debit += entryDebit
 	}
 	return credit, debit
 }
 
+// entryAmounts returns the Amount of entry as a credit or debit depending on its TransactionCode.
+func entryAmounts(entry *EntryDetail) (credit int, debit int) {
+	switch entry.TransactionCode {
+	case CheckingCredit, CheckingReturnNOCCredit, CheckingPrenoteCredit, CheckingZeroDollarRemittanceCredit,
+		SavingsCredit, SavingsReturnNOCCredit, SavingsPrenoteCredit, SavingsZeroDollarRemittanceCredit, GLCredit,
+		GLReturnNOCCredit, GLPrenoteCredit, GLZeroDollarRemittanceCredit, LoanCredit, LoanReturnNOCCredit,
+		LoanPrenoteCredit, LoanZeroDollarRemittanceCredit:
+		return entry.Amount, 0
+	case CheckingDebit, CheckingReturnNOCDebit, CheckingPrenoteDebit, CheckingZeroDollarRemittanceDebit,
+		SavingsDebit, SavingsReturnNOCDebit, SavingsPrenoteDebit, SavingsZeroDollarRemittanceDebit, GLDebit,
+		GLReturnNOCDebit, GLPrenoteDebit, GLZeroDollarRemittanceDebit, LoanDebit, LoanReturnNOCDebit:
+		return 0, entry.Amount
+	}
+	return 0, 0
+}
+
 func (batch *Batch) calculateADVBatchAmounts() (credit int, debit int) {
 	for _, entry := range batch.ADVEntries {
 		if entry.TransactionCode == CreditForDebitsOriginated ||
@@ -1028,6 +1045,15 @@ func (batch *Batch) addendaFieldInclusionReturn(entry *EntryDetail) error {
 	return nil
 }
 
+// hasControl reports if the batch has the control record its checks compare against,
+// an ADVBatchControl for ADV batches and a BatchControl for all others.
+func (batch *Batch) hasControl() bool {
+	if batch.IsADV() {
+		return batch.ADVControl != nil
+	}
+	return batch.Control != nil
+}
+
 // IsADV determines if a batch is batch type ADV - BatchADV
 func (batch *Batch) IsADV() bool {
 	ok := batch.GetHeader().StandardEntryClassCode == ADV
@@ -1158,6 +1184,27 @@ func (b *Batch) WithOffset(off *Offset) {
 	b.offset = off
 }
 
+// WithTraceNumbers sets a TraceNumberAllocator onto a Batch. During Create entries (and offset records) which need a
+// trace number are given the next one from alloc instead of their position in the batch.
+func (b *Batch) WithTraceNumbers(alloc TraceNumberAllocator) {
+	b.traceNumbers = alloc
+}
+
+// setTraceNumber gives entry the next trace number from the batch's TraceNumberAllocator,
+// or the sequence number seq from the batch's ODFI.
+func (b *Batch) setTraceNumber(entry *EntryDetail, seq int) error {
+	if b.traceNumbers != nil {
+		trace, err := b.traceNumbers.Next(b.Header.ODFIIdentification)
+		if err != nil {
+			return b.Error("TraceNumber", err)
+		}
+		setAllocatedTraceNumber(entry, trace)
+		return nil
+	}
+	entry.SetTraceNumber(b.Header.ODFIIdentification, seq)
+	return nil
+}
+
 const offsetIndividualName = "OFFSET"
 
 func (b *Batch) upsertOffsets() error {
@@ -1197,7 +1244,6 @@ func (b *Batch) upsertOffsets() error {
 
 	// Create our debit offset EntryDetail
 	debitED := createOffsetEntryDetail(b.offset, b)
-	debitED.TraceNumber = fmt.Sprintf("%15.15d", lastTraceNumber(b.Entries)+offsetCount)
 	debitED.Amount = b.Control.TotalCreditEntryDollarAmount
 	switch b.offset.AccountType {
 	case OffsetChecking:
@@ -1208,12 +1254,14 @@ func (b *Batch) upsertOffsets() error {
 	if debitED.Amount == 0 {
 		debitED = nil // zero out so we don't add an empty OFFSET EntryDetail
 	} else {
+		if err := b.setOffsetTraceNumber(debitED, offsetCount); err != nil {
+			return err
+		}
 		offsetCount += 1
 	}
 
 	// Create our credit offset EntryDetail
 	creditED := createOffsetEntryDetail(b.offset, b)
-	creditED.TraceNumber = fmt.Sprintf("%15.15d", lastTraceNumber(b.Entries)+offsetCount)
 	creditED.Amount = b.Control.TotalDebitEntryDollarAmount
 	switch b.offset.AccountType {
 	case OffsetChecking:
@@ -1223,6 +1271,10 @@ func (b *Batch) upsertOffsets() error {
 	}
 	if creditED.Amount == 0 {
 		creditED = nil // zero out so we don't add an empty OFFSET EntryDetail
+	} else {
+		if err := b.setOffsetTraceNumber(creditED, offsetCount); err != nil {
+			return err
+		}
 	}
 
 	// Add both EntryDetails to our Batch and recalculate some fields
@@ -1244,6 +1296,21 @@ func (b *Batch) upsertOffsets() error {
 	return nil
 }
 
+// setOffsetTraceNumber gives an offset EntryDetail the next trace number from the batch's TraceNumberAllocator,
+// or the n-th trace number after the last entry in the batch.
+func (b *Batch) setOffsetTraceNumber(ed *EntryDetail, n int) error {
+	if b.traceNumbers != nil {
+		trace, err := b.traceNumbers.Next(b.Header.ODFIIdentification)
+		if err != nil {
+			return fmt.Errorf("offset: %w", err)
+		}
+		setAllocatedTraceNumber(ed, trace)
+		return nil
+	}
+	ed.TraceNumber = fmt.Sprintf("%15.15d", lastTraceNumber(b.Entries)+n)
+	return nil
+}
+
 func createOffsetEntryDetail(off *Offset, batch *Batch) *EntryDetail {
 	ed := NewEntryDetail()
 	ed.RDFIIdentification = batch.offset.RoutingNumber[:8]
@@ -74,7 +74,7 @@ func (batch *BatchACK) Validate() error {
 		}
 	}
 
-	return nil
+	return batch.verifyRules()
 }
 
 // Create will tabulate and assemble an ACH batch into a valid state. This includes
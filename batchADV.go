@@ -67,7 +67,7 @@ func (batch *BatchADV) Validate() error {
 			}
 		}
 	}
-	return nil
+	return batch.verifyRules()
 }
 
 // Create will tabulate and assemble an ACH batch into a valid state. This includes
@@ -92,7 +92,7 @@ func (batch *BatchARC) Validate() error {
 			return err
 		}
 	}
-	return nil
+	return batch.verifyRules()
 }
 
 // Create will tabulate and assemble an ACH batch into a valid state. This includes
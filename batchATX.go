@@ -90,7 +90,7 @@ func (batch *BatchATX) Validate() error {
 			return err
 		}
 	}
-	return nil
+	return batch.verifyRules()
 }
 
 // Create will tabulate and assemble an ACH batch into a valid state. This includes
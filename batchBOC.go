@@ -97,7 +97,7 @@ func (batch *BatchBOC) Validate() error {
 			return err
 		}
 	}
-	return nil
+	return batch.verifyRules()
 }
 
 // Create will tabulate and assemble an ACH batch into a valid state. This includes
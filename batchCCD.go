@@ -63,7 +63,7 @@ func (batch *BatchCCD) Validate() error {
 			return err
 		}
 	}
-	return nil
+	return batch.verifyRules()
 }
 
 // Create will tabulate and assemble an ACH batch into a valid state. This includes
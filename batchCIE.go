@@ -84,7 +84,7 @@ func (batch *BatchCIE) Validate() error {
 			return err
 		}
 	}
-	return nil
+	return batch.verifyRules()
 }
 
 // Create will tabulate and assemble an ACH batch into a valid state. This includes
@@ -18,9 +18,7 @@
 package ach
 
 import (
-	"fmt"
 	"strconv"
-	"unicode/utf8"
 )
 
 // BatchControl contains entry counts, dollar total and has totals for all
@@ -78,6 +76,8 @@ type BatchControl struct {
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// source is where the record was read from
+	source
 
 	validateOpts *ValidateOpts
 }
@@ -93,31 +93,32 @@ func (bc *BatchControl) SetValidation(opts *ValidateOpts) {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (bc *BatchControl) Parse(record string) {
-	if utf8.RuneCountInString(record) != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
 	// 1-1 Always "8"
 	// 2-4 This is the same as the "Service code" field in previous Batch Header Record
-	bc.ServiceClassCode = bc.parseNumField(record[1:4])
+	bc.ServiceClassCode = bc.parseNumField(fields.field(1, 4))
 	// 5-10 Total number of Entry Detail Record in the batch
-	bc.EntryAddendaCount = bc.parseNumField(record[4:10])
+	bc.EntryAddendaCount = bc.parseNumField(fields.field(4, 10))
 	// 11-20 Total of all positions 4-11 on each Entry Detail Record in the batch. This is essentially the sum of all the RDFI routing numbers in the batch.
 	// If the sum exceeds 10 digits (because you have lots of Entry Detail Records), lop off the most significant digits of the sum until there are only 10
-	bc.EntryHash = bc.parseNumField(record[10:20])
+	bc.EntryHash = bc.parseNumField(fields.field(10, 20))
 	// 21-32 Number of cents of debit entries within the batch
-	bc.TotalDebitEntryDollarAmount = bc.parseNumField(record[20:32])
+	bc.TotalDebitEntryDollarAmount = bc.parseNumField(fields.field(20, 32))
 	// 33-44 Number of cents of credit entries within the batch
-	bc.TotalCreditEntryDollarAmount = bc.parseNumField(record[32:44])
+	bc.TotalCreditEntryDollarAmount = bc.parseNumField(fields.field(32, 44))
 	// 45-54 This is the same as the "Company identification" field in previous Batch Header Record
-	bc.CompanyIdentification = bc.parseStringFieldWithOpts(record[44:54], bc.validateOpts)
+	bc.CompanyIdentification = bc.parseStringFieldWithOpts(fields.field(44, 54), bc.validateOpts)
 	// 55-73 Seems to always be blank
-	bc.MessageAuthenticationCode = bc.parseStringFieldWithOpts(record[54:73], bc.validateOpts)
+	bc.MessageAuthenticationCode = bc.parseStringFieldWithOpts(fields.field(54, 73), bc.validateOpts)
 	// 74-79 Always blank (just fill with spaces)
 	// 80-87 This is the same as the "ODFI identification" field in previous Batch Header Record
-	bc.ODFIIdentification = bc.parseStringFieldWithOpts(record[79:87], bc.validateOpts)
+	bc.ODFIIdentification = bc.parseStringFieldWithOpts(fields.field(79, 87), bc.validateOpts)
 	// 88-94 This is the same as the "Batch number" field in previous Batch Header Record
-	bc.BatchNumber = bc.parseNumField(record[87:94])
+	bc.BatchNumber = bc.parseNumField(fields.field(87, 94))
 }
 
 // NewBatchControl returns a new BatchControl with default values for none exported fields
@@ -131,21 +132,23 @@ func NewBatchControl() *BatchControl {
 
 // String writes the BatchControl struct to a 94 character string.
 func (bc *BatchControl) String() string {
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(batchControlPos)
-	buf.WriteString(fmt.Sprintf("%v", bc.ServiceClassCode))
-	buf.WriteString(bc.EntryAddendaCountField())
-	buf.WriteString(bc.EntryHashField())
-	buf.WriteString(bc.TotalDebitEntryDollarAmountField())
-	buf.WriteString(bc.TotalCreditEntryDollarAmountField())
-	buf.WriteString(bc.CompanyIdentificationField())
-	buf.WriteString(bc.MessageAuthenticationCodeField())
-	buf.WriteString("      ")
-	buf.WriteString(bc.ODFIIdentificationField())
-	buf.WriteString(bc.BatchNumberField())
-	return buf.String()
+	return string(bc.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the BatchControl record to dst and returns the extended buffer.
+func (bc *BatchControl) AppendTo(dst []byte) []byte {
+	dst = append(dst, batchControlPos...)
+	dst = strconv.AppendInt(dst, int64(bc.ServiceClassCode), 10)
+	dst = bc.appendNumericField(dst, bc.EntryAddendaCount, 6)
+	dst = bc.appendNumericField(dst, bc.EntryHash, 10)
+	dst = bc.appendNumericField(dst, bc.TotalDebitEntryDollarAmount, 12)
+	dst = bc.appendNumericField(dst, bc.TotalCreditEntryDollarAmount, 12)
+	dst = bc.appendAlphaField(dst, bc.CompanyIdentification, 10)
+	dst = bc.appendAlphaField(dst, bc.MessageAuthenticationCode, 19)
+	dst = append(dst, "      "...)
+	dst = bc.appendStringField(dst, bc.ODFIIdentification, 8)
+	dst = bc.appendNumericField(dst, bc.BatchNumber, 7)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -181,6 +184,22 @@ func (bc *BatchControl) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (bc *BatchControl) validateAll() []error {
+	var errs fieldErrors
+	if bc.ServiceClassCode == 0 {
+		errs.add("ServiceClassCode", ErrConstructor, strconv.Itoa(bc.ServiceClassCode))
+	}
+	if bc.ODFIIdentification == "000000000" {
+		errs.add("ODFIIdentification", ErrConstructor, bc.ODFIIdentificationField())
+	}
+	errs.add("ServiceClassCode", bc.isServiceClass(bc.ServiceClassCode), strconv.Itoa(bc.ServiceClassCode))
+	errs.add("CompanyIdentification", bc.isAlphanumeric(bc.CompanyIdentification), bc.CompanyIdentification)
+	errs.add("MessageAuthenticationCode", bc.isAlphanumeric(bc.MessageAuthenticationCode), bc.MessageAuthenticationCode)
+	return errs
+}
+
 // EntryAddendaCountField gets a string of the addenda count zero padded
 func (bc *BatchControl) EntryAddendaCountField() string {
 	return bc.numericField(bc.EntryAddendaCount, 6)
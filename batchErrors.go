@@ -25,6 +25,8 @@ import (
 var (
 	// ErrBatchNoEntries is the error given when a batch doesn't have any entries
 	ErrBatchNoEntries = errors.New("must have Entry Record(s) to be built")
+	// ErrBatchControl is the error given when a batch doesn't have a Batch Control (or ADV Batch Control) record
+	ErrBatchControl = errors.New("must have a Batch Control Record")
 	// ErrBatchADVCount is the error given when an ADV batch has too many entries
 	ErrBatchADVCount = errors.New("there can be a maximum of 9999 ADV Sequence Numbers (ADV Entry Detail Records)")
 	// ErrBatchAddendaIndicator is the error given when the addenda indicator is incorrectly set
@@ -55,8 +57,50 @@ var (
 	ErrBatchCompanyEntryDescriptionREDEPCHECK = errors.New("this batch type requires that the Company Entry Description is REDEPCHECK")
 	// ErrBatchAddendaCategory is the error given when the addenda isn't allowed for the batch's type and category
 	ErrBatchAddendaCategory = errors.New("this batch type does not allow this addenda for category")
+	// ErrBatchEffectiveEntryDatePast is the error given when a batch's effective entry date has already passed
+	ErrBatchEffectiveEntryDatePast = errors.New("effective entry date is in the past")
+	// ErrBatchEffectiveEntryDateFuture is the error given when a batch's effective entry date is too many banking days ahead
+	ErrBatchEffectiveEntryDateFuture = errors.New("effective entry date is too far in the future")
+	// ErrBatchSameDayIAT is the error given when IAT entries are submitted as Same Day ACH
+	ErrBatchSameDayIAT = errors.New("IAT entries are not eligible for Same Day ACH")
+	// ErrBatchSameDayAmount is the error given when an entry is over the Same Day ACH per-entry limit
+	ErrBatchSameDayAmount = errors.New("entry amount exceeds the Same Day ACH per-entry limit")
+	// ErrBatchSameDayEffectiveEntryDate is the error given when the effective entry date isn't the banking day a Same Day file is created
+	ErrBatchSameDayEffectiveEntryDate = errors.New("effective entry date is not valid for a Same Day ACH submission")
+	// ErrBatchSameDaySettlementDate is the error given when the settlement date of a Same Day batch isn't its effective entry date
+	ErrBatchSameDaySettlementDate = errors.New("settlement date does not match the Same Day ACH effective entry date")
+	// ErrBatchSameDayDescriptiveDate is the error given when an "SDHHMM" company descriptive date isn't a Same Day settlement time
+	ErrBatchSameDayDescriptiveDate = errors.New("company descriptive date is not a Same Day ACH settlement time")
 )
 
+// batchErrorCodes are the stable codes of the batch errors above, see CodeOf
+var batchErrorCodes = map[error]ErrorCode{
+	ErrBatchNoEntries:                         "ACH-BATCH-NO-ENTRIES",
+	ErrBatchControl:                           "ACH-BATCH-CONTROL-MISSING",
+	ErrBatchADVCount:                          "ACH-BATCH-ADV-COUNT",
+	ErrBatchAddendaIndicator:                  "ACH-BATCH-ADDENDA-INDICATOR",
+	ErrBatchOriginatorDNE:                     "ACH-BATCH-ORIGINATOR-DNE",
+	ErrBatchInvalidCardTransactionType:        "ACH-BATCH-CARD-TRANSACTION-TYPE",
+	ErrBatchDebitOnly:                         "ACH-BATCH-DEBIT-ONLY",
+	ErrBatchCheckSerialNumber:                 "ACH-BATCH-CHECK-SERIAL-NUMBER",
+	ErrBatchSECType:                           "ACH-BATCH-SEC-TYPE",
+	ErrBatchServiceClassCode:                  "ACH-BATCH-SERVICE-CLASS-CODE",
+	ErrBatchTransactionCode:                   "ACH-BATCH-TRANSACTION-CODE",
+	ErrBatchTransactionCodeAddenda:            "ACH-BATCH-TRANSACTION-CODE-ADDENDA",
+	ErrBatchAmountNonZero:                     "ACH-BATCH-AMOUNT-NON-ZERO",
+	ErrBatchAmountZero:                        "ACH-BATCH-AMOUNT-ZERO",
+	ErrBatchCompanyEntryDescriptionAutoenroll: "ACH-BATCH-DESCRIPTION-AUTOENROLL",
+	ErrBatchCompanyEntryDescriptionREDEPCHECK: "ACH-BATCH-DESCRIPTION-REDEPCHECK",
+	ErrBatchAddendaCategory:                   "ACH-BATCH-ADDENDA-CATEGORY",
+	ErrBatchEffectiveEntryDatePast:            "ACH-BATCH-EFFECTIVE-DATE-PAST",
+	ErrBatchEffectiveEntryDateFuture:          "ACH-BATCH-EFFECTIVE-DATE-FUTURE",
+	ErrBatchSameDayIAT:                        "ACH-BATCH-SAME-DAY-IAT",
+	ErrBatchSameDayAmount:                     "ACH-BATCH-SAME-DAY-AMOUNT",
+	ErrBatchSameDayEffectiveEntryDate:         "ACH-BATCH-SAME-DAY-EFFECTIVE-DATE",
+	ErrBatchSameDaySettlementDate:             "ACH-BATCH-SAME-DAY-SETTLEMENT-DATE",
+	ErrBatchSameDayDescriptiveDate:            "ACH-BATCH-SAME-DAY-DESCRIPTIVE-DATE",
+}
+
 // BatchError is an Error that describes batch validation issues
 type BatchError struct {
 	BatchNumber int
@@ -64,6 +108,10 @@ type BatchError struct {
 	FieldName   string
 	FieldValue  interface{}
 	Err         error
+
+	// Line is the line the record with the problem was read from, or the line of the batch header for
+	// problems with the whole batch. It is 0 when the batch wasn't read by a Reader.
+	Line int
 }
 
 func (e *BatchError) Error() string {
@@ -78,6 +126,11 @@ func (e *BatchError) Unwrap() error {
 	return e.Err
 }
 
+// Code returns the ErrorCode of the error BatchError wraps
+func (e *BatchError) Code() ErrorCode {
+	return CodeOf(e.Err)
+}
+
 // error returns a new BatchError based on err
 func (b *Batch) Error(field string, err error, values ...interface{}) error {
 	if err == nil {
@@ -93,6 +146,7 @@ func (b *Batch) Error(field string, err error, values ...interface{}) error {
 	if b != nil {
 		be.BatchNumber = b.Header.BatchNumber
 		be.BatchType = b.Header.StandardEntryClassCode
+		be.Line = errorLine(err, b.Header.SourceLine())
 	}
 	// only the first value counts
 	if len(values) > 0 {
@@ -114,6 +168,7 @@ func (iatBatch *IATBatch) Error(field string, err error, values ...interface{})
 		BatchType:   iatBatch.Header.StandardEntryClassCode,
 		FieldName:   field,
 		Err:         err,
+		Line:        errorLine(err, iatBatch.Header.SourceLine()),
 	}
 	// only the first value counts
 	if len(values) > 0 {
@@ -142,6 +197,11 @@ func (e ErrBatchHeaderControlEquality) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-BATCH-HEADER-CONTROL-MISMATCH
+func (e ErrBatchHeaderControlEquality) Code() ErrorCode {
+	return "ACH-BATCH-HEADER-CONTROL-MISMATCH"
+}
+
 // ErrBatchCalculatedControlEquality is the error given when the control record does not match the calculated value
 type ErrBatchCalculatedControlEquality struct {
 	Message         string
@@ -162,6 +222,11 @@ func (e ErrBatchCalculatedControlEquality) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-BATCH-CONTROL-OUT-OF-BALANCE
+func (e ErrBatchCalculatedControlEquality) Code() ErrorCode {
+	return "ACH-BATCH-CONTROL-OUT-OF-BALANCE"
+}
+
 // ErrBatchAscending is the error given when the trace numbers in a batch are not in ascending order
 type ErrBatchAscending struct {
 	Message       string
@@ -182,6 +247,11 @@ func (e ErrBatchAscending) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-BATCH-TRACE-ASCENDING
+func (e ErrBatchAscending) Code() ErrorCode {
+	return "ACH-BATCH-TRACE-ASCENDING"
+}
+
 // ErrBatchCategory is the error given when a batch has entires with two different categories
 type ErrBatchCategory struct {
 	Message   string
@@ -202,6 +272,11 @@ func (e ErrBatchCategory) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-BATCH-CATEGORY-MIXED
+func (e ErrBatchCategory) Code() ErrorCode {
+	return "ACH-BATCH-CATEGORY-MIXED"
+}
+
 // ErrBatchTraceNumberNotODFI is the error given when a batch's ODFI does not match an entry's trace number
 type ErrBatchTraceNumberNotODFI struct {
 	Message     string
@@ -222,6 +297,11 @@ func (e ErrBatchTraceNumberNotODFI) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-BATCH-TRACE-ODFI
+func (e ErrBatchTraceNumberNotODFI) Code() ErrorCode {
+	return "ACH-BATCH-TRACE-ODFI"
+}
+
 // ErrBatchAddendaTraceNumber is the error given when the entry detail sequence number doesn't match the trace number
 type ErrBatchAddendaTraceNumber struct {
 	Message           string
@@ -242,6 +322,11 @@ func (e ErrBatchAddendaTraceNumber) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-BATCH-ADDENDA-TRACE-NUMBER
+func (e ErrBatchAddendaTraceNumber) Code() ErrorCode {
+	return "ACH-BATCH-ADDENDA-TRACE-NUMBER"
+}
+
 // ErrBatchAddendaCount is the error given when there are too many addenda than allowed for the batch type
 type ErrBatchAddendaCount struct {
 	Message      string
@@ -262,6 +347,11 @@ func (e ErrBatchAddendaCount) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-BATCH-ADDENDA-COUNT
+func (e ErrBatchAddendaCount) Code() ErrorCode {
+	return "ACH-BATCH-ADDENDA-COUNT"
+}
+
 // ErrBatchRequiredAddendaCount is the error given when the batch type requires a certain number of addenda, which is not met
 type ErrBatchRequiredAddendaCount struct {
 	Message       string
@@ -282,6 +372,11 @@ func (e ErrBatchRequiredAddendaCount) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-BATCH-ADDENDA-REQUIRED-COUNT
+func (e ErrBatchRequiredAddendaCount) Code() ErrorCode {
+	return "ACH-BATCH-ADDENDA-REQUIRED-COUNT"
+}
+
 // ErrBatchExpectedAddendaCount is the error given when the batch type has entries with a field
 // for the number of addenda, and a different number of addenda are foound
 type ErrBatchExpectedAddendaCount struct {
@@ -303,6 +398,11 @@ func (e ErrBatchExpectedAddendaCount) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-BATCH-ADDENDA-EXPECTED-COUNT
+func (e ErrBatchExpectedAddendaCount) Code() ErrorCode {
+	return "ACH-BATCH-ADDENDA-EXPECTED-COUNT"
+}
+
 // ErrBatchServiceClassTranCode is the error given when the transaction code is not valid for the batch's service class
 type ErrBatchServiceClassTranCode struct {
 	Message          string
@@ -323,6 +423,11 @@ func (e ErrBatchServiceClassTranCode) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-BATCH-SERVICE-CLASS-TRANSACTION-CODE
+func (e ErrBatchServiceClassTranCode) Code() ErrorCode {
+	return "ACH-BATCH-SERVICE-CLASS-TRANSACTION-CODE"
+}
+
 // ErrBatchAmount is the error given when the amount exceeds the batch type's limit
 type ErrBatchAmount struct {
 	Message string
@@ -344,6 +449,11 @@ func (e ErrBatchAmount) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-BATCH-AMOUNT-LIMIT
+func (e ErrBatchAmount) Code() ErrorCode {
+	return "ACH-BATCH-AMOUNT-LIMIT"
+}
+
 // ErrBatchIATNOC is the error given when an IAT batch has an NOC, and there are invalid values
 type ErrBatchIATNOC struct {
 	Message  string
@@ -364,3 +474,8 @@ func NewErrBatchIATNOC(found, expected interface{}) ErrBatchIATNOC {
 func (e ErrBatchIATNOC) Error() string {
 	return e.Message
 }
+
+// Code returns ACH-BATCH-IAT-NOC
+func (e ErrBatchIATNOC) Code() ErrorCode {
+	return "ACH-BATCH-IAT-NOC"
+}
@@ -18,11 +18,9 @@
 package ach
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 	"time"
-	"unicode/utf8"
 )
 
 // BatchHeader identifies the originating entity and the type of transactions
@@ -119,6 +117,8 @@ type BatchHeader struct {
 
 	// converters is composed for ACH to golang Converters
 	converters
+	// source is where the record was read from
+	source
 
 	validateOpts *ValidateOpts
 }
@@ -149,100 +149,67 @@ func NewBatchHeader() *BatchHeader {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (bh *BatchHeader) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 1:
-			reset()
-		case 4:
-			// 2-4 MixedCreditsAnDebits (200), CreditsOnly (220), DebitsOnly (225)
-			bh.ServiceClassCode = bh.parseNumField(reset())
-		case 20:
-			// 5-20 Your company's name. This name may appear on the receivers' statements prepared by the RDFI.
-			bh.CompanyName = bh.parseStringFieldWithOpts(reset(), bh.validateOpts)
-		case 40:
-			// 21-40 Optional field you may use to describe the batch for internal accounting purposes
-			bh.CompanyDiscretionaryData = bh.parseStringFieldWithOpts(reset(), bh.validateOpts)
-		case 50:
-			// 41-50 A 10-digit number assigned to you by the ODFI once they approve you to
-			// originate ACH files through them. This is the same as the "Immediate origin" field in File Header Record
-			bh.CompanyIdentification = bh.parseStringFieldWithOpts(reset(), bh.validateOpts)
-		case 53:
-			// 51-53 If the entries are PPD (credits/debits towards consumer account), use PPD.
-			// If the entries are CCD (credits/debits towards corporate account), use CCD.
-			// The difference between the 2 SEC codes are outside of the scope of this post.
-			bh.StandardEntryClassCode = reset()
-		case 63:
-			// 54-63 Your description of the transaction. This text will appear on the receivers' bank statement.
-			// For example: "Payroll   "
-			bh.CompanyEntryDescription = bh.parseStringFieldWithOpts(reset(), bh.validateOpts)
-		case 69:
-			// 64-69 The date you choose to identify the transactions in YYMMDD format.
-			// This date may be printed on the receivers' bank statement by the RDFI
-			bh.CompanyDescriptiveDate = bh.parseStringFieldWithOpts(reset(), bh.validateOpts)
-		case 75:
-			// 70-75 Date transactions are to be posted to the receivers' account.
-			// You almost always want the transaction to post as soon as possible, so put tomorrow's date in YYMMDD format
-			bh.EffectiveEntryDate = bh.validateSimpleDate(reset())
-		case 78:
-			// 76-78 Always blank if creating batches (just fill with spaces).
-			// Set to file value when parsing. Julian day format.
-			bh.SettlementDate = bh.validateSettlementDate(reset())
-		case 79:
-			// 79-79 Always 1
-			bh.OriginatorStatusCode = bh.parseNumField(reset())
-		case 87:
-			// 80-87 Your ODFI's routing number without the last digit. The last digit is simply a
-			// checksum digit, which is why it is not necessary
-			bh.ODFIIdentification = bh.parseStringFieldWithOpts(reset(), bh.validateOpts)
-		case 94:
-			// 88-94 Sequential number of this Batch Header Record
-			// For example, put "1" if this is the first Batch Header Record in the file
-			bh.BatchNumber = bh.parseNumField(reset())
-		}
-	}
+	// 2-4 MixedCreditsAnDebits (200), CreditsOnly (220), DebitsOnly (225)
+	bh.ServiceClassCode = bh.parseNumField(fields.field(1, 4))
+	// 5-20 Your company's name. This name may appear on the receivers' statements prepared by the RDFI.
+	bh.CompanyName = bh.parseStringFieldWithOpts(fields.field(4, 20), bh.validateOpts)
+	// 21-40 Optional field you may use to describe the batch for internal accounting purposes
+	bh.CompanyDiscretionaryData = bh.parseStringFieldWithOpts(fields.field(20, 40), bh.validateOpts)
+	// 41-50 A 10-digit number assigned to you by the ODFI once they approve you to
+	// originate ACH files through them. This is the same as the "Immediate origin" field in File Header Record
+	bh.CompanyIdentification = bh.parseStringFieldWithOpts(fields.field(40, 50), bh.validateOpts)
+	// 51-53 If the entries are PPD (credits/debits towards consumer account), use PPD.
+	// If the entries are CCD (credits/debits towards corporate account), use CCD.
+	// The difference between the 2 SEC codes are outside of the scope of this post.
+	bh.StandardEntryClassCode = fields.field(50, 53)
+	// 54-63 Your description of the transaction. This text will appear on the receivers' bank statement.
+	// For example: "Payroll   "
+	bh.CompanyEntryDescription = bh.parseStringFieldWithOpts(fields.field(53, 63), bh.validateOpts)
+	// 64-69 The date you choose to identify the transactions in YYMMDD format.
+	// This date may be printed on the receivers' bank statement by the RDFI
+	bh.CompanyDescriptiveDate = bh.parseStringFieldWithOpts(fields.field(63, 69), bh.validateOpts)
+	// 70-75 Date transactions are to be posted to the receivers' account.
+	// You almost always want the transaction to post as soon as possible, so put tomorrow's date in YYMMDD format
+	bh.EffectiveEntryDate = bh.validateSimpleDate(fields.field(69, 75))
+	// 76-78 Always blank if creating batches (just fill with spaces).
+	// Set to file value when parsing. Julian day format.
+	bh.SettlementDate = bh.validateSettlementDate(fields.field(75, 78))
+	// 79-79 Always 1
+	bh.OriginatorStatusCode = bh.parseNumField(fields.field(78, 79))
+	// 80-87 Your ODFI's routing number without the last digit. The last digit is simply a
+	// checksum digit, which is why it is not necessary
+	bh.ODFIIdentification = bh.parseStringFieldWithOpts(fields.field(79, 87), bh.validateOpts)
+	// 88-94 Sequential number of this Batch Header Record
+	// For example, put "1" if this is the first Batch Header Record in the file
+	bh.BatchNumber = bh.parseNumField(fields.field(87, 94))
 }
 
 // String writes the BatchHeader struct to a 94 character string.
 func (bh *BatchHeader) String() string {
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(batchHeaderPos)
-	buf.WriteString(strconv.Itoa(bh.ServiceClassCode))
-	buf.WriteString(bh.CompanyNameField())
-	buf.WriteString(bh.CompanyDiscretionaryDataField())
-	buf.WriteString(bh.CompanyIdentificationField())
-	buf.WriteString(bh.StandardEntryClassCode)
-	buf.WriteString(bh.CompanyEntryDescriptionField())
-	buf.WriteString(bh.CompanyDescriptiveDateField())
-	buf.WriteString(bh.EffectiveEntryDateField())
-	buf.WriteString(bh.SettlementDateField())
-	buf.WriteString(fmt.Sprintf("%v", bh.OriginatorStatusCode))
-	buf.WriteString(bh.ODFIIdentificationField())
-	buf.WriteString(bh.BatchNumberField())
-	return buf.String()
+	return string(bh.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the BatchHeader record to dst and returns the extended buffer.
+func (bh *BatchHeader) AppendTo(dst []byte) []byte {
+	dst = append(dst, batchHeaderPos...)
+	dst = strconv.AppendInt(dst, int64(bh.ServiceClassCode), 10)
+	dst = bh.appendAlphaField(dst, bh.CompanyName, 16)
+	dst = bh.appendAlphaField(dst, bh.CompanyDiscretionaryData, 20)
+	dst = bh.appendAlphaField(dst, bh.CompanyIdentification, 10)
+	dst = append(dst, bh.StandardEntryClassCode...)
+	dst = bh.appendAlphaField(dst, bh.CompanyEntryDescription, 10)
+	dst = bh.appendAlphaField(dst, bh.CompanyDescriptiveDate, 6)
+	dst = append(dst, bh.EffectiveEntryDateField()...)
+	dst = bh.appendAlphaField(dst, bh.SettlementDate, 3)
+	dst = strconv.AppendInt(dst, int64(bh.OriginatorStatusCode), 10)
+	dst = bh.appendStringField(dst, bh.ODFIIdentification, 8)
+	dst = bh.appendNumericField(dst, bh.BatchNumber, 7)
+	return dst
 }
 
 // Equal returns true only if two BatchHeaders are equal.
@@ -349,6 +316,43 @@ func (bh *BatchHeader) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (bh *BatchHeader) validateAll() []error {
+	var errs fieldErrors
+	if bh.ServiceClassCode == 0 {
+		errs.add("ServiceClassCode", ErrConstructor, strconv.Itoa(bh.ServiceClassCode))
+	}
+	if bh.CompanyName == "" {
+		errs.add("CompanyName", ErrConstructor, bh.CompanyName)
+	}
+	if bh.CompanyIdentification == "" {
+		errs.add("CompanyIdentification", ErrConstructor, bh.CompanyIdentification)
+	}
+	if bh.StandardEntryClassCode == "" {
+		errs.add("StandardEntryClassCode", ErrConstructor, bh.StandardEntryClassCode)
+	}
+	if bh.CompanyEntryDescription == "" {
+		errs.add("CompanyEntryDescription", ErrConstructor, bh.CompanyEntryDescription)
+	}
+	if bh.ODFIIdentification == "" {
+		errs.add("ODFIIdentification", ErrConstructor, bh.ODFIIdentificationField())
+	}
+	if bh.validateOpts == nil || bh.validateOpts.CheckTransactionCode == nil {
+		errs.add("ServiceClassCode", bh.isServiceClass(bh.ServiceClassCode), bh.ServiceClassCode)
+	}
+	errs.add("StandardEntryClassCode", bh.isSECCode(bh.StandardEntryClassCode), bh.StandardEntryClassCode)
+	errs.add("OriginatorStatusCode", bh.isOriginatorStatusCode(bh.OriginatorStatusCode), bh.OriginatorStatusCode)
+	if bh.StandardEntryClassCode != ADV && bh.OriginatorStatusCode == 0 {
+		errs.add("OriginatorStatusCode", ErrOrigStatusCode, bh.OriginatorStatusCode)
+	}
+	errs.add("CompanyName", bh.isAlphanumeric(bh.CompanyName), bh.CompanyName)
+	errs.add("CompanyDiscretionaryData", bh.isAlphanumeric(bh.CompanyDiscretionaryData), bh.CompanyDiscretionaryData)
+	errs.add("CompanyIdentification", bh.isAlphanumeric(bh.CompanyIdentification), bh.CompanyIdentification)
+	errs.add("CompanyEntryDescription", bh.isAlphanumeric(bh.CompanyEntryDescription), bh.CompanyEntryDescription)
+	return errs
+}
+
 // CompanyNameField get the CompanyName left padded
 func (bh *BatchHeader) CompanyNameField() string {
 	return bh.alphaField(bh.CompanyName, 16)
@@ -81,7 +81,7 @@ func (batch *BatchMTE) Validate() error {
 			return batch.Error("IdentificationNumber", ErrIdentificationNumber, entry.IdentificationNumber)
 		}
 	}
-	return nil
+	return batch.verifyRules()
 }
 
 // Create will tabulate and assemble an ACH batch into a valid state. This includes
@@ -86,7 +86,7 @@ func (batch *BatchPOS) Validate() error {
 			}
 		}
 	}
-	return nil
+	return batch.verifyRules()
 }
 
 // Create will tabulate and assemble an ACH batch into a valid state. This includes
@@ -100,7 +100,7 @@ func (batch *BatchSHR) Validate() error {
 			}
 		}
 	}
-	return nil
+	return batch.verifyRules()
 }
 
 // Create will tabulate and assemble an ACH batch into a valid state. This includes
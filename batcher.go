@@ -52,6 +52,16 @@ type Batcher interface {
 	SetValidation(*ValidateOpts)
 }
 
+// TraceNumberSetter is implemented by batches which can number their entries from a TraceNumberAllocator during Create.
+// It's kept separate from Batcher so existing Batcher implementations outside this package still satisfy Batcher.
+//
+//	if b, ok := batch.(ach.TraceNumberSetter); ok {
+//		b.WithTraceNumbers(alloc)
+//	}
+type TraceNumberSetter interface {
+	WithTraceNumbers(alloc TraceNumberAllocator)
+}
+
 // Offset contains the associated information to append an 'Offset Record' on an ACH batch during Create.
 type Offset struct {
 	RoutingNumber string            `json:"routingNumber"`
@@ -0,0 +1,311 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/base"
+)
+
+// Company identifies the originator of a batch created with Builder.
+type Company struct {
+	// Name of the Originator, shown to receivers
+	Name string `json:"name"`
+
+	// Identification is the Company Identification of the Originator
+	Identification string `json:"identification"`
+
+	// EntryDescription is the Company Entry Description shown on receivers' statements, e.g. "PAYROLL"
+	EntryDescription string `json:"entryDescription"`
+
+	// DiscretionaryData is optional data for the ODFI
+	DiscretionaryData string `json:"discretionaryData,omitempty"`
+
+	// DescriptiveDate is an optional Company Descriptive Date, e.g. "SD1300"
+	DescriptiveDate string `json:"descriptiveDate,omitempty"`
+
+	// EffectiveEntryDate is the date entries should settle. When zero the next banking day is used.
+	// Weekends and holidays are moved to the next banking day.
+	EffectiveEntryDate time.Time `json:"effectiveEntryDate,omitempty"`
+
+	// ODFIIdentification is the routing number of the ODFI. When empty the file's origin is used.
+	ODFIIdentification string `json:"odfiIdentification,omitempty"`
+}
+
+// EntryOption sets optional fields on an EntryDetail created with Builder.
+type EntryOption func(*EntryDetail)
+
+// WithSavingsAccount posts the entry to a savings account instead of a checking account.
+func WithSavingsAccount() EntryOption {
+	return func(ed *EntryDetail) {
+		switch ed.TransactionCode {
+		case CheckingCredit:
+			ed.TransactionCode = SavingsCredit
+		case CheckingDebit:
+			ed.TransactionCode = SavingsDebit
+		}
+	}
+}
+
+// WithIdentificationNumber sets the IdentificationNumber of the entry.
+func WithIdentificationNumber(id string) EntryOption {
+	return func(ed *EntryDetail) {
+		ed.IdentificationNumber = id
+	}
+}
+
+// WithDiscretionaryData sets the DiscretionaryData of the entry.
+func WithDiscretionaryData(data string) EntryOption {
+	return func(ed *EntryDetail) {
+		ed.DiscretionaryData = data
+	}
+}
+
+// WithAddenda05 attaches an Addenda05 record with the payment related information to the entry.
+func WithAddenda05(paymentRelatedInformation string) EntryOption {
+	return func(ed *EntryDetail) {
+		addenda05 := NewAddenda05()
+		addenda05.ID = base.ID()
+		addenda05.PaymentRelatedInformation = paymentRelatedInformation
+		addenda05.SequenceNumber = len(ed.Addenda05) + 1
+		ed.AddAddenda05(addenda05)
+		ed.AddendaRecordIndicator = 1
+	}
+}
+
+// Builder creates a File one batch and entry at a time. Batches are numbered in ascending order
+// and entries are given trace numbers from their ODFI in the order they're added.
+//
+// The first error encountered is kept and returned from Build, so calls can be chained:
+//
+//	file, err := ach.NewBuilder("121042882", "231380104").
+//		Batch(ach.PPD, ach.Company{Name: "Acme Corp", Identification: "121042882", EntryDescription: "PAYROLL"}).
+//		Credit("231380104", "12345678", 100000, "Jane Doe").
+//		Build()
+type Builder struct {
+	header  FileHeader
+	batches []*builderBatch
+	opts    *ValidateOpts
+	traces  TraceNumberAllocator
+	cal     *Calendar
+	now     time.Time
+	err     error
+}
+
+type builderBatch struct {
+	header  *BatchHeader
+	entries []*EntryDetail
+}
+
+// NewBuilder returns a Builder for a File sent from origin to destination, which are usually routing numbers.
+func NewBuilder(origin, destination string) *Builder {
+	now := time.Now()
+
+	fh := NewFileHeader()
+	fh.ID = base.ID()
+	fh.ImmediateOrigin = origin
+	fh.ImmediateDestination = destination
+	fh.FileCreationDate = now.Format("060102")
+	fh.FileCreationTime = now.Format("1504")
+
+	return &Builder{
+		header: fh,
+		traces: NewMemoryTraceNumberAllocator(),
+		cal:    NewCalendar(),
+		now:    now,
+	}
+}
+
+// Names sets the ImmediateOriginName and ImmediateDestinationName of the File.
+func (b *Builder) Names(originName, destinationName string) *Builder {
+	b.header.ImmediateOriginName = originName
+	b.header.ImmediateDestinationName = destinationName
+	return b
+}
+
+// CreatedAt sets the FileCreationDate and FileCreationTime of the File. NewBuilder uses the current time.
+func (b *Builder) CreatedAt(when time.Time) *Builder {
+	b.now = when
+	b.header.FileCreationDate = when.Format("060102")
+	b.header.FileCreationTime = when.Format("1504")
+	return b
+}
+
+// TraceNumbers sets the TraceNumberAllocator entries are numbered from. NewBuilder numbers each ODFI's entries from 1.
+func (b *Builder) TraceNumbers(alloc TraceNumberAllocator) *Builder {
+	b.traces = alloc
+	return b
+}
+
+// SetValidation stores ValidateOpts on the File and its batches.
+func (b *Builder) SetValidation(opts *ValidateOpts) *Builder {
+	b.opts = opts
+	b.header.SetValidation(opts)
+	return b
+}
+
+// Batch starts a new batch of sec entries from company. Entries added afterwards are part of this batch.
+func (b *Builder) Batch(sec string, company Company) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	bh := NewBatchHeader()
+	bh.ID = base.ID()
+	bh.StandardEntryClassCode = sec
+	bh.CompanyName = company.Name
+	bh.CompanyIdentification = company.Identification
+	bh.CompanyEntryDescription = company.EntryDescription
+	bh.CompanyDiscretionaryData = company.DiscretionaryData
+	bh.CompanyDescriptiveDate = company.DescriptiveDate
+	odfi := company.ODFIIdentification
+	if odfi == "" {
+		odfi = b.header.ImmediateOrigin
+	}
+	bh.ODFIIdentification = aba8(strings.TrimSpace(odfi))
+	if bh.ODFIIdentification == "" {
+		b.err = fieldError("ODFIIdentification", ErrConstructor, odfi)
+		return b
+	}
+	bh.BatchNumber = len(b.batches) + 1
+
+	effective := company.EffectiveEntryDate
+	if effective.IsZero() {
+		effective = b.cal.NextBankingDay(b.now)
+	}
+	b.cal.SetEffectiveEntryDate(bh, effective)
+
+	b.batches = append(b.batches, &builderBatch{header: bh})
+	return b
+}
+
+// Credit adds an entry sending amount (in cents) to the receiver's checking account in the current batch.
+// routing is the receiver's 8 or 9 digit routing number.
+func (b *Builder) Credit(routing, account string, amount int, name string, opts ...EntryOption) *Builder {
+	return b.addEntry(CheckingCredit, routing, account, amount, name, opts)
+}
+
+// Debit adds an entry pulling amount (in cents) from the receiver's checking account in the current batch.
+// routing is the receiver's 8 or 9 digit routing number.
+func (b *Builder) Debit(routing, account string, amount int, name string, opts ...EntryOption) *Builder {
+	return b.addEntry(CheckingDebit, routing, account, amount, name, opts)
+}
+
+// Entry adds a caller created EntryDetail to the current batch. Its trace number is assigned by the Builder.
+func (b *Builder) Entry(entry *EntryDetail) *Builder {
+	if b.err != nil {
+		return b
+	}
+	if len(b.batches) == 0 {
+		b.err = ErrFileEntryOutsideBatch
+		return b
+	}
+	current := b.batches[len(b.batches)-1]
+
+	trace, err := b.traces.Next(current.header.ODFIIdentification)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	setAllocatedTraceNumber(entry, trace)
+
+	current.entries = append(current.entries, entry)
+	return b
+}
+
+func (b *Builder) addEntry(code int, routing, account string, amount int, name string, opts []EntryOption) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	ed := NewEntryDetail()
+	ed.ID = base.ID()
+	ed.TransactionCode = code
+	if len(routing) == 8 {
+		ed.RDFIIdentification = routing
+		ed.CheckDigit = strconv.Itoa(CalculateCheckDigit(routing))
+	} else {
+		if err := CheckRoutingNumber(routing); err != nil {
+			b.err = fieldError("RDFIIdentification", err, routing)
+			return b
+		}
+		ed.SetRDFI(routing)
+	}
+	ed.DFIAccountNumber = account
+	ed.Amount = amount
+	ed.IndividualName = name
+	ed.Category = CategoryForward
+	for _, opt := range opts {
+		opt(ed)
+	}
+	return b.Entry(ed)
+}
+
+// Build creates and validates the File. The first error from building the File is returned.
+func (b *Builder) Build() (*File, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	file := NewFile()
+	file.ID = base.ID()
+	file.SetHeader(b.header)
+	file.SetValidation(b.opts)
+
+	for _, current := range b.batches {
+		hasCredits, hasDebits := false, false
+		for _, entry := range current.entries {
+			hasCredits = hasCredits || entry.CreditOrDebit() == "C"
+			hasDebits = hasDebits || entry.CreditOrDebit() == "D"
+		}
+		switch {
+		case hasCredits && hasDebits:
+			current.header.ServiceClassCode = MixedDebitsAndCredits
+		case hasDebits:
+			current.header.ServiceClassCode = DebitsOnly
+		default:
+			current.header.ServiceClassCode = CreditsOnly
+		}
+
+		batch, err := NewBatch(current.header)
+		if err != nil {
+			return nil, err
+		}
+		batch.SetID(current.header.ID)
+		batch.SetValidation(b.opts)
+		for _, entry := range current.entries {
+			batch.AddEntry(entry)
+		}
+		if err := batch.Create(); err != nil {
+			return nil, fmt.Errorf("batch %d: %w", current.header.BatchNumber, err)
+		}
+		file.AddBatch(batch)
+	}
+
+	if err := file.Create(); err != nil {
+		return nil, err
+	}
+	if err := file.Validate(); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
@@ -0,0 +1,118 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	company := Company{
+		Name:               "Acme Corp",
+		Identification:     "121042882",
+		EntryDescription:   "PAYROLL",
+		EffectiveEntryDate: time.Date(2023, time.December, 23, 0, 0, 0, 0, time.UTC), // Saturday
+	}
+	file, err := NewBuilder("121042882", "231380104").
+		Names("My Bank", "Federal Reserve Bank").
+		CreatedAt(time.Date(2023, time.December, 21, 10, 30, 0, 0, time.UTC)).
+		Batch(PPD, company).
+		Credit("231380104", "12345678", 100000, "Jane Doe").
+		Credit("23138010", "87654321", 2500, "John Doe", WithSavingsAccount(), WithAddenda05("bonus")).
+		Batch(CCD, company).
+		Debit("231380104", "55555", 5000, "Widgets Inc", WithIdentificationNumber("INV-1")).
+		Build()
+	require.NoError(t, err)
+
+	require.Equal(t, "231221", file.Header.FileCreationDate)
+	require.Equal(t, "1030", file.Header.FileCreationTime)
+	require.Len(t, file.Batches, 2)
+
+	b1 := file.Batches[0]
+	require.Equal(t, 1, b1.GetHeader().BatchNumber)
+	require.Equal(t, CreditsOnly, b1.GetHeader().ServiceClassCode)
+	require.Equal(t, "231226", b1.GetHeader().EffectiveEntryDate) // after the weekend and Christmas
+	require.Equal(t, "12104288", b1.GetHeader().ODFIIdentification)
+
+	entries := b1.GetEntries()
+	require.Len(t, entries, 2)
+	require.Equal(t, "121042880000001", entries[0].TraceNumber)
+	require.Equal(t, "121042880000002", entries[1].TraceNumber)
+	require.Equal(t, "4", entries[1].CheckDigit)
+	require.Equal(t, SavingsCredit, entries[1].TransactionCode)
+	require.Len(t, entries[1].Addenda05, 1)
+	require.Equal(t, "bonus", entries[1].Addenda05[0].PaymentRelatedInformation)
+
+	b2 := file.Batches[1]
+	require.Equal(t, 2, b2.GetHeader().BatchNumber)
+	require.Equal(t, DebitsOnly, b2.GetHeader().ServiceClassCode)
+	require.Equal(t, "121042880000003", b2.GetEntries()[0].TraceNumber)
+	require.Equal(t, "INV-1", b2.GetEntries()[0].IdentificationNumber)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+	parsed, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+	require.Len(t, parsed.Batches, 2)
+}
+
+func TestBuilder__PaddedOrigin(t *testing.T) {
+	company := Company{Name: "Acme Corp", Identification: "121042882", EntryDescription: "PAYROLL"}
+
+	file, err := NewBuilder(" 121042882", "231380104").
+		Batch(PPD, company).
+		Credit("231380104", "12345678", 100000, "Jane Doe").
+		Build()
+	require.NoError(t, err)
+	require.Equal(t, "12104288", file.Batches[0].GetHeader().ODFIIdentification)
+	require.Equal(t, "121042880000001", file.Batches[0].GetEntries()[0].TraceNumber)
+
+	company.ODFIIdentification = "0231380104"
+	file, err = NewBuilder(" 121042882", "231380104").
+		Batch(PPD, company).
+		Credit("231380104", "12345678", 100000, "Jane Doe").
+		Build()
+	require.NoError(t, err)
+	require.Equal(t, "23138010", file.Batches[0].GetHeader().ODFIIdentification)
+}
+
+func TestBuilderErrors(t *testing.T) {
+	company := Company{Name: "Acme Corp", Identification: "121042882", EntryDescription: "PAYROLL"}
+
+	_, err := NewBuilder("121042882", "231380104").
+		Credit("231380104", "12345678", 100000, "Jane Doe").
+		Build()
+	require.ErrorIs(t, err, ErrFileEntryOutsideBatch)
+
+	_, err = NewBuilder("121042882", "231380104").
+		Batch(PPD, company).
+		Credit("231380105", "12345678", 100000, "Jane Doe").
+		Build()
+	require.ErrorContains(t, err, "checksum mismatch")
+
+	// PPD entries need a receiver name
+	_, err = NewBuilder("121042882", "231380104").
+		Batch(PPD, company).
+		Credit("231380104", "12345678", 100000, "").
+		Build()
+	require.Error(t, err)
+}
@@ -0,0 +1,294 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"sort"
+	"time"
+)
+
+// Holiday is a day the Federal Reserve Banks are closed.
+type Holiday struct {
+	// Name of the holiday, e.g. "Independence Day"
+	Name string `json:"name"`
+
+	// Date is the day the Federal Reserve Banks are closed. Holidays falling on a Sunday are
+	// observed the following Monday. Holidays falling on a Saturday are not observed on Friday.
+	Date time.Time `json:"date"`
+}
+
+// Calendar determines banking days from weekends and the holidays observed by the Federal Reserve Banks.
+//
+// Dates are compared by their year, month and day in the location of each time.Time, so callers
+// should convert times into Eastern time (the time zone of the ACH Operators) first when it matters.
+// Methods which return a time.Time keep the location and clock time of their argument.
+type Calendar struct {
+	// closures are extra non-banking days added with AddHoliday
+	closures map[string]string
+}
+
+// NewCalendar returns a Calendar with the Federal Reserve holiday schedule.
+func NewCalendar() *Calendar {
+	return &Calendar{
+		closures: make(map[string]string),
+	}
+}
+
+// AddHoliday marks an extra day (such as an emergency closure) as a non-banking day.
+// AddHoliday is not safe to call concurrently with other Calendar methods.
+func (c *Calendar) AddHoliday(name string, date time.Time) {
+	if c.closures == nil {
+		c.closures = make(map[string]string)
+	}
+	c.closures[dateKey(date)] = name
+}
+
+// Holidays returns the days in year the Federal Reserve Banks are closed, in order.
+func (c *Calendar) Holidays(year int) []Holiday {
+	out := federalReserveHolidays(year)
+	for key, name := range c.closures {
+		if date, err := time.Parse("2006-01-02", key); err == nil && date.Year() == year {
+			out = append(out, Holiday{Name: name, Date: date})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Date.Before(out[j].Date)
+	})
+	return out
+}
+
+// Holiday returns the holiday observed on date, if any.
+func (c *Calendar) Holiday(date time.Time) (Holiday, bool) {
+	key := dateKey(date)
+	if name, exists := c.closures[key]; exists {
+		return Holiday{Name: name, Date: truncateDate(date)}, true
+	}
+	for _, holiday := range federalReserveHolidays(date.Year()) {
+		if dateKey(holiday.Date) == key {
+			return holiday, true
+		}
+	}
+	return Holiday{}, false
+}
+
+// IsHoliday reports if the Federal Reserve Banks are closed for a holiday on date.
+func (c *Calendar) IsHoliday(date time.Time) bool {
+	_, exists := c.Holiday(date)
+	return exists
+}
+
+// IsBankingDay reports if date is neither a weekend nor a holiday.
+func (c *Calendar) IsBankingDay(date time.Time) bool {
+	switch date.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	return !c.IsHoliday(date)
+}
+
+// NextBankingDay returns the first banking day after date.
+func (c *Calendar) NextBankingDay(date time.Time) time.Time {
+	next := date.AddDate(0, 0, 1)
+	for !c.IsBankingDay(next) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// PreviousBankingDay returns the last banking day before date.
+func (c *Calendar) PreviousBankingDay(date time.Time) time.Time {
+	prev := date.AddDate(0, 0, -1)
+	for !c.IsBankingDay(prev) {
+		prev = prev.AddDate(0, 0, -1)
+	}
+	return prev
+}
+
+// AddBankingDays returns the date n banking days after date, or before date when n is negative.
+// date is returned unchanged when n is zero.
+func (c *Calendar) AddBankingDays(date time.Time, n int) time.Time {
+	for ; n > 0; n-- {
+		date = c.NextBankingDay(date)
+	}
+	for ; n < 0; n++ {
+		date = c.PreviousBankingDay(date)
+	}
+	return date
+}
+
+// BankingDaysBetween returns the number of banking days after start up to and including end.
+// The result is negative when end is before start.
+func (c *Calendar) BankingDaysBetween(start, end time.Time) int {
+	from, to := truncateDate(start), truncateDate(end)
+	sign := 1
+	if to.Before(from) {
+		from, to = to, from
+		sign = -1
+	}
+	days := 0
+	for day := from.AddDate(0, 0, 1); !day.After(to); day = day.AddDate(0, 0, 1) {
+		if c.IsBankingDay(day) {
+			days++
+		}
+	}
+	return sign * days
+}
+
+// SettlementDate returns the banking day an entry with the given effective entry date settles on.
+// Entries with an effective entry date on a weekend or holiday settle the next banking day.
+func (c *Calendar) SettlementDate(effectiveEntryDate time.Time) time.Time {
+	if c.IsBankingDay(effectiveEntryDate) {
+		return effectiveEntryDate
+	}
+	return c.NextBankingDay(effectiveEntryDate)
+}
+
+// SetEffectiveEntryDate sets the EffectiveEntryDate of bh to date, or the next banking day when date
+// is a weekend or holiday. The effective entry date which was set is returned.
+func (c *Calendar) SetEffectiveEntryDate(bh *BatchHeader, date time.Time) time.Time {
+	date = c.SettlementDate(date)
+	bh.EffectiveEntryDate = date.Format("060102")
+	return date
+}
+
+// SetIATEffectiveEntryDate sets the EffectiveEntryDate of bh to date, or the next banking day when date
+// is a weekend or holiday. The effective entry date which was set is returned.
+func (c *Calendar) SetIATEffectiveEntryDate(bh *IATBatchHeader, date time.Time) time.Time {
+	date = c.SettlementDate(date)
+	bh.EffectiveEntryDate = date.Format("060102")
+	return date
+}
+
+// FileDateLimits are the checks made by Calendar.CheckFileDates.
+type FileDateLimits struct {
+	// StaleBankingDays is the number of banking days after its FileCreationDate a file becomes stale.
+	// Zero disables the check.
+	StaleBankingDays int `json:"staleBankingDays"`
+
+	// MaxFutureBankingDays is the number of banking days an EffectiveEntryDate can be ahead of now.
+	// Zero disables the check.
+	MaxFutureBankingDays int `json:"maxFutureBankingDays"`
+
+	// AllowPastEffectiveEntryDates skips rejecting an EffectiveEntryDate before now.
+	AllowPastEffectiveEntryDates bool `json:"allowPastEffectiveEntryDates"`
+}
+
+// CheckFileDates returns an error if the FileCreationDate of file is stale or an EffectiveEntryDate
+// of its batches is in the past or too far ahead of now. Batches without an EffectiveEntryDate are skipped.
+func (c *Calendar) CheckFileDates(file *File, now time.Time, limits FileDateLimits) error {
+	today := truncateDate(now)
+
+	if limits.StaleBankingDays > 0 {
+		created, err := time.Parse("060102", file.Header.FileCreationDate)
+		if err != nil {
+			return fieldError("FileCreationDate", ErrValidDay, file.Header.FileCreationDate)
+		}
+		if today.After(c.AddBankingDays(created, limits.StaleBankingDays)) {
+			return fieldError("FileCreationDate", ErrFileCreationDateStale, file.Header.FileCreationDate)
+		}
+	}
+
+	check := func(batchNumber int, sec, value string) error {
+		if value == "" {
+			return nil
+		}
+		be := &BatchError{BatchNumber: batchNumber, BatchType: sec, FieldName: "EffectiveEntryDate", FieldValue: value}
+		effective, err := time.Parse("060102", value)
+		if err != nil {
+			be.Err = ErrValidDay
+			return be
+		}
+		if !limits.AllowPastEffectiveEntryDates && effective.Before(today) {
+			be.Err = ErrBatchEffectiveEntryDatePast
+			return be
+		}
+		if limits.MaxFutureBankingDays > 0 && effective.After(c.AddBankingDays(today, limits.MaxFutureBankingDays)) {
+			be.Err = ErrBatchEffectiveEntryDateFuture
+			return be
+		}
+		return nil
+	}
+	for _, b := range file.Batches {
+		bh := b.GetHeader()
+		if err := check(bh.BatchNumber, bh.StandardEntryClassCode, bh.EffectiveEntryDate); err != nil {
+			return err
+		}
+	}
+	for _, b := range file.IATBatches {
+		bh := b.GetHeader()
+		if err := check(bh.BatchNumber, bh.StandardEntryClassCode, bh.EffectiveEntryDate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// federalReserveHolidays returns the holidays in year the Federal Reserve Banks are closed.
+//
+// https://www.frbservices.org/about/holiday-schedules
+func federalReserveHolidays(year int) []Holiday {
+	out := []Holiday{
+		observedHoliday("New Year's Day", time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		{Name: "Birthday of Martin Luther King, Jr.", Date: nthWeekday(year, time.January, time.Monday, 3)},
+		{Name: "Washington's Birthday", Date: nthWeekday(year, time.February, time.Monday, 3)},
+		{Name: "Memorial Day", Date: lastWeekday(year, time.May, time.Monday)},
+	}
+	if year >= 2022 {
+		out = append(out, observedHoliday("Juneteenth National Independence Day", time.Date(year, time.June, 19, 0, 0, 0, 0, time.UTC)))
+	}
+	return append(out,
+		observedHoliday("Independence Day", time.Date(year, time.July, 4, 0, 0, 0, 0, time.UTC)),
+		Holiday{Name: "Labor Day", Date: nthWeekday(year, time.September, time.Monday, 1)},
+		Holiday{Name: "Columbus Day", Date: nthWeekday(year, time.October, time.Monday, 2)},
+		observedHoliday("Veterans Day", time.Date(year, time.November, 11, 0, 0, 0, 0, time.UTC)),
+		Holiday{Name: "Thanksgiving Day", Date: nthWeekday(year, time.November, time.Thursday, 4)},
+		observedHoliday("Christmas Day", time.Date(year, time.December, 25, 0, 0, 0, 0, time.UTC)),
+	)
+}
+
+// observedHoliday moves a holiday falling on Sunday to the following Monday. The Federal Reserve Banks
+// are open the Friday before a holiday falling on Saturday.
+func observedHoliday(name string, date time.Time) Holiday {
+	if date.Weekday() == time.Sunday {
+		date = date.AddDate(0, 0, 1)
+	}
+	return Holiday{Name: name, Date: date}
+}
+
+// nthWeekday returns the nth (starting at 1) weekday of month.
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+7*(n-1))
+}
+
+// lastWeekday returns the last weekday of month.
+func lastWeekday(year int, month time.Month, weekday time.Weekday) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+	offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+	return last.AddDate(0, 0, -offset)
+}
+
+func truncateDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func dateKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
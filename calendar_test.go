@@ -0,0 +1,138 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func calendarDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCalendar__Holidays(t *testing.T) {
+	cal := NewCalendar()
+
+	holidays := cal.Holidays(2023)
+	require.Len(t, holidays, 11)
+	require.Equal(t, calendarDate(2023, time.January, 2), holidays[0].Date) // New Year's Day observed on Monday
+	require.Equal(t, calendarDate(2023, time.January, 16), holidays[1].Date)
+	require.Equal(t, calendarDate(2023, time.February, 20), holidays[2].Date)
+	require.Equal(t, calendarDate(2023, time.May, 29), holidays[3].Date)
+	require.Equal(t, calendarDate(2023, time.June, 19), holidays[4].Date)
+	require.Equal(t, calendarDate(2023, time.July, 4), holidays[5].Date)
+	require.Equal(t, calendarDate(2023, time.September, 4), holidays[6].Date)
+	require.Equal(t, calendarDate(2023, time.October, 9), holidays[7].Date)
+	require.Equal(t, calendarDate(2023, time.November, 11), holidays[8].Date) // Saturday, not observed
+	require.Equal(t, calendarDate(2023, time.November, 23), holidays[9].Date)
+	require.Equal(t, calendarDate(2023, time.December, 25), holidays[10].Date)
+
+	// Juneteenth is observed from 2022
+	require.Len(t, cal.Holidays(2021), 10)
+
+	holiday, ok := cal.Holiday(calendarDate(2023, time.November, 23))
+	require.True(t, ok)
+	require.Equal(t, "Thanksgiving Day", holiday.Name)
+
+	cal.AddHoliday("Closure", calendarDate(2023, time.March, 1))
+	require.True(t, cal.IsHoliday(calendarDate(2023, time.March, 1)))
+	require.Len(t, cal.Holidays(2023), 12)
+}
+
+func TestCalendar__BankingDays(t *testing.T) {
+	cal := NewCalendar()
+
+	require.True(t, cal.IsBankingDay(calendarDate(2023, time.July, 3)))
+	require.False(t, cal.IsBankingDay(calendarDate(2023, time.July, 4)))
+	require.False(t, cal.IsBankingDay(calendarDate(2023, time.July, 8)))
+	require.False(t, cal.IsBankingDay(calendarDate(2023, time.January, 2)))
+	require.True(t, cal.IsBankingDay(calendarDate(2023, time.November, 10))) // Friday before a Saturday holiday
+
+	require.Equal(t, calendarDate(2023, time.July, 5), cal.NextBankingDay(calendarDate(2023, time.July, 3)))
+	require.Equal(t, calendarDate(2023, time.July, 3), cal.PreviousBankingDay(calendarDate(2023, time.July, 5)))
+	require.Equal(t, calendarDate(2023, time.September, 5), cal.NextBankingDay(calendarDate(2023, time.September, 1)))
+
+	// Friday before Thanksgiving plus five banking days skips the weekend and the holiday
+	require.Equal(t, calendarDate(2023, time.November, 27), cal.AddBankingDays(calendarDate(2023, time.November, 17), 5))
+	require.Equal(t, calendarDate(2023, time.November, 17), cal.AddBankingDays(calendarDate(2023, time.November, 27), -5))
+	require.Equal(t, calendarDate(2023, time.November, 18), cal.AddBankingDays(calendarDate(2023, time.November, 18), 0))
+
+	require.Equal(t, 5, cal.BankingDaysBetween(calendarDate(2023, time.November, 17), calendarDate(2023, time.November, 27)))
+	require.Equal(t, -5, cal.BankingDaysBetween(calendarDate(2023, time.November, 27), calendarDate(2023, time.November, 17)))
+
+	require.Equal(t, calendarDate(2023, time.July, 5), cal.SettlementDate(calendarDate(2023, time.July, 4)))
+	require.Equal(t, calendarDate(2023, time.July, 3), cal.SettlementDate(calendarDate(2023, time.July, 3)))
+
+	// clock time and location are kept
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	next := cal.NextBankingDay(time.Date(2023, time.July, 3, 14, 30, 0, 0, loc))
+	require.Equal(t, time.Date(2023, time.July, 5, 14, 30, 0, 0, loc), next)
+}
+
+func TestCalendar__SetEffectiveEntryDate(t *testing.T) {
+	cal := NewCalendar()
+
+	bh := mockBatchPPDHeader()
+	effective := cal.SetEffectiveEntryDate(bh, calendarDate(2023, time.December, 23))
+	require.Equal(t, calendarDate(2023, time.December, 26), effective)
+	require.Equal(t, "231226", bh.EffectiveEntryDate)
+
+	iatBh := mockIATBatchHeaderFF()
+	cal.SetIATEffectiveEntryDate(iatBh, calendarDate(2023, time.December, 22))
+	require.Equal(t, "231222", iatBh.EffectiveEntryDate)
+}
+
+func TestCalendar__CheckFileDates(t *testing.T) {
+	cal := NewCalendar()
+
+	file := NewFile()
+	file.Header.FileCreationDate = "231117"
+	batch := NewBatchPPD(mockBatchPPDHeader())
+	batch.GetHeader().EffectiveEntryDate = "231120"
+	file.AddBatch(batch)
+
+	now := calendarDate(2023, time.November, 20)
+	require.NoError(t, cal.CheckFileDates(file, now, FileDateLimits{StaleBankingDays: 1, MaxFutureBankingDays: 2}))
+
+	// stale file
+	err := cal.CheckFileDates(file, calendarDate(2023, time.November, 22), FileDateLimits{StaleBankingDays: 2, AllowPastEffectiveEntryDates: true})
+	require.ErrorIs(t, err, ErrFileCreationDateStale)
+
+	// past effective entry date
+	err = cal.CheckFileDates(file, calendarDate(2023, time.November, 21), FileDateLimits{})
+	require.ErrorIs(t, err, ErrBatchEffectiveEntryDatePast)
+	var be *BatchError
+	require.True(t, errors.As(err, &be))
+	require.Equal(t, "EffectiveEntryDate", be.FieldName)
+	require.NoError(t, cal.CheckFileDates(file, calendarDate(2023, time.November, 21), FileDateLimits{AllowPastEffectiveEntryDates: true}))
+
+	// effective entry date too far ahead, Thanksgiving is skipped
+	batch.GetHeader().EffectiveEntryDate = "231127"
+	require.NoError(t, cal.CheckFileDates(file, now, FileDateLimits{MaxFutureBankingDays: 4}))
+	err = cal.CheckFileDates(file, now, FileDateLimits{MaxFutureBankingDays: 3})
+	require.ErrorIs(t, err, ErrBatchEffectiveEntryDateFuture)
+
+	batch.GetHeader().EffectiveEntryDate = "23112"
+	err = cal.CheckFileDates(file, now, FileDateLimits{})
+	require.ErrorIs(t, err, ErrValidDay)
+}
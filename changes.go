@@ -0,0 +1,217 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/moov-io/base"
+)
+
+// ChangeEntry identifies a forward entry which an RDFI is correcting with a Notification of Change.
+type ChangeEntry struct {
+	// TraceNumber is the trace number of the forward EntryDetail being corrected.
+	TraceNumber string `json:"traceNumber"`
+
+	// ChangeCode is the Nacha change code (e.g. C01) describing the correction.
+	ChangeCode string `json:"changeCode"`
+
+	// CorrectedData holds the corrected values. The fields required depend on the ChangeCode.
+	CorrectedData *CorrectedData `json:"correctedData"`
+}
+
+// CreateNotificationOfChange builds a Notification of Change (COR) file from the forward entries of f
+// which match the trace numbers in changes. The forward File is not modified.
+//
+// Each corrected entry is placed in a COR batch which copies the original Company/Batch Header with
+// the RDFI as the ODFIIdentification. Entries have a zero amount, the return/NOC transaction code
+// matching their original transaction code and an Addenda98 record with the corrected data.
+// The ImmediateOrigin and ImmediateDestination of the FileHeader are swapped.
+//
+// IAT entries are not supported.
+func (f *File) CreateNotificationOfChange(changes []ChangeEntry) (*File, error) {
+	if len(changes) == 0 {
+		return nil, ErrFileNoChangeEntries
+	}
+	requested := make(map[string]ChangeEntry, len(changes))
+	for i := range changes {
+		change := changes[i]
+		if change.TraceNumber == "" {
+			return nil, fieldError("TraceNumber", ErrFieldRequired, change.TraceNumber)
+		}
+		if err := ValidateCorrectedData(change.ChangeCode, change.CorrectedData); err != nil {
+			return nil, err
+		}
+		if _, exists := requested[change.TraceNumber]; exists {
+			return nil, fmt.Errorf("trace number %s: %w", change.TraceNumber, ErrFileChangeEntryDuplicate)
+		}
+		requested[change.TraceNumber] = change
+	}
+
+	out := NewFile()
+	out.ID = base.ID()
+	out.Header = f.returnFileHeader(time.Now())
+	out.SetValidation(f.validateOpts)
+
+	found := make(map[string]bool)
+
+	err := f.createReturnBatches(out, COR, func(bh *BatchHeader, entry *EntryDetail) (*EntryDetail, error) {
+		change, exists := requested[entry.TraceNumber]
+		if !exists {
+			return nil, nil
+		}
+		if entry.Category != CategoryForward {
+			return nil, fmt.Errorf("trace number %s: %w", entry.TraceNumber, ErrFileChangeEntryCategory)
+		}
+		found[entry.TraceNumber] = true
+		return createChangeEntryDetail(bh, entry, change), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range changes {
+		if !found[changes[i].TraceNumber] {
+			return nil, fmt.Errorf("trace number %s: %w", changes[i].TraceNumber, ErrFileChangeEntryNotFound)
+		}
+	}
+
+	if err := out.Create(); err != nil {
+		return nil, err
+	}
+	if err := out.Validate(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func createChangeEntryDetail(bh *BatchHeader, entry *EntryDetail, change ChangeEntry) *EntryDetail {
+	odfi := bh.ODFIIdentificationField()
+
+	ed := NewEntryDetail()
+	ed.ID = base.ID()
+	ed.TransactionCode = returnTransactionCode(entry.TransactionCode)
+	ed.RDFIIdentification = odfi
+	ed.CheckDigit = strconv.Itoa(CalculateCheckDigit(odfi))
+	ed.DFIAccountNumber = entry.DFIAccountNumber
+	ed.Amount = 0
+	ed.IdentificationNumber = entry.IdentificationNumber
+	ed.IndividualName = entry.IndividualName
+	ed.DiscretionaryData = entry.DiscretionaryData
+	ed.AddendaRecordIndicator = 1
+	ed.Category = CategoryNOC
+
+	addenda98 := NewAddenda98()
+	addenda98.ID = base.ID()
+	addenda98.ChangeCode = strings.ToUpper(change.ChangeCode)
+	addenda98.OriginalTrace = entry.TraceNumber
+	addenda98.OriginalDFI = entry.RDFIIdentificationField()
+	addenda98.CorrectedData = WriteCorrectionData(addenda98.ChangeCode, change.CorrectedData)
+	if addenda98.ChangeCode == "C08" && change.CorrectedData != nil {
+		// foreign Receiving DFI Identifications continue into the IAT corrected data
+		if id := []rune(change.CorrectedData.RoutingNumber); len(id) > correctedDataCharLength {
+			addenda98.CorrectedData = string(id[:correctedDataCharLength])
+			addenda98.iatCorrectedData = string(id[correctedDataCharLength:])
+		}
+	}
+	ed.Addenda98 = addenda98
+
+	return ed
+}
+
+// ValidateCorrectedData checks that data contains valid corrections for the given change code.
+// Refused change codes (C61-C69) are not accepted as they are only sent by an ODFI.
+func ValidateCorrectedData(code string, data *CorrectedData) error {
+	code = strings.ToUpper(code)
+	if LookupChangeCode(code) == nil || IsRefusedChangeCode(code) {
+		return fieldError("ChangeCode", ErrAddenda98ChangeCode, code)
+	}
+	if code == "C13" {
+		// Addenda format errors carry no corrected data
+		return nil
+	}
+	if data == nil {
+		return fieldError("CorrectedData", ErrAddenda98CorrectedData, code)
+	}
+
+	checkAccount := func() error {
+		if n := utf8.RuneCountInString(data.AccountNumber); n == 0 || n > 17 {
+			return fieldError("CorrectedData", ErrAddenda98CorrectedData, data.AccountNumber)
+		}
+		return nil
+	}
+	checkRouting := func() error {
+		if err := CheckRoutingNumber(data.RoutingNumber); err != nil {
+			return fieldError("CorrectedData", ErrAddenda98CorrectedData, data.RoutingNumber)
+		}
+		return nil
+	}
+	checkTransactionCode := func() error {
+		if err := StandardTransactionCode(data.TransactionCode); err != nil {
+			return fieldError("CorrectedData", ErrAddenda98CorrectedData, data.TransactionCode)
+		}
+		return nil
+	}
+	checkLength := func(value string, max int) error {
+		if n := utf8.RuneCountInString(value); n == 0 || n > max {
+			return fieldError("CorrectedData", ErrAddenda98CorrectedData, value)
+		}
+		return nil
+	}
+
+	var checks []func() error
+	switch code {
+	case "C01":
+		checks = append(checks, checkAccount)
+	case "C02":
+		checks = append(checks, checkRouting)
+	case "C03":
+		checks = append(checks, checkRouting, checkAccount)
+	case "C04":
+		checks = append(checks, func() error { return checkLength(data.Name, 22) })
+	case "C05":
+		checks = append(checks, checkTransactionCode)
+	case "C06":
+		checks = append(checks, checkAccount, checkTransactionCode)
+	case "C07":
+		checks = append(checks, checkRouting, checkAccount, checkTransactionCode)
+	case "C08":
+		checks = append(checks, func() error { return checkLength(data.RoutingNumber, iatCorrectedDataCharLength) })
+	case "C09":
+		checks = append(checks, func() error { return checkLength(data.Identification, 22) })
+	case "C10":
+		checks = append(checks, func() error { return checkLength(data.CompanyName, 16) })
+	case "C11":
+		checks = append(checks, func() error { return checkLength(data.CompanyIdentification, 10) })
+	case "C12":
+		checks = append(checks,
+			func() error { return checkLength(data.CompanyName, 16) },
+			func() error { return checkLength(data.CompanyIdentification, 10) },
+		)
+	}
+	for i := range checks {
+		if err := checks[i](); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile__CreateNotificationOfChange(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	cor, err := file.CreateNotificationOfChange([]ChangeEntry{
+		{
+			TraceNumber:   "121042880000001",
+			ChangeCode:    "C01",
+			CorrectedData: &CorrectedData{AccountNumber: "1918171614"},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, file.Header.ImmediateOrigin, cor.Header.ImmediateDestination)
+	require.Len(t, cor.Batches, 1)
+	require.Len(t, cor.NotificationOfChange, 1)
+
+	b := cor.Batches[0]
+	require.IsType(t, &BatchCOR{}, b)
+	require.Equal(t, COR, b.GetHeader().StandardEntryClassCode)
+	require.Equal(t, "Name on Account", b.GetHeader().CompanyName)
+	require.Equal(t, "23138010", b.GetHeader().ODFIIdentification)
+	require.Equal(t, CategoryNOC, b.Category())
+
+	ed := b.GetEntries()[0]
+	require.Equal(t, CheckingReturnNOCDebit, ed.TransactionCode)
+	require.Equal(t, 0, ed.Amount)
+	require.Equal(t, "12104288", ed.RDFIIdentification)
+	require.NotNil(t, ed.Addenda98)
+	require.Equal(t, "C01", ed.Addenda98.ChangeCode)
+	require.Equal(t, "121042880000001", ed.Addenda98.OriginalTrace)
+	require.Equal(t, "23138010", ed.Addenda98.OriginalDFI)
+	require.Equal(t, ed.TraceNumber, ed.Addenda98.TraceNumber)
+
+	corrected := ed.Addenda98.ParseCorrectedData()
+	require.NotNil(t, corrected)
+	require.Equal(t, "1918171614", corrected.AccountNumber)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(cor))
+	parsed, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+	require.Len(t, parsed.NotificationOfChange, 1)
+}
+
+func TestFile__CreateNotificationOfChangeErrors(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	_, err = file.CreateNotificationOfChange(nil)
+	require.ErrorIs(t, err, ErrFileNoChangeEntries)
+
+	data := &CorrectedData{AccountNumber: "1918171614"}
+	_, err = file.CreateNotificationOfChange([]ChangeEntry{{TraceNumber: "999999999999999", ChangeCode: "C01", CorrectedData: data}})
+	require.ErrorIs(t, err, ErrFileChangeEntryNotFound)
+
+	_, err = file.CreateNotificationOfChange([]ChangeEntry{
+		{TraceNumber: "121042880000001", ChangeCode: "C01", CorrectedData: data},
+		{TraceNumber: "121042880000001", ChangeCode: "C01", CorrectedData: data},
+	})
+	require.ErrorIs(t, err, ErrFileChangeEntryDuplicate)
+
+	_, err = file.CreateNotificationOfChange([]ChangeEntry{{TraceNumber: "121042880000001", ChangeCode: "C02", CorrectedData: data}})
+	require.ErrorIs(t, err, ErrAddenda98CorrectedData)
+}
+
+func TestValidateCorrectedData(t *testing.T) {
+	cases := []struct {
+		code  string
+		data  *CorrectedData
+		valid bool
+	}{
+		{"C01", &CorrectedData{AccountNumber: "12345"}, true},
+		{"C01", &CorrectedData{AccountNumber: "123456789012345678"}, false},
+		{"C02", &CorrectedData{RoutingNumber: "987654320"}, true},
+		{"C02", &CorrectedData{RoutingNumber: "987654321"}, false},
+		{"C03", &CorrectedData{RoutingNumber: "987654320", AccountNumber: "123"}, true},
+		{"C03", &CorrectedData{RoutingNumber: "987654320"}, false},
+		{"C04", &CorrectedData{Name: "Jane Doe"}, true},
+		{"C05", &CorrectedData{TransactionCode: SavingsCredit}, true},
+		{"C05", &CorrectedData{TransactionCode: 99}, false},
+		{"C06", &CorrectedData{AccountNumber: "5421", TransactionCode: CheckingDebit}, true},
+		{"C07", &CorrectedData{RoutingNumber: "987654320", AccountNumber: "5421", TransactionCode: SavingsCredit}, true},
+		{"C07", &CorrectedData{RoutingNumber: "987654320", AccountNumber: "5421"}, false},
+		{"C08", &CorrectedData{RoutingNumber: "FR76300060000112345678"}, true},
+		{"C08", &CorrectedData{RoutingNumber: strings.Repeat("1", 35)}, true},
+		{"C08", &CorrectedData{RoutingNumber: strings.Repeat("1", 36)}, false},
+		{"C09", &CorrectedData{Identification: "FooBar"}, true},
+		{"C10", &CorrectedData{CompanyName: "Acme Corp"}, true},
+		{"C11", &CorrectedData{CompanyIdentification: "12345678901"}, false},
+		{"C12", &CorrectedData{CompanyName: "Acme Corp", CompanyIdentification: "1234567890"}, true},
+		{"C13", nil, true},
+		{"C61", &CorrectedData{}, false},
+		{"C99", &CorrectedData{}, false},
+		{"C01", nil, false},
+	}
+	for _, tc := range cases {
+		err := ValidateCorrectedData(tc.code, tc.data)
+		if tc.valid {
+			require.NoError(t, err, "%s %#v", tc.code, tc.data)
+		} else {
+			require.Error(t, err, "%s %#v", tc.code, tc.data)
+		}
+	}
+}
@@ -59,6 +59,7 @@ func dumpFiles(paths []string, validateOpts *ach.ValidateOpts) error {
 				MaskCorrectedData:  *flagMask || *flagMaskCorrectedData,
 				MaskNames:          *flagMask || *flagMaskNames,
 				PrettyAmounts:      *flagPretty || *flagPrettyAmounts,
+				SourceLines:        *flagLines,
 			})
 		} else {
 			fmt.Printf("nil ACH file in position %d\n", i)
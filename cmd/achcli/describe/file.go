@@ -25,6 +25,8 @@ type Opts struct {
 	MaskCorrectedData  bool
 
 	PrettyAmounts bool
+
+	SourceLines bool
 }
 
 func File(ww io.Writer, file *ach.File, opts *Opts) {
@@ -41,16 +43,17 @@ func File(ww io.Writer, file *ach.File, opts *Opts) {
 	fh, fc := file.Header, file.Control
 
 	// FileHeader
-	fmt.Fprintln(w, "  Origin\tOriginName\tDestination\tDestinationName\tFileCreationDate\tFileCreationTime")
-	fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\n", fh.ImmediateOriginField(), fh.ImmediateOriginNameField(), fh.ImmediateDestinationField(), fh.ImmediateDestinationNameField(), fh.FileCreationDateField(), fh.FileCreationTimeField())
+	fmt.Fprintf(w, "  %sOrigin\tOriginName\tDestination\tDestinationName\tFileCreationDate\tFileCreationTime\n", lineHeader(opts))
+	fmt.Fprintf(w, "  %s%s\t%s\t%s\t%s\t%s\t%s\n", lineColumn(opts, fh.SourceLine()), fh.ImmediateOriginField(), fh.ImmediateOriginNameField(), fh.ImmediateDestinationField(), fh.ImmediateDestinationNameField(), fh.FileCreationDateField(), fh.FileCreationTimeField())
 
 	// Batches
 	for i := range file.Batches {
-		fmt.Fprintln(w, "\n  BatchNumber\tSECCode\tServiceClassCode\tCompanyName\tDiscretionaryData\tIdentification\tEntryDescription\tEffectiveEntryDate\tDescriptiveDate")
+		fmt.Fprintf(w, "\n  %sBatchNumber\tSECCode\tServiceClassCode\tCompanyName\tDiscretionaryData\tIdentification\tEntryDescription\tEffectiveEntryDate\tDescriptiveDate\n", lineHeader(opts))
 
 		bh := file.Batches[i].GetHeader()
 		if bh != nil {
-			fmt.Fprintf(w, "  %s\t%s\t%d %s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			fmt.Fprintf(w, "  %s%s\t%s\t%d %s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				lineColumn(opts, bh.SourceLine()),
 				bh.BatchNumberField(),
 				bh.StandardEntryClassCode,
 				bh.ServiceClassCode,
@@ -66,7 +69,7 @@ func File(ww io.Writer, file *ach.File, opts *Opts) {
 
 		entries := file.Batches[i].GetEntries()
 		for j := range entries {
-			fmt.Fprintln(w, "\n    TransactionCode\tRDFIIdentification\tAccountNumber\tAmount\tName\tTraceNumber\tCategory")
+			fmt.Fprintf(w, "\n    %sTransactionCode\tRDFIIdentification\tAccountNumber\tAmount\tName\tTraceNumber\tCategory\n", lineHeader(opts))
 
 			e := entries[j]
 			accountNumber := e.DFIAccountNumberField()
@@ -81,7 +84,7 @@ func File(ww io.Writer, file *ach.File, opts *Opts) {
 				name = maskName(name)
 			}
 
-			fmt.Fprintf(w, "    %d %s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.TransactionCode, transactionCodes[e.TransactionCode], e.RDFIIdentificationField(), accountNumber, amount, name, e.TraceNumberField(), e.Category)
+			fmt.Fprintf(w, "    %s%d %s\t%s\t%s\t%s\t%s\t%s\t%s\n", lineColumn(opts, e.SourceLine()), e.TransactionCode, transactionCodes[e.TransactionCode], e.RDFIIdentificationField(), accountNumber, amount, name, e.TraceNumberField(), e.Category)
 
 			dumpAddenda02(w, e.Addenda02)
 			for i := range e.Addenda05 {
@@ -98,12 +101,12 @@ func File(ww io.Writer, file *ach.File, opts *Opts) {
 
 		bc := file.Batches[i].GetControl()
 		if bc != nil {
-			fmt.Fprintln(w, "\n  ServiceClassCode\tEntryAddendaCount\tEntryHash\tTotalDebits\tTotalCredits\tMACCode\tODFIIdentification\tBatchNumber")
+			fmt.Fprintf(w, "\n  %sServiceClassCode\tEntryAddendaCount\tEntryHash\tTotalDebits\tTotalCredits\tMACCode\tODFIIdentification\tBatchNumber\n", lineHeader(opts))
 
 			debitTotal := formatAmount(opts.PrettyAmounts, bc.TotalDebitEntryDollarAmount)
 			creditTotal := formatAmount(opts.PrettyAmounts, bc.TotalCreditEntryDollarAmount)
-			fmt.Fprintf(w, "  %d %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-				bc.ServiceClassCode, serviceClassCodes[bh.ServiceClassCode], bc.EntryAddendaCountField(), bc.EntryHashField(), debitTotal, creditTotal, bc.MessageAuthenticationCodeField(), bc.ODFIIdentificationField(), bc.BatchNumberField())
+			fmt.Fprintf(w, "  %s%d %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				lineColumn(opts, bc.SourceLine()), bc.ServiceClassCode, serviceClassCodes[bh.ServiceClassCode], bc.EntryAddendaCountField(), bc.EntryHashField(), debitTotal, creditTotal, bc.MessageAuthenticationCodeField(), bc.ODFIIdentificationField(), bc.BatchNumberField())
 		}
 	}
 
@@ -112,8 +115,9 @@ func File(ww io.Writer, file *ach.File, opts *Opts) {
 		iatBatch := file.IATBatches[i]
 		bh := iatBatch.GetHeader()
 		if bh != nil {
-			fmt.Fprintln(w, "\n  BatchNumber\tSECCode\tServiceClassCode\tIATIndicator\tDestinationCountryCode\tFE Indicator\tFE ReferenceIndicator\tFE Reference\tCompanyEntryDescription")
-			fmt.Fprintf(w, "  %s\t%s\t%d %s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			fmt.Fprintf(w, "\n  %sBatchNumber\tSECCode\tServiceClassCode\tIATIndicator\tDestinationCountryCode\tFE Indicator\tFE ReferenceIndicator\tFE Reference\tCompanyEntryDescription\n", lineHeader(opts))
+			fmt.Fprintf(w, "  %s%s\t%s\t%d %s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				lineColumn(opts, bh.SourceLine()),
 				bh.BatchNumberField(),
 				bh.StandardEntryClassCode,
 				bh.ServiceClassCode,
@@ -139,7 +143,7 @@ func File(ww io.Writer, file *ach.File, opts *Opts) {
 
 		entries := iatBatch.GetEntries()
 		for j := range entries {
-			fmt.Fprintln(w, "\n    TransactionCode\tRDFIIdentification\tAccountNumber\tAmount\tAddendaRecords\tTraceNumber\tCategory")
+			fmt.Fprintf(w, "\n    %sTransactionCode\tRDFIIdentification\tAccountNumber\tAmount\tAddendaRecords\tTraceNumber\tCategory\n", lineHeader(opts))
 
 			e := entries[j]
 			accountNumber := e.DFIAccountNumberField()
@@ -148,7 +152,7 @@ func File(ww io.Writer, file *ach.File, opts *Opts) {
 			}
 
 			amount := formatAmount(opts.PrettyAmounts, e.Amount)
-			fmt.Fprintf(w, "    %d %s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.TransactionCode, transactionCodes[e.TransactionCode], e.RDFIIdentificationField(), accountNumber, amount, e.AddendaRecordsField(), e.TraceNumberField(), e.Category)
+			fmt.Fprintf(w, "    %s%d %s\t%s\t%s\t%s\t%s\t%s\t%s\n", lineColumn(opts, e.SourceLine()), e.TransactionCode, transactionCodes[e.TransactionCode], e.RDFIIdentificationField(), accountNumber, amount, e.AddendaRecordsField(), e.TraceNumberField(), e.Category)
 
 			dumpAddenda10(w, e.Addenda10)
 			dumpAddenda11(w, e.Addenda11)
@@ -171,21 +175,37 @@ func File(ww io.Writer, file *ach.File, opts *Opts) {
 
 		bc := iatBatch.GetControl()
 		if bc != nil {
-			fmt.Fprintln(w, "\n  ServiceClassCode\tEntryAddendaCount\tEntryHash\tTotalDebits\tTotalCredits\tMACCode\tODFIIdentification\tBatchNumber")
+			fmt.Fprintf(w, "\n  %sServiceClassCode\tEntryAddendaCount\tEntryHash\tTotalDebits\tTotalCredits\tMACCode\tODFIIdentification\tBatchNumber\n", lineHeader(opts))
 
 			debitTotal := formatAmount(opts.PrettyAmounts, bc.TotalDebitEntryDollarAmount)
 			creditTotal := formatAmount(opts.PrettyAmounts, bc.TotalCreditEntryDollarAmount)
-			fmt.Fprintf(w, "  %d %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-				bc.ServiceClassCode, serviceClassCodes[bh.ServiceClassCode], bc.EntryAddendaCountField(), bc.EntryHashField(), debitTotal, creditTotal, bc.MessageAuthenticationCodeField(), bc.ODFIIdentificationField(), bc.BatchNumberField())
+			fmt.Fprintf(w, "  %s%d %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				lineColumn(opts, bc.SourceLine()), bc.ServiceClassCode, serviceClassCodes[bh.ServiceClassCode], bc.EntryAddendaCountField(), bc.EntryHashField(), debitTotal, creditTotal, bc.MessageAuthenticationCodeField(), bc.ODFIIdentificationField(), bc.BatchNumberField())
 		}
 	}
 
 	// FileControl
-	fmt.Fprintln(w, "\n  BatchCount\tBlockCount\tEntryAddendaCount\tTotalDebitAmount\tTotalCreditAmount")
+	fmt.Fprintf(w, "\n  %sBatchCount\tBlockCount\tEntryAddendaCount\tTotalDebitAmount\tTotalCreditAmount\n", lineHeader(opts))
 
 	debitTotal := formatAmount(opts.PrettyAmounts, fc.TotalDebitEntryDollarAmountInFile)
 	creditTotal := formatAmount(opts.PrettyAmounts, fc.TotalCreditEntryDollarAmountInFile)
-	fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", fc.BatchCountField(), fc.BlockCountField(), fc.EntryAddendaCountField(), debitTotal, creditTotal)
+	fmt.Fprintf(w, "  %s%s\t%s\t%s\t%s\t%s\n", lineColumn(opts, fc.SourceLine()), fc.BatchCountField(), fc.BlockCountField(), fc.EntryAddendaCountField(), debitTotal, creditTotal)
+}
+
+// lineHeader returns the heading of the Line column when opts.SourceLines is set
+func lineHeader(opts *Opts) string {
+	if !opts.SourceLines {
+		return ""
+	}
+	return "Line\t"
+}
+
+// lineColumn returns the line a record was read from as the first column of its row when opts.SourceLines is set
+func lineColumn(opts *Opts, line int) string {
+	if !opts.SourceLines {
+		return ""
+	}
+	return fmt.Sprintf("%d\t", line)
 }
 
 // formatAmount can optionally convert an integer into a human readable amount
@@ -109,3 +109,17 @@ func TestMaskName(t *testing.T) {
 	}
 	require.Equal(t, "Ja** Sm*** **", maskName(ed.IndividualNameField()))
 }
+
+func TestDescribeSourceLines(t *testing.T) {
+	file, err := ach.ReadFile(filepath.Join("..", "..", "..", "test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	File(&buf, file, &Opts{SourceLines: true})
+	if testing.Verbose() {
+		os.Stdout.Write(buf.Bytes())
+	}
+	require.Contains(t, buf.String(), "\n    Line  TransactionCode")
+	require.Contains(t, buf.String(), "\n    3     27 (Checking Debit)")
+	require.Contains(t, buf.String(), "\n  5     000001")
+}
@@ -21,9 +21,12 @@ USAGE
 
 EXAMPLES
   achcli -diff first.ach second.ach    Show the difference between two ACH files
+  achcli -lines file.ach               Print file details with the line each record was read from
   achcli -mask file.ach                Print file details with personally identifiable information partially removed
   achcli -reformat=json first.ach      Convert an incoming ACH file into another format (options: ach, json)
+  achcli -stream large.ach             Validate a large ACH file without reading it into memory
   achcli -validate opts.json file.ach  Read an ACH File with the provided ValidateOpts
+  achcli -profiles profiles.yaml -validate fedach file.ach  Read an ACH File with a validation profile
   achcli -version                      Print the version of achcli (Example: %s)
   achcli 20060102.ach                  Summarize an ACH file for human readability
 
@@ -6,9 +6,11 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/moov-io/ach"
@@ -31,8 +33,12 @@ var (
 	flagPretty        = flag.Bool("pretty", false, "Display all values in their human readable format")
 	flagPrettyAmounts = flag.Bool("pretty.amounts", false, "Display human readable amounts instead of exact values")
 
+	flagLines = flag.Bool("lines", false, "Display the line each record was read from")
+
 	flagSkipValidation = flag.Bool("skip-validation", false, "Skip all validation checks")
-	flagValidateOpts   = flag.String("validate", "", "Path to config file in json format to enable validation opts")
+	flagValidateOpts   = flag.String("validate", "", "Path to config file in json format to enable validation opts, or the name of a validation profile")
+	flagProfiles       = flag.String("profiles", "", "Path to YAML or JSON validation profiles which -validate can reference by name")
+	flagStream         = flag.Bool("stream", false, "Validate files one record at a time without printing them")
 )
 
 func main() {
@@ -78,6 +84,12 @@ func main() {
 			os.Exit(1)
 		}
 
+	case *flagStream:
+		if err := streamFiles(args, validateOpts); err != nil {
+			fmt.Printf("ERROR: %v\n", err)
+			os.Exit(1)
+		}
+
 	case *flagReformat != "" && len(args) == 1:
 		if err := reformat(*flagReformat, args[0], validateOpts); err != nil {
 			fmt.Printf("ERROR: %v\n", err)
@@ -100,7 +112,24 @@ func readValidationOpts(path string) *ach.ValidateOpts {
 		return &opts
 	}
 
+	if *flagProfiles != "" {
+		if err := ach.LoadValidationProfiles(*flagProfiles); err != nil {
+			fmt.Printf("ERROR: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	if path != "" {
+		// names which aren't a file, or written like one, are validation profiles
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) && !looksLikePath(path) {
+			profile, err := ach.ValidationProfileOpts(path)
+			if err != nil {
+				fmt.Printf("ERROR: reading validate opts failed: %v\n", err)
+				os.Exit(1)
+			}
+			return profile
+		}
+
 		// read config file
 		bs, readErr := os.ReadFile(path)
 		if readErr != nil {
@@ -116,3 +145,16 @@ func readValidationOpts(path string) *ach.ValidateOpts {
 	}
 	return nil
 }
+
+// looksLikePath returns true if name is written as a file path rather than the name of a validation profile,
+// so a mistyped path is reported as a missing file.
+func looksLikePath(name string) bool {
+	if strings.ContainsRune(name, '/') || strings.ContainsRune(name, filepath.Separator) {
+		return true
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+// Copyright 2019 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/moov-io/ach"
+)
+
+// streamFiles validates each Nacha formatted file as it's read, so files too large to keep in memory can be checked.
+func streamFiles(paths []string, validateOpts *ach.ValidateOpts) error {
+	for _, path := range paths {
+		fd, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		err = ach.ValidateStream(fd, validateOpts)
+		fd.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		fmt.Printf("%s is valid\n", path)
+	}
+	return nil
+}
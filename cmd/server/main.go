@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -73,6 +74,13 @@ func main() {
 			logger.Logf("Using %v as ach.File TTL", achFileTTL)
 		}
 	}
+	if v := os.Getenv("ACH_VALIDATION_PROFILES"); v != "" {
+		if err := ach.LoadValidationProfiles(v); err != nil {
+			logger.Fatal().LogErrorf("problem loading validation profiles: %v", err)
+			os.Exit(1)
+		}
+		logger.Logf("Loaded validation profiles: %s", strings.Join(ach.ValidationProfiles(), ", "))
+	}
 	r := server.NewRepositoryInMemory(achFileTTL, logger)
 	svc = server.NewService(r)
 
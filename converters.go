@@ -28,7 +28,21 @@ import (
 type converters struct{}
 
 func (c *converters) parseNumField(r string) (s int) {
-	s, _ = strconv.Atoi(strings.TrimSpace(r))
+	r = strings.TrimSpace(r)
+
+	// Most fields are only digits, parse them without strconv's error allocation for blank fields
+	if len(r) <= 18 {
+		for i := 0; i < len(r); i++ {
+			d := r[i] - '0'
+			if d > 9 {
+				s, _ = strconv.Atoi(r)
+				return s
+			}
+			s = s*10 + int(d)
+		}
+		return s
+	}
+	s, _ = strconv.Atoi(r)
 	return s
 }
 
@@ -45,6 +59,37 @@ func (c *converters) parseStringFieldWithOpts(r string, opts *ValidateOpts) stri
 	}
 }
 
+// recordFields slices the fields of a 94 character record by their character positions without copying.
+// ACH records are ASCII and sliced directly, other records are sliced at the byte offset of each character.
+type recordFields struct {
+	record  string
+	offsets []int
+}
+
+// newRecordFields returns the fields of record and true when record is 94 characters long.
+func newRecordFields(record string) (recordFields, bool) {
+	if utf8.RuneCountInString(record) != RecordLength {
+		return recordFields{}, false
+	}
+	fields := recordFields{record: record}
+	if len(record) != RecordLength {
+		fields.offsets = make([]int, 0, RecordLength+1)
+		for i := range record {
+			fields.offsets = append(fields.offsets, i)
+		}
+		fields.offsets = append(fields.offsets, len(record))
+	}
+	return fields, true
+}
+
+// field returns the characters from start up to end, counting from 0 like a slice expression.
+func (f recordFields) field(start, end int) string {
+	if f.offsets == nil {
+		return f.record[start:end]
+	}
+	return f.record[f.offsets[start]:f.offsets[end]]
+}
+
 // formatSimpleDate takes a YYMMDD date and formats it for the fixed-width ACH file format
 func (c *converters) formatSimpleDate(s string) string {
 	if s == "" {
@@ -126,6 +171,45 @@ func (c *converters) stringField(s string, max uint) string {
 	return strings.Repeat("0", m) + s
 }
 
+// appendAlphaField appends s to dst left-justified and space filled like alphaField.
+func (c *converters) appendAlphaField(dst []byte, s string, max uint) []byte {
+	ln := uint(utf8.RuneCountInString(s))
+	if ln > max {
+		return append(dst, s[:max]...)
+	}
+	dst = append(dst, s...)
+	return appendPadding(dst, ' ', int(max-ln))
+}
+
+// appendNumericField appends n to dst right-justified and zero filled like numericField.
+func (c *converters) appendNumericField(dst []byte, n int, max uint) []byte {
+	var digits [20]byte
+	s := strconv.AppendInt(digits[:0], int64(n), 10)
+	if l := uint(len(s)); l > max {
+		return append(dst, s[l-max:]...)
+	}
+	dst = appendPadding(dst, '0', int(max)-len(s))
+	return append(dst, s...)
+}
+
+// appendStringField appends s to dst sliced to max length and zero filled like stringField.
+func (c *converters) appendStringField(dst []byte, s string, max uint) []byte {
+	ln := uint(utf8.RuneCountInString(s))
+	if ln > max {
+		return append(dst, s[:max]...)
+	}
+	dst = appendPadding(dst, '0', int(max-ln))
+	return append(dst, s...)
+}
+
+// appendPadding appends n copies of pad to dst.
+func appendPadding(dst []byte, pad byte, n int) []byte {
+	for i := 0; i < n; i++ {
+		dst = append(dst, pad)
+	}
+	return dst
+}
+
 // leastSignificantDigits returns the least significant digits of v limited by maxDigits.
 func (c *converters) leastSignificantDigits(v int, maxDigits uint) int {
 	return v % int(math.Pow10(int(maxDigits)))
@@ -18,6 +18,7 @@
 package ach
 
 import (
+	"strings"
 	"testing"
 	"unicode/utf8"
 
@@ -150,6 +151,30 @@ func BenchmarkParseNumField(b *testing.B) {
 	}
 }
 
+func TestParseNumField__NotDigits(t *testing.T) {
+	c := converters{}
+	require.Equal(t, 0, c.parseNumField(""))
+	require.Equal(t, 0, c.parseNumField("     "))
+	require.Equal(t, 0, c.parseNumField("12A4"))
+	require.Equal(t, 12, c.parseNumField("+12"))
+	require.Equal(t, -12, c.parseNumField("-12"))
+	require.Equal(t, 1234567890123456789, c.parseNumField("1234567890123456789"))
+}
+
+func TestRecordFields(t *testing.T) {
+	_, ok := newRecordFields("6")
+	require.False(t, ok)
+
+	record := "6" + strings.Repeat(" ", 53) + "Returned per ODFI’s Re" + strings.Repeat("0", 18)
+	require.Equal(t, RecordLength, utf8.RuneCountInString(record))
+
+	fields, ok := newRecordFields(record)
+	require.True(t, ok)
+	require.Equal(t, "6", fields.field(0, 1))
+	require.Equal(t, "Returned per ODFI’s Re", fields.field(54, 76))
+	require.Equal(t, strings.Repeat("0", 18), fields.field(76, 94))
+}
+
 // testParseStringField handles spaces in string conversion
 func testParseStringField(t testing.TB) {
 	c := converters{}
@@ -0,0 +1,446 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Receiver is an Originator's record of the account an entry was sent to. Notifications of Change
+// are applied to Receivers with ApplyCorrections.
+type Receiver struct {
+	// ID uniquely identifies the Receiver within a ReceiverStore
+	ID string `json:"id"`
+
+	// RoutingNumber is the 9 digit routing number of the Receiver's financial institution,
+	// or the foreign Receiving DFI Identification for IAT Receivers.
+	RoutingNumber   string `json:"routingNumber"`
+	AccountNumber   string `json:"accountNumber"`
+	TransactionCode int    `json:"transactionCode"`
+
+	// Name and Identification are the IndividualName and IdentificationNumber sent on entries
+	Name           string `json:"name"`
+	Identification string `json:"identification,omitempty"`
+
+	// CompanyName and CompanyIdentification are the Originator values sent on the BatchHeader
+	CompanyName           string `json:"companyName,omitempty"`
+	CompanyIdentification string `json:"companyIdentification,omitempty"`
+}
+
+// ReceiverStore is the storage used by ApplyCorrections to find and update Receivers.
+type ReceiverStore interface {
+	// FindReceiver returns the Receiver of the original entry trace number. Implementations
+	// may fall back to the DFI account number when the trace number is unknown. A nil Receiver
+	// and nil error are returned when no Receiver is found.
+	FindReceiver(originalTrace, accountNumber string) (*Receiver, error)
+
+	// UpdateReceiver saves the corrected Receiver
+	UpdateReceiver(receiver *Receiver) error
+}
+
+// CorrectionStatus describes the outcome of applying one Notification of Change.
+type CorrectionStatus string
+
+const (
+	// CorrectionApplied is given when the Receiver was updated
+	CorrectionApplied CorrectionStatus = "applied"
+	// CorrectionIgnored is given when there was nothing to update
+	CorrectionIgnored CorrectionStatus = "ignored"
+	// CorrectionConflict is given when the correction could not be applied safely
+	CorrectionConflict CorrectionStatus = "conflict"
+)
+
+var (
+	// ErrCorrectionReceiverNotFound is given when no Receiver matches the NOC
+	ErrCorrectionReceiverNotFound = errors.New("receiver not found")
+	// ErrCorrectionRefused is given for Refused Notification of Change entries
+	ErrCorrectionRefused = errors.New("refused notification of change")
+	// ErrCorrectionNoChange is given when the Receiver already contains the corrected data
+	ErrCorrectionNoChange = errors.New("receiver already contains the corrected data")
+	// ErrCorrectionAddendaFormat is given for C13 entries which require no Receiver update
+	ErrCorrectionAddendaFormat = errors.New("addenda format error requires no correction")
+	// ErrCorrectionUnparsable is given when the corrected data can't be read for the change code
+	ErrCorrectionUnparsable = errors.New("unable to parse corrected data")
+	// ErrCorrectionMismatch is given when the Receiver does not match the original entry
+	ErrCorrectionMismatch = errors.New("receiver does not match original entry")
+	// ErrCorrectionConflicting is given when an earlier NOC in the file corrected the same field differently
+	ErrCorrectionConflicting = errors.New("conflicts with an earlier correction")
+)
+
+// CorrectionResult is the outcome of applying one Notification of Change entry.
+type CorrectionResult struct {
+	OriginalTrace string           `json:"originalTrace"`
+	ChangeCode    string           `json:"changeCode"`
+	Status        CorrectionStatus `json:"status"`
+
+	// Err explains why a correction was ignored or conflicting and Reason is its message
+	Err    error  `json:"-"`
+	Reason string `json:"reason,omitempty"`
+
+	// Before and After are copies of the Receiver around the correction
+	Before *Receiver `json:"before,omitempty"`
+	After  *Receiver `json:"after,omitempty"`
+}
+
+// ApplyCorrections applies each Notification of Change in file to the Receivers of store and reports
+// the outcome of every NOC entry. Change codes C01 through C13 are supported.
+//
+// A correction is ignored when its Receiver is not found, when it is a Refused NOC, when it is a
+// C13 or when the Receiver already holds the corrected data. A correction conflicts when the corrected
+// data can't be parsed, when the Receiver's routing or account number doesn't match the original entry
+// or when an earlier NOC in file corrected the same field of the Receiver to a different value.
+//
+// An error is only returned when store fails.
+func ApplyCorrections(file *File, store ReceiverStore) ([]CorrectionResult, error) {
+	if file == nil || store == nil {
+		return nil, nil
+	}
+	applier := &correctionApplier{
+		store:     store,
+		originals: make(map[string]Receiver),
+		applied:   make(map[string]map[string]string),
+	}
+	var results []CorrectionResult
+	for _, batch := range file.Batches {
+		for _, entry := range batch.GetEntries() {
+			if entry.Addenda98 == nil && entry.Addenda98Refused == nil {
+				continue
+			}
+			result, err := applier.apply(correctionEntry{
+				addenda98:     entry.Addenda98,
+				refused:       entry.Addenda98Refused,
+				accountNumber: entry.DFIAccountNumber,
+				checkRouting:  true,
+			})
+			if err != nil {
+				return results, err
+			}
+			results = append(results, result)
+		}
+	}
+	for _, iatBatch := range file.IATBatches {
+		for _, entry := range iatBatch.Entries {
+			if entry.Addenda98 == nil {
+				continue
+			}
+			result, err := applier.apply(correctionEntry{
+				addenda98:     entry.Addenda98,
+				accountNumber: entry.DFIAccountNumber,
+			})
+			if err != nil {
+				return results, err
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+type correctionEntry struct {
+	addenda98 *Addenda98
+	refused   *Addenda98Refused
+
+	accountNumber string
+	checkRouting  bool
+}
+
+type correctionApplier struct {
+	store ReceiverStore
+
+	// originals holds each Receiver as it was before any correction was applied
+	originals map[string]Receiver
+
+	// applied holds the fields (and their values) corrected on each Receiver by earlier entries
+	applied map[string]map[string]string
+}
+
+func (c *correctionApplier) apply(entry correctionEntry) (CorrectionResult, error) {
+	if entry.refused != nil {
+		return CorrectionResult{
+			OriginalTrace: entry.refused.OriginalTrace,
+			ChangeCode:    entry.refused.RefusedChangeCode,
+			Status:        CorrectionIgnored,
+			Err:           ErrCorrectionRefused,
+			Reason:        ErrCorrectionRefused.Error(),
+		}, nil
+	}
+
+	add := entry.addenda98
+	result := CorrectionResult{
+		OriginalTrace: add.OriginalTrace,
+		ChangeCode:    add.ChangeCode,
+	}
+	ignore := func(reason error) (CorrectionResult, error) {
+		result.Status = CorrectionIgnored
+		result.Err, result.Reason = reason, reason.Error()
+		return result, nil
+	}
+	conflict := func(reason error) (CorrectionResult, error) {
+		result.Status = CorrectionConflict
+		result.Err, result.Reason = reason, reason.Error()
+		return result, nil
+	}
+
+	account := strings.TrimSpace(entry.accountNumber)
+	receiver, err := c.store.FindReceiver(add.OriginalTrace, account)
+	if err != nil {
+		return result, fmt.Errorf("finding receiver for %s: %w", add.OriginalTrace, err)
+	}
+	if receiver == nil {
+		return ignore(ErrCorrectionReceiverNotFound)
+	}
+	before := *receiver
+	result.Before = &before
+
+	if add.ChangeCode == "C13" {
+		return ignore(ErrCorrectionAddendaFormat)
+	}
+	data := add.ParseCorrectedData()
+	if data == nil {
+		return conflict(ErrCorrectionUnparsable)
+	}
+	if err := ValidateCorrectedData(add.ChangeCode, data); err != nil {
+		return conflict(fmt.Errorf("%w: %v", ErrCorrectionUnparsable, err))
+	}
+
+	updates := correctedFields(add.ChangeCode, data)
+
+	// Replaying a NOC which was already applied has nothing to change
+	if receiverHasFields(&before, updates) {
+		return ignore(ErrCorrectionNoChange)
+	}
+
+	// The Receiver must have held the values of the original entry before any corrections from this file
+	original, exists := c.originals[before.ID]
+	if !exists {
+		original = before
+		c.originals[before.ID] = before
+	}
+	if account != "" && strings.TrimSpace(original.AccountNumber) != account {
+		return conflict(fmt.Errorf("%w: account number", ErrCorrectionMismatch))
+	}
+	if dfi := strings.TrimSpace(add.OriginalDFI); entry.checkRouting && dfi != "" {
+		if !strings.HasPrefix(original.RoutingNumber, dfi) {
+			return conflict(fmt.Errorf("%w: routing number", ErrCorrectionMismatch))
+		}
+	}
+
+	// Reject corrections which disagree with earlier corrections to the same Receiver
+	earlier := c.applied[before.ID]
+	for field, value := range updates {
+		if prior, exists := earlier[field]; exists && prior != value {
+			return conflict(fmt.Errorf("%w: %s", ErrCorrectionConflicting, field))
+		}
+	}
+
+	after := before
+	setReceiverFields(&after, updates)
+	if err := c.store.UpdateReceiver(&after); err != nil {
+		return result, fmt.Errorf("updating receiver %s: %w", after.ID, err)
+	}
+
+	if earlier == nil {
+		earlier = make(map[string]string)
+		c.applied[before.ID] = earlier
+	}
+	for field, value := range updates {
+		earlier[field] = value
+	}
+
+	result.Status = CorrectionApplied
+	result.After = &after
+	return result, nil
+}
+
+// correctedFields returns the Receiver fields and values corrected by a change code.
+func correctedFields(code string, data *CorrectedData) map[string]string {
+	out := make(map[string]string)
+	switch code {
+	case "C01":
+		out["AccountNumber"] = data.AccountNumber
+	case "C02", "C08":
+		out["RoutingNumber"] = data.RoutingNumber
+	case "C03":
+		out["RoutingNumber"] = data.RoutingNumber
+		out["AccountNumber"] = data.AccountNumber
+	case "C04":
+		out["Name"] = data.Name
+	case "C05":
+		out["TransactionCode"] = strconv.Itoa(data.TransactionCode)
+	case "C06":
+		out["AccountNumber"] = data.AccountNumber
+		out["TransactionCode"] = strconv.Itoa(data.TransactionCode)
+	case "C07":
+		out["RoutingNumber"] = data.RoutingNumber
+		out["AccountNumber"] = data.AccountNumber
+		out["TransactionCode"] = strconv.Itoa(data.TransactionCode)
+	case "C09":
+		out["Identification"] = data.Identification
+	case "C10":
+		out["CompanyName"] = data.CompanyName
+	case "C11":
+		out["CompanyIdentification"] = data.CompanyIdentification
+	case "C12":
+		out["CompanyName"] = data.CompanyName
+		out["CompanyIdentification"] = data.CompanyIdentification
+	}
+	return out
+}
+
+func receiverField(r *Receiver, field string) string {
+	switch field {
+	case "RoutingNumber":
+		return r.RoutingNumber
+	case "AccountNumber":
+		return r.AccountNumber
+	case "TransactionCode":
+		return strconv.Itoa(r.TransactionCode)
+	case "Name":
+		return r.Name
+	case "Identification":
+		return r.Identification
+	case "CompanyName":
+		return r.CompanyName
+	case "CompanyIdentification":
+		return r.CompanyIdentification
+	}
+	return ""
+}
+
+func receiverHasFields(r *Receiver, fields map[string]string) bool {
+	for field, value := range fields {
+		if !strings.EqualFold(strings.TrimSpace(receiverField(r, field)), value) {
+			return false
+		}
+	}
+	return true
+}
+
+func setReceiverFields(r *Receiver, fields map[string]string) {
+	for field, value := range fields {
+		switch field {
+		case "RoutingNumber":
+			r.RoutingNumber = value
+		case "AccountNumber":
+			r.AccountNumber = value
+		case "TransactionCode":
+			if n, err := strconv.Atoi(value); err == nil {
+				r.TransactionCode = n
+			}
+		case "Name":
+			r.Name = value
+		case "Identification":
+			r.Identification = value
+		case "CompanyName":
+			r.CompanyName = value
+		case "CompanyIdentification":
+			r.CompanyIdentification = value
+		}
+	}
+}
+
+// MemoryReceiverStore is an in-memory ReceiverStore which is safe for concurrent use.
+type MemoryReceiverStore struct {
+	mu        sync.RWMutex
+	receivers map[string]*Receiver
+	traces    map[string]string // original trace number to Receiver ID
+}
+
+// NewMemoryReceiverStore returns an empty MemoryReceiverStore
+func NewMemoryReceiverStore() *MemoryReceiverStore {
+	return &MemoryReceiverStore{
+		receivers: make(map[string]*Receiver),
+		traces:    make(map[string]string),
+	}
+}
+
+// AddReceiver saves a copy of receiver and associates it with the trace numbers of entries sent to it.
+func (s *MemoryReceiverStore) AddReceiver(receiver Receiver, traceNumbers ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.receivers[receiver.ID] = &receiver
+	for _, trace := range traceNumbers {
+		s.traces[trace] = receiver.ID
+	}
+}
+
+// GetReceiver returns a copy of the Receiver with id, or nil if it does not exist.
+func (s *MemoryReceiverStore) GetReceiver(id string) *Receiver {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if r, exists := s.receivers[id]; exists {
+		out := *r
+		return &out
+	}
+	return nil
+}
+
+// FindReceiver returns a copy of the Receiver for originalTrace, falling back to the
+// first Receiver with accountNumber.
+func (s *MemoryReceiverStore) FindReceiver(originalTrace, accountNumber string) (*Receiver, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if id, exists := s.traces[originalTrace]; exists {
+		if r, exists := s.receivers[id]; exists {
+			out := *r
+			return &out, nil
+		}
+	}
+	if accountNumber == "" {
+		return nil, nil
+	}
+	var found *Receiver
+	for _, r := range s.receivers {
+		if strings.TrimSpace(r.AccountNumber) != accountNumber {
+			continue
+		}
+		// Pick the lowest ID so lookups are deterministic
+		if found == nil || r.ID < found.ID {
+			found = r
+		}
+	}
+	if found != nil {
+		out := *found
+		return &out, nil
+	}
+	return nil, nil
+}
+
+// UpdateReceiver replaces the stored Receiver with the same ID.
+func (s *MemoryReceiverStore) UpdateReceiver(receiver *Receiver) error {
+	if receiver == nil {
+		return errors.New("nil Receiver")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.receivers[receiver.ID]; !exists {
+		return fmt.Errorf("receiver %s: %w", receiver.ID, ErrCorrectionReceiverNotFound)
+	}
+	r := *receiver
+	s.receivers[receiver.ID] = &r
+	return nil
+}
@@ -0,0 +1,222 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyCorrections(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "cor-example.ach"))
+	require.NoError(t, err)
+
+	store := NewMemoryReceiverStore()
+	store.AddReceiver(Receiver{
+		ID:              "receiver-1",
+		RoutingNumber:   "121042882",
+		AccountNumber:   "744-5678-99",
+		TransactionCode: CheckingCredit,
+		Name:            "Best Co. #23",
+	}, "121042880000001")
+
+	results, err := ApplyCorrections(file, store)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, CorrectionApplied, results[0].Status)
+	require.Equal(t, "C01", results[0].ChangeCode)
+	require.Equal(t, "744-5678-99", results[0].Before.AccountNumber)
+	require.Equal(t, "1918171614", results[0].After.AccountNumber)
+	require.Equal(t, "1918171614", store.GetReceiver("receiver-1").AccountNumber)
+
+	// applying the file again has nothing to change
+	results, err = ApplyCorrections(file, store)
+	require.NoError(t, err)
+	require.Equal(t, CorrectionIgnored, results[0].Status)
+	require.ErrorIs(t, results[0].Err, ErrCorrectionNoChange)
+
+	// unknown receivers are ignored
+	results, err = ApplyCorrections(file, NewMemoryReceiverStore())
+	require.NoError(t, err)
+	require.Equal(t, CorrectionIgnored, results[0].Status)
+	require.ErrorIs(t, results[0].Err, ErrCorrectionReceiverNotFound)
+}
+
+func TestApplyCorrections__CreatedNOC(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	cor, err := file.CreateNotificationOfChange([]ChangeEntry{
+		{
+			TraceNumber:   "121042880000001",
+			ChangeCode:    "C07",
+			CorrectedData: &CorrectedData{RoutingNumber: "987654320", AccountNumber: "5421", TransactionCode: SavingsDebit},
+		},
+	})
+	require.NoError(t, err)
+
+	// look the receiver up by account number
+	store := NewMemoryReceiverStore()
+	store.AddReceiver(Receiver{
+		ID:              "receiver-1",
+		RoutingNumber:   "231380104",
+		AccountNumber:   "12345678",
+		TransactionCode: CheckingDebit,
+	})
+
+	results, err := ApplyCorrections(cor, store)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, CorrectionApplied, results[0].Status)
+
+	r := store.GetReceiver("receiver-1")
+	require.Equal(t, "987654320", r.RoutingNumber)
+	require.Equal(t, "5421", r.AccountNumber)
+	require.Equal(t, SavingsDebit, r.TransactionCode)
+}
+
+func TestCorrectionApplier(t *testing.T) {
+	newStore := func() *MemoryReceiverStore {
+		store := NewMemoryReceiverStore()
+		store.AddReceiver(Receiver{
+			ID:                    "receiver-1",
+			RoutingNumber:         "121042882",
+			AccountNumber:         "12345",
+			TransactionCode:       CheckingCredit,
+			Name:                  "Jane Doe",
+			Identification:        "ABC123",
+			CompanyName:           "Acme Corp",
+			CompanyIdentification: "1234567890",
+		}, "121042880000001")
+		return store
+	}
+	noc := func(code string, data *CorrectedData) correctionEntry {
+		add := NewAddenda98()
+		add.ChangeCode = code
+		add.OriginalTrace = "121042880000001"
+		add.OriginalDFI = "12104288"
+		if data != nil {
+			add.CorrectedData = WriteCorrectionData(code, data)
+		}
+		return correctionEntry{addenda98: add, accountNumber: "12345", checkRouting: true}
+	}
+
+	cases := []struct {
+		code     string
+		data     *CorrectedData
+		expected Receiver
+	}{
+		{"C01", &CorrectedData{AccountNumber: "54321"}, Receiver{AccountNumber: "54321"}},
+		{"C02", &CorrectedData{RoutingNumber: "987654320"}, Receiver{RoutingNumber: "987654320"}},
+		{"C03", &CorrectedData{RoutingNumber: "987654320", AccountNumber: "54321"}, Receiver{RoutingNumber: "987654320", AccountNumber: "54321"}},
+		{"C04", &CorrectedData{Name: "Jane Smith"}, Receiver{Name: "Jane Smith"}},
+		{"C05", &CorrectedData{TransactionCode: SavingsCredit}, Receiver{TransactionCode: SavingsCredit}},
+		{"C06", &CorrectedData{AccountNumber: "54321", TransactionCode: SavingsCredit}, Receiver{AccountNumber: "54321", TransactionCode: SavingsCredit}},
+		{"C09", &CorrectedData{Identification: "XYZ789"}, Receiver{Identification: "XYZ789"}},
+		{"C10", &CorrectedData{CompanyName: "Acme Inc"}, Receiver{CompanyName: "Acme Inc"}},
+		{"C11", &CorrectedData{CompanyIdentification: "0987654321"}, Receiver{CompanyIdentification: "0987654321"}},
+		{"C12", &CorrectedData{CompanyName: "Acme Inc", CompanyIdentification: "0987654321"}, Receiver{CompanyName: "Acme Inc", CompanyIdentification: "0987654321"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.code, func(t *testing.T) {
+			store := newStore()
+			applier := &correctionApplier{store: store, originals: make(map[string]Receiver), applied: make(map[string]map[string]string)}
+
+			result, err := applier.apply(noc(tc.code, tc.data))
+			require.NoError(t, err)
+			require.Equal(t, CorrectionApplied, result.Status, result.Reason)
+
+			r := store.GetReceiver("receiver-1")
+			if tc.expected.AccountNumber != "" {
+				require.Equal(t, tc.expected.AccountNumber, r.AccountNumber)
+			}
+			if tc.expected.RoutingNumber != "" {
+				require.Equal(t, tc.expected.RoutingNumber, r.RoutingNumber)
+			}
+			if tc.expected.TransactionCode != 0 {
+				require.Equal(t, tc.expected.TransactionCode, r.TransactionCode)
+			}
+			if tc.expected.Name != "" {
+				require.Equal(t, tc.expected.Name, r.Name)
+			}
+			if tc.expected.Identification != "" {
+				require.Equal(t, tc.expected.Identification, r.Identification)
+			}
+			if tc.expected.CompanyName != "" {
+				require.Equal(t, tc.expected.CompanyName, r.CompanyName)
+			}
+			if tc.expected.CompanyIdentification != "" {
+				require.Equal(t, tc.expected.CompanyIdentification, r.CompanyIdentification)
+			}
+		})
+	}
+
+	t.Run("C13", func(t *testing.T) {
+		applier := &correctionApplier{store: newStore(), originals: make(map[string]Receiver), applied: make(map[string]map[string]string)}
+		result, err := applier.apply(noc("C13", nil))
+		require.NoError(t, err)
+		require.Equal(t, CorrectionIgnored, result.Status)
+		require.ErrorIs(t, result.Err, ErrCorrectionAddendaFormat)
+	})
+
+	t.Run("conflicts", func(t *testing.T) {
+		store := newStore()
+		applier := &correctionApplier{store: store, originals: make(map[string]Receiver), applied: make(map[string]map[string]string)}
+
+		// the second correction of the account number disagrees with the first
+		result, err := applier.apply(noc("C01", &CorrectedData{AccountNumber: "54321"}))
+		require.NoError(t, err)
+		require.Equal(t, CorrectionApplied, result.Status)
+
+		result, err = applier.apply(noc("C06", &CorrectedData{AccountNumber: "99999", TransactionCode: SavingsCredit}))
+		require.NoError(t, err)
+		require.Equal(t, CorrectionConflict, result.Status)
+		require.ErrorIs(t, result.Err, ErrCorrectionConflicting)
+
+		// other fields of the receiver can still be corrected
+		result, err = applier.apply(noc("C04", &CorrectedData{Name: "Jane Smith"}))
+		require.NoError(t, err)
+		require.Equal(t, CorrectionApplied, result.Status)
+
+		// the NOC was for a different account
+		entry := noc("C04", &CorrectedData{Name: "John Doe"})
+		entry.accountNumber = "11111"
+		result, err = applier.apply(entry)
+		require.NoError(t, err)
+		require.Equal(t, CorrectionConflict, result.Status)
+		require.ErrorIs(t, result.Err, ErrCorrectionMismatch)
+
+		// the NOC was for a different RDFI
+		entry = noc("C09", &CorrectedData{Identification: "XYZ789"})
+		entry.addenda98.OriginalDFI = "23138010"
+		result, err = applier.apply(entry)
+		require.NoError(t, err)
+		require.Equal(t, CorrectionConflict, result.Status)
+		require.ErrorIs(t, result.Err, ErrCorrectionMismatch)
+
+		// corrected data which can't be parsed
+		entry = noc("C06", nil)
+		entry.addenda98.CorrectedData = "54321"
+		result, err = applier.apply(entry)
+		require.NoError(t, err)
+		require.Equal(t, CorrectionConflict, result.Status)
+		require.ErrorIs(t, result.Err, ErrCorrectionUnparsable)
+	})
+}
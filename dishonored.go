@@ -0,0 +1,250 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/base"
+)
+
+// DishonoredReturnEntry identifies a received return entry which an ODFI is dishonoring.
+type DishonoredReturnEntry struct {
+	// TraceNumber is the trace number of the return EntryDetail being dishonored.
+	TraceNumber string `json:"traceNumber"`
+
+	// DishonoredReturnCode is the reason for the dishonored return (R61, R62, R67, R68, R69 or R70).
+	DishonoredReturnCode string `json:"dishonoredReturnCode"`
+
+	// AddendaInformation is optional information included on the Addenda99Dishonored record.
+	AddendaInformation string `json:"addendaInformation,omitempty"`
+}
+
+// ContestedReturnEntry identifies a received dishonored return entry which an RDFI is contesting.
+type ContestedReturnEntry struct {
+	// TraceNumber is the trace number of the dishonored return EntryDetail being contested.
+	TraceNumber string `json:"traceNumber"`
+
+	// ContestedReturnCode is the reason for contesting the dishonored return (R71 through R77).
+	ContestedReturnCode string `json:"contestedReturnCode"`
+
+	// DateOriginalEntryReturned is the date the RDFI returned the original entry. Format: YYMMDD
+	DateOriginalEntryReturned string `json:"dateOriginalEntryReturned"`
+
+	// OriginalSettlementDate is the Julian day the original entry settled. Format: DDD
+	OriginalSettlementDate string `json:"originalSettlementDate"`
+}
+
+// CreateDishonoredReturns builds a dishonored return file from the return entries of f which match
+// the trace numbers in entries. The received return File is not modified.
+//
+// Each dishonored entry keeps the amount and transaction code of the return entry and is sent back
+// to the returning RDFI. The Addenda99Dishonored record links the original entry trace number, the
+// return trace number, return settlement date and return reason code.
+func (f *File) CreateDishonoredReturns(entries []DishonoredReturnEntry) (*File, error) {
+	if len(entries) == 0 {
+		return nil, ErrFileNoReturnEntries
+	}
+	requested := make(map[string]DishonoredReturnEntry, len(entries))
+	for i := range entries {
+		dr := entries[i]
+		if dr.TraceNumber == "" {
+			return nil, fieldError("TraceNumber", ErrFieldRequired, dr.TraceNumber)
+		}
+		if f.validateOpts == nil || !f.validateOpts.CustomReturnCodes {
+			if !IsDishonoredReturnCode(strings.ToUpper(dr.DishonoredReturnCode)) {
+				return nil, fieldError("DishonoredReturnReasonCode", ErrAddenda99DishonoredReturnCode, dr.DishonoredReturnCode)
+			}
+		}
+		if _, exists := requested[dr.TraceNumber]; exists {
+			return nil, fmt.Errorf("trace number %s: %w", dr.TraceNumber, ErrFileReturnEntryDuplicate)
+		}
+		requested[dr.TraceNumber] = dr
+	}
+
+	out := NewFile()
+	out.ID = base.ID()
+	out.Header = f.returnFileHeader(time.Now())
+	out.SetValidation(f.validateOpts)
+
+	found := make(map[string]bool)
+	err := f.createReturnBatches(out, "", func(bh *BatchHeader, entry *EntryDetail) (*EntryDetail, error) {
+		dr, exists := requested[entry.TraceNumber]
+		if !exists {
+			return nil, nil
+		}
+		if entry.Category != CategoryReturn || entry.Addenda99 == nil {
+			return nil, fmt.Errorf("trace number %s: %w", entry.TraceNumber, ErrFileDishonoredEntryCategory)
+		}
+		found[entry.TraceNumber] = true
+		return createDishonoredEntryDetail(bh, entry, dr), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		if !found[entries[i].TraceNumber] {
+			return nil, fmt.Errorf("trace number %s: %w", entries[i].TraceNumber, ErrFileReturnEntryNotFound)
+		}
+	}
+	if err := out.Create(); err != nil {
+		return nil, err
+	}
+	if err := out.Validate(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateContestedReturns builds a contested dishonored return file from the dishonored return entries
+// of f which match the trace numbers in entries. The received dishonored return File is not modified.
+//
+// Each contested entry keeps the amount and transaction code of the dishonored return entry and is sent
+// back to the ODFI. The Addenda99Contested record links the original entry, return and dishonored return.
+func (f *File) CreateContestedReturns(entries []ContestedReturnEntry) (*File, error) {
+	if len(entries) == 0 {
+		return nil, ErrFileNoReturnEntries
+	}
+	requested := make(map[string]ContestedReturnEntry, len(entries))
+	for i := range entries {
+		cr := entries[i]
+		if cr.TraceNumber == "" {
+			return nil, fieldError("TraceNumber", ErrFieldRequired, cr.TraceNumber)
+		}
+		if f.validateOpts == nil || !f.validateOpts.CustomReturnCodes {
+			if !IsContestedReturnCode(strings.ToUpper(cr.ContestedReturnCode)) {
+				return nil, fieldError("ContestedReturnCode", ErrAddenda99ContestedReturnCode, cr.ContestedReturnCode)
+			}
+		}
+		if _, err := time.Parse("060102", cr.DateOriginalEntryReturned); err != nil {
+			return nil, fieldError("DateOriginalEntryReturned", ErrValidDay, cr.DateOriginalEntryReturned)
+		}
+		if n, err := strconv.Atoi(cr.OriginalSettlementDate); err != nil || n < 1 || n > 366 {
+			return nil, fieldError("OriginalSettlementDate", ErrValidDay, cr.OriginalSettlementDate)
+		}
+		if _, exists := requested[cr.TraceNumber]; exists {
+			return nil, fmt.Errorf("trace number %s: %w", cr.TraceNumber, ErrFileReturnEntryDuplicate)
+		}
+		requested[cr.TraceNumber] = cr
+	}
+
+	out := NewFile()
+	out.ID = base.ID()
+	out.Header = f.returnFileHeader(time.Now())
+	out.SetValidation(f.validateOpts)
+
+	found := make(map[string]bool)
+	err := f.createReturnBatches(out, "", func(bh *BatchHeader, entry *EntryDetail) (*EntryDetail, error) {
+		cr, exists := requested[entry.TraceNumber]
+		if !exists {
+			return nil, nil
+		}
+		if entry.Category != CategoryDishonoredReturn || entry.Addenda99Dishonored == nil {
+			return nil, fmt.Errorf("trace number %s: %w", entry.TraceNumber, ErrFileContestedEntryCategory)
+		}
+		found[entry.TraceNumber] = true
+		return createContestedEntryDetail(bh, entry, cr), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		if !found[entries[i].TraceNumber] {
+			return nil, fmt.Errorf("trace number %s: %w", entries[i].TraceNumber, ErrFileReturnEntryNotFound)
+		}
+	}
+	if err := out.Create(); err != nil {
+		return nil, err
+	}
+	if err := out.Validate(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// copyReturnEntryDetail creates an EntryDetail sent back to the ODFI of bh with the amount and
+// transaction code of entry.
+func copyReturnEntryDetail(bh *BatchHeader, entry *EntryDetail) *EntryDetail {
+	odfi := bh.ODFIIdentificationField()
+
+	ed := NewEntryDetail()
+	ed.ID = base.ID()
+	ed.TransactionCode = entry.TransactionCode
+	ed.RDFIIdentification = odfi
+	ed.CheckDigit = strconv.Itoa(CalculateCheckDigit(odfi))
+	ed.DFIAccountNumber = entry.DFIAccountNumber
+	ed.Amount = entry.Amount
+	ed.IdentificationNumber = entry.IdentificationNumber
+	ed.IndividualName = entry.IndividualName
+	ed.DiscretionaryData = entry.DiscretionaryData
+	ed.AddendaRecordIndicator = 1
+	return ed
+}
+
+func createDishonoredEntryDetail(bh *BatchHeader, entry *EntryDetail, dr DishonoredReturnEntry) *EntryDetail {
+	ed := copyReturnEntryDetail(bh, entry)
+	ed.Category = CategoryDishonoredReturn
+
+	addenda99 := NewAddenda99Dishonored()
+	addenda99.ID = base.ID()
+	addenda99.DishonoredReturnReasonCode = strings.ToUpper(dr.DishonoredReturnCode)
+	addenda99.OriginalEntryTraceNumber = entry.Addenda99.OriginalTrace
+	addenda99.OriginalReceivingDFIIdentification = entry.Addenda99.OriginalDFI
+	addenda99.ReturnTraceNumber = entry.TraceNumber
+	addenda99.ReturnSettlementDate = bh.SettlementDate
+	addenda99.ReturnReasonCode = returnReasonCodeDigits(entry.Addenda99.ReturnCode)
+	addenda99.AddendaInformation = dr.AddendaInformation
+	ed.Addenda99Dishonored = addenda99
+
+	return ed
+}
+
+func createContestedEntryDetail(bh *BatchHeader, entry *EntryDetail, cr ContestedReturnEntry) *EntryDetail {
+	ed := copyReturnEntryDetail(bh, entry)
+	ed.Category = CategoryDishonoredReturnContested
+
+	dishonored := entry.Addenda99Dishonored
+
+	addenda99 := NewAddenda99Contested()
+	addenda99.ID = base.ID()
+	addenda99.ContestedReturnCode = strings.ToUpper(cr.ContestedReturnCode)
+	addenda99.OriginalEntryTraceNumber = dishonored.OriginalEntryTraceNumber
+	addenda99.DateOriginalEntryReturned = cr.DateOriginalEntryReturned
+	addenda99.OriginalReceivingDFIIdentification = dishonored.OriginalReceivingDFIIdentification
+	addenda99.OriginalSettlementDate = cr.OriginalSettlementDate
+	addenda99.ReturnTraceNumber = dishonored.ReturnTraceNumber
+	addenda99.ReturnSettlementDate = dishonored.ReturnSettlementDate
+	addenda99.ReturnReasonCode = returnReasonCodeDigits(dishonored.ReturnReasonCode)
+	addenda99.DishonoredReturnTraceNumber = entry.TraceNumber
+	addenda99.DishonoredReturnSettlementDate = bh.SettlementDate
+	addenda99.DishonoredReturnReasonCode = returnReasonCodeDigits(dishonored.DishonoredReturnReasonCode)
+	ed.Addenda99Contested = addenda99
+
+	return ed
+}
+
+// returnReasonCodeDigits drops the leading R from a return code (R01 becomes 01) as
+// dishonored and contested addenda records hold the two digit return reason code.
+func returnReasonCodeDigits(code string) string {
+	return strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(code)), "R")
+}
@@ -0,0 +1,165 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readReturnFile creates a return for ppd-debit.ach as it would be received from the ACH Operator
+func readReturnFile(t *testing.T) *File {
+	t.Helper()
+
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	returns, err := file.CreateReturns([]ReturnEntry{{TraceNumber: "121042880000001", ReturnCode: "R01"}})
+	require.NoError(t, err)
+	returns.Batches[0].GetHeader().SettlementDate = "178"
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(returns))
+	parsed, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+	return &parsed
+}
+
+func TestFile__CreateDishonoredReturns(t *testing.T) {
+	returns := readReturnFile(t)
+
+	dishonored, err := returns.CreateDishonoredReturns([]DishonoredReturnEntry{
+		{TraceNumber: "231380100000001", DishonoredReturnCode: "R68", AddendaInformation: "UNTIMELY"},
+	})
+	require.NoError(t, err)
+	require.Len(t, dishonored.Batches, 1)
+
+	bh := dishonored.Batches[0].GetHeader()
+	require.Equal(t, "12104288", bh.ODFIIdentification)
+
+	ed := dishonored.Batches[0].GetEntries()[0]
+	require.Equal(t, CategoryDishonoredReturn, ed.Category)
+	require.Equal(t, CheckingReturnNOCDebit, ed.TransactionCode)
+	require.Equal(t, "23138010", ed.RDFIIdentification)
+	require.Equal(t, 100000000, ed.Amount)
+	require.Equal(t, "121042880000001", ed.TraceNumber)
+
+	addenda := ed.Addenda99Dishonored
+	require.NotNil(t, addenda)
+	require.Equal(t, "R68", addenda.DishonoredReturnReasonCode)
+	require.Equal(t, "121042880000001", addenda.OriginalEntryTraceNumber)
+	require.Equal(t, "23138010", addenda.OriginalReceivingDFIIdentification)
+	require.Equal(t, "231380100000001", addenda.ReturnTraceNumber)
+	require.Equal(t, "178", addenda.ReturnSettlementDate)
+	require.Equal(t, "01", addenda.ReturnReasonCode)
+	require.Equal(t, ed.TraceNumber, addenda.TraceNumber)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(dishonored))
+	parsed, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+
+	entry := parsed.Batches[0].GetEntries()[0]
+	require.Equal(t, CategoryDishonoredReturn, entry.Category)
+	require.Equal(t, "231380100000001", entry.Addenda99Dishonored.ReturnTraceNumber)
+}
+
+func TestFile__CreateContestedReturns(t *testing.T) {
+	returns := readReturnFile(t)
+
+	dishonored, err := returns.CreateDishonoredReturns([]DishonoredReturnEntry{
+		{TraceNumber: "231380100000001", DishonoredReturnCode: "R68"},
+	})
+	require.NoError(t, err)
+	dishonored.Batches[0].GetHeader().SettlementDate = "180"
+
+	contested, err := dishonored.CreateContestedReturns([]ContestedReturnEntry{
+		{
+			TraceNumber:               "121042880000001",
+			ContestedReturnCode:       "R73",
+			DateOriginalEntryReturned: "190626",
+			OriginalSettlementDate:    "176",
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, contested.Batches, 1)
+	require.Equal(t, "23138010", contested.Batches[0].GetHeader().ODFIIdentification)
+
+	ed := contested.Batches[0].GetEntries()[0]
+	require.Equal(t, CategoryDishonoredReturnContested, ed.Category)
+	require.Equal(t, 100000000, ed.Amount)
+	require.Equal(t, "12104288", ed.RDFIIdentification)
+
+	addenda := ed.Addenda99Contested
+	require.NotNil(t, addenda)
+	require.Equal(t, "R73", addenda.ContestedReturnCode)
+	require.Equal(t, "121042880000001", addenda.OriginalEntryTraceNumber)
+	require.Equal(t, "190626", addenda.DateOriginalEntryReturned)
+	require.Equal(t, "23138010", addenda.OriginalReceivingDFIIdentification)
+	require.Equal(t, "176", addenda.OriginalSettlementDate)
+	require.Equal(t, "231380100000001", addenda.ReturnTraceNumber)
+	require.Equal(t, "178", addenda.ReturnSettlementDate)
+	require.Equal(t, "01", addenda.ReturnReasonCode)
+	require.Equal(t, "121042880000001", addenda.DishonoredReturnTraceNumber)
+	require.Equal(t, "180", addenda.DishonoredReturnSettlementDate)
+	require.Equal(t, "68", addenda.DishonoredReturnReasonCode)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(contested))
+	parsed, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+	require.Equal(t, CategoryDishonoredReturnContested, parsed.Batches[0].GetEntries()[0].Category)
+}
+
+func TestFile__CreateDishonoredReturnsErrors(t *testing.T) {
+	returns := readReturnFile(t)
+
+	_, err := returns.CreateDishonoredReturns(nil)
+	require.ErrorIs(t, err, ErrFileNoReturnEntries)
+
+	_, err = returns.CreateDishonoredReturns([]DishonoredReturnEntry{{TraceNumber: "231380100000001", DishonoredReturnCode: "R01"}})
+	require.ErrorIs(t, err, ErrAddenda99DishonoredReturnCode)
+
+	_, err = returns.CreateDishonoredReturns([]DishonoredReturnEntry{{TraceNumber: "999999999999999", DishonoredReturnCode: "R69"}})
+	require.ErrorIs(t, err, ErrFileReturnEntryNotFound)
+
+	// forward entries can't be dishonored
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	_, err = file.CreateDishonoredReturns([]DishonoredReturnEntry{{TraceNumber: "121042880000001", DishonoredReturnCode: "R69"}})
+	require.ErrorIs(t, err, ErrFileDishonoredEntryCategory)
+
+	// return entries can't be contested
+	_, err = returns.CreateContestedReturns([]ContestedReturnEntry{
+		{TraceNumber: "231380100000001", ContestedReturnCode: "R77", DateOriginalEntryReturned: "190626", OriginalSettlementDate: "176"},
+	})
+	require.ErrorIs(t, err, ErrFileContestedEntryCategory)
+
+	_, err = returns.CreateContestedReturns([]ContestedReturnEntry{
+		{TraceNumber: "231380100000001", ContestedReturnCode: "R73", DateOriginalEntryReturned: "19062", OriginalSettlementDate: "176"},
+	})
+	require.ErrorIs(t, err, ErrValidDay)
+
+	_, err = returns.CreateContestedReturns([]ContestedReturnEntry{
+		{TraceNumber: "231380100000001", ContestedReturnCode: "R69", DateOriginalEntryReturned: "190626", OriginalSettlementDate: "176"},
+	})
+	require.ErrorIs(t, err, ErrAddenda99ContestedReturnCode)
+}
@@ -101,6 +101,8 @@ type EntryDetail struct {
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// source is where the record was read from
+	source
 
 	validateOpts *ValidateOpts
 }
@@ -205,97 +207,55 @@ func NewEntryDetail() *EntryDetail {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (ed *EntryDetail) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// do nothing, ignore "6" record type
-			reset()
-
-		case 3:
-			// 2-3 is checking credit 22 debit 27 savings credit 32 debit 37
-			ed.TransactionCode = ed.parseNumField(reset())
-
-		case 11:
-			// 4-11 the RDFI's routing number without the last digit.
-			ed.RDFIIdentification = reset()
-
-		case 12:
-			// 12-12 The last digit of the RDFI's routing number
-			ed.CheckDigit = reset()
-
-		case 29:
-			// 13-29 The receiver's bank account number you are crediting/debiting
-			ed.DFIAccountNumber = ed.parseStringFieldWithOpts(reset(), ed.validateOpts)
-
-		case 39:
-			// 30-39 Number of cents you are debiting/crediting this account
-			ed.Amount = ed.parseNumField(reset())
-
-		case 54:
-			// 40-54 An internal identification (alphanumeric) that you use to uniquely identify this Entry Detail Record
-			ed.IdentificationNumber = reset()
-
-		case 76:
-			// 55-76 The name of the receiver, usually the name on the bank account
-			ed.IndividualName = reset()
-
-		case 78:
-			// 77-78 allows ODFIs to include codes of significance only to them, normally blank
-			// For WEB and TEL batches this field is the PaymentType which is either R(reoccurring) or S(single)
-			ed.DiscretionaryData = reset()
-
-		case 79:
-			// 79-79 1 if addenda exists 0 if it does not
-			ed.AddendaRecordIndicator = ed.parseNumField(reset())
-
-		case 94:
-			// 80-94 An internal identification (numeric) that you use to uniquely identify
-			// this Entry Detail Record This number should be unique to the transaction and will help identify the transaction in case of an inquiry
-			ed.TraceNumber = reset() // capture end of record
-		}
-	}
+	// 1-1 Always "6"
+	// 2-3 is checking credit 22 debit 27 savings credit 32 debit 37
+	ed.TransactionCode = ed.parseNumField(fields.field(1, 3))
+	// 4-11 the RDFI's routing number without the last digit.
+	ed.RDFIIdentification = fields.field(3, 11)
+	// 12-12 The last digit of the RDFI's routing number
+	ed.CheckDigit = fields.field(11, 12)
+	// 13-29 The receiver's bank account number you are crediting/debiting
+	ed.DFIAccountNumber = ed.parseStringFieldWithOpts(fields.field(12, 29), ed.validateOpts)
+	// 30-39 Number of cents you are debiting/crediting this account
+	ed.Amount = ed.parseNumField(fields.field(29, 39))
+	// 40-54 An internal identification (alphanumeric) that you use to uniquely identify this Entry Detail Record
+	ed.IdentificationNumber = fields.field(39, 54)
+	// 55-76 The name of the receiver, usually the name on the bank account
+	ed.IndividualName = fields.field(54, 76)
+	// 77-78 allows ODFIs to include codes of significance only to them, normally blank
+	// For WEB and TEL batches this field is the PaymentType which is either R(reoccurring) or S(single)
+	ed.DiscretionaryData = fields.field(76, 78)
+	// 79-79 1 if addenda exists 0 if it does not
+	ed.AddendaRecordIndicator = ed.parseNumField(fields.field(78, 79))
+	// 80-94 An internal identification (numeric) that you use to uniquely identify
+	// this Entry Detail Record This number should be unique to the transaction and will help identify the transaction in case of an inquiry
+	ed.TraceNumber = fields.field(79, 94) // capture end of record
 }
 
 // String writes the EntryDetail struct to a 94 character string.
 func (ed *EntryDetail) String() string {
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryDetailPos)
-	buf.WriteString(fmt.Sprintf("%v", ed.TransactionCode))
-	buf.WriteString(ed.RDFIIdentificationField())
-	buf.WriteString(ed.CheckDigit)
-	buf.WriteString(ed.DFIAccountNumberField())
-	buf.WriteString(ed.AmountField())
-	buf.WriteString(ed.IdentificationNumberField())
-	buf.WriteString(ed.IndividualNameField())
-	buf.WriteString(ed.DiscretionaryDataField())
-	buf.WriteString(fmt.Sprintf("%v", ed.AddendaRecordIndicator))
-	buf.WriteString(ed.TraceNumberField())
+	return string(ed.AppendTo(make([]byte, 0, RecordLength)))
+}
 
-	return buf.String()
+// AppendTo appends the EntryDetail record to dst and returns the extended buffer.
+func (ed *EntryDetail) AppendTo(dst []byte) []byte {
+	dst = append(dst, entryDetailPos...)
+	dst = strconv.AppendInt(dst, int64(ed.TransactionCode), 10)
+	dst = ed.appendStringField(dst, ed.RDFIIdentification, 8)
+	dst = append(dst, ed.CheckDigit...)
+	dst = ed.appendAlphaField(dst, ed.DFIAccountNumber, 17)
+	dst = ed.appendNumericField(dst, ed.Amount, 10)
+	dst = ed.appendAlphaField(dst, ed.IdentificationNumber, 15)
+	dst = ed.appendAlphaField(dst, ed.IndividualName, 22)
+	dst = ed.appendAlphaField(dst, ed.DiscretionaryData, 2)
+	dst = strconv.AppendInt(dst, int64(ed.AddendaRecordIndicator), 10)
+	dst = ed.appendStringField(dst, ed.TraceNumber, 15)
+	return dst
 }
 
 // SetValidation stores ValidateOpts on the EntryDetail which are to be used to override
@@ -378,6 +338,49 @@ func (ed *EntryDetail) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (ed *EntryDetail) validateAll() []error {
+	var errs fieldErrors
+	if ed.TransactionCode == 0 {
+		errs.add("TransactionCode", ErrConstructor, strconv.Itoa(ed.TransactionCode))
+	}
+	if ed.RDFIIdentification == "" {
+		errs.add("RDFIIdentification", ErrConstructor, ed.RDFIIdentificationField())
+	}
+	if ed.DFIAccountNumber == "" {
+		errs.add("DFIAccountNumber", ErrConstructor, ed.DFIAccountNumber)
+	}
+	if ed.IndividualName == "" {
+		errs.add("IndividualName", ErrConstructor, ed.IndividualName)
+	}
+	if ed.TraceNumber == "" {
+		errs.add("TraceNumber", ErrConstructor, ed.TraceNumberField())
+	}
+	if ed.validateOpts != nil && ed.validateOpts.CheckTransactionCode != nil {
+		errs.add("TransactionCode", ed.validateOpts.CheckTransactionCode(ed.TransactionCode), strconv.Itoa(ed.TransactionCode))
+	} else {
+		errs.add("TransactionCode", ed.isTransactionCode(ed.TransactionCode), strconv.Itoa(ed.TransactionCode))
+	}
+	errs.add("DFIAccountNumber", ed.isAlphanumeric(ed.DFIAccountNumber), ed.DFIAccountNumber)
+	if ed.Amount < 0 {
+		errs.add("Amount", ErrNegativeAmount, ed.Amount)
+	}
+	errs.add("Amount", ed.amountOverflowsField(), ed.Amount)
+	errs.add("IdentificationNumber", ed.isAlphanumeric(ed.IdentificationNumber), ed.IdentificationNumber)
+	errs.add("IndividualName", ed.isAlphanumeric(ed.IndividualName), ed.IndividualName)
+	errs.add("DiscretionaryData", ed.isAlphanumeric(ed.DiscretionaryData), ed.DiscretionaryData)
+	if ed.validateOpts == nil || !ed.validateOpts.AllowInvalidCheckDigit {
+		calculated := CalculateCheckDigit(ed.RDFIIdentificationField())
+		if edCheckDigit, err := strconv.Atoi(ed.CheckDigit); err != nil {
+			errs.add("CheckDigit", err, ed.CheckDigit)
+		} else if calculated != edCheckDigit {
+			errs.add("RDFIIdentification", NewErrValidCheckDigit(calculated), ed.CheckDigit)
+		}
+	}
+	return errs
+}
+
 var (
 	// Amount is a 10 digit field
 	maxAmount = 9_999_999_999
@@ -705,3 +708,38 @@ func (ed *EntryDetail) addendaCount() (n int) {
 	}
 	return n
 }
+
+// copyEntryDetail returns a copy of ed and its addenda records which can be modified without changing ed.
+func copyEntryDetail(ed *EntryDetail) *EntryDetail {
+	out := *ed
+	if ed.Addenda02 != nil {
+		a := *ed.Addenda02
+		out.Addenda02 = &a
+	}
+	out.Addenda05 = nil
+	for _, addenda05 := range ed.Addenda05 {
+		a := *addenda05
+		out.AddAddenda05(&a)
+	}
+	if ed.Addenda98 != nil {
+		a := *ed.Addenda98
+		out.Addenda98 = &a
+	}
+	if ed.Addenda98Refused != nil {
+		a := *ed.Addenda98Refused
+		out.Addenda98Refused = &a
+	}
+	if ed.Addenda99 != nil {
+		a := *ed.Addenda99
+		out.Addenda99 = &a
+	}
+	if ed.Addenda99Contested != nil {
+		a := *ed.Addenda99Contested
+		out.Addenda99Contested = &a
+	}
+	if ed.Addenda99Dishonored != nil {
+		a := *ed.Addenda99Dishonored
+		out.Addenda99Dishonored = &a
+	}
+	return &out
+}
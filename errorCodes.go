@@ -0,0 +1,102 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/moov-io/base"
+)
+
+// ErrorCode is a stable identifier of an error, such as "ACH-FIELD-ROUTING-CHECKDIGIT".
+// Codes don't change when error messages are reworded, so they can be matched by programs.
+type ErrorCode string
+
+// ErrorCategory groups error codes by what was checked.
+type ErrorCategory string
+
+const (
+	// ErrorCategoryField is the category of errors with a single field of a record
+	ErrorCategoryField ErrorCategory = "field"
+	// ErrorCategoryBatch is the category of errors with a batch, its entries or totals
+	ErrorCategoryBatch ErrorCategory = "batch"
+	// ErrorCategoryFile is the category of errors with the structure or totals of a file
+	ErrorCategoryFile ErrorCategory = "file"
+)
+
+// Category returns the category of an error code, which is the segment after "ACH-".
+func (c ErrorCode) Category() ErrorCategory {
+	parts := strings.SplitN(string(c), "-", 3)
+	if len(parts) != 3 {
+		return ""
+	}
+	return ErrorCategory(strings.ToLower(parts[1]))
+}
+
+// ErrorCoder is implemented by errors with an ErrorCode. FieldError, BatchError and the error types
+// of this package implement ErrorCoder, so the code of a wrapped error can be found with errors.As:
+//
+//	var coder ach.ErrorCoder
+//	if errors.As(err, &coder) {
+//		fmt.Println(coder.Code(), coder.Code().Category())
+//	}
+//
+// FieldError and BatchError return an empty ErrorCode when the error they wrap doesn't have a code.
+type ErrorCoder interface {
+	error
+	Code() ErrorCode
+}
+
+// CodeOf returns the ErrorCode of err, or of the first error it wraps which has a code.
+// The first coded error of a base.ErrorList is used. An empty ErrorCode is returned when no code is found.
+func CodeOf(err error) ErrorCode {
+	for err != nil {
+		if coder, ok := err.(ErrorCoder); ok {
+			return coder.Code()
+		}
+		if list, ok := err.(base.ErrorList); ok {
+			for i := range list {
+				if code := CodeOf(list[i]); code != "" {
+					return code
+				}
+			}
+			return ""
+		}
+		if code := sentinelCode(err); code != "" {
+			return code
+		}
+		err = errors.Unwrap(err)
+	}
+	return ""
+}
+
+// sentinelCode returns the ErrorCode of a sentinel error declared in fieldErrors.go, batchErrors.go or fileErrors.go.
+func sentinelCode(err error) ErrorCode {
+	if !reflect.TypeOf(err).Comparable() {
+		return ""
+	}
+	if code, ok := fieldErrorCodes[err]; ok {
+		return code
+	}
+	if code, ok := batchErrorCodes[err]; ok {
+		return code
+	}
+	return fileErrorCodes[err]
+}
@@ -0,0 +1,152 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeOf(t *testing.T) {
+	err := fieldError("RDFIIdentification", NewErrValidCheckDigit(3), "23138010")
+	require.Equal(t, ErrorCode("ACH-FIELD-ROUTING-CHECKDIGIT"), CodeOf(err))
+	require.Equal(t, ErrorCategoryField, CodeOf(err).Category())
+
+	var coder ErrorCoder
+	require.True(t, errors.As(err, &coder))
+	require.Equal(t, ErrorCode("ACH-FIELD-ROUTING-CHECKDIGIT"), coder.Code())
+
+	batch := &Batch{Header: NewBatchHeader()}
+	err = fmt.Errorf("reading file: %w", batch.Error("Amount", NewErrBatchAmount(100, 10)))
+	require.Equal(t, ErrorCode("ACH-BATCH-AMOUNT-LIMIT"), CodeOf(err))
+	require.Equal(t, ErrorCategoryBatch, CodeOf(err).Category())
+
+	// sentinels keep their identity
+	err = batch.Error("entries", ErrBatchNoEntries)
+	require.ErrorIs(t, err, ErrBatchNoEntries)
+	require.True(t, base.Match(err, ErrBatchNoEntries))
+	require.False(t, base.Match(err, ErrBatchDebitOnly))
+	require.Equal(t, ErrorCode("ACH-BATCH-NO-ENTRIES"), CodeOf(err))
+
+	var list base.ErrorList
+	list.Add(errors.New("no code"))
+	list.Add(ErrFileNoBatches)
+	require.Equal(t, ErrorCode("ACH-FILE-NO-BATCHES"), CodeOf(list))
+	require.Equal(t, ErrorCategoryFile, CodeOf(list).Category())
+
+	require.Empty(t, CodeOf(nil))
+	require.Empty(t, CodeOf(errors.New("no code")))
+	require.Empty(t, CodeOf(fieldError("Amount", errors.New("no code"))))
+	require.Empty(t, ErrorCode("").Category())
+}
+
+func TestCodeOf__ValidationReport(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	file.Header.FileIDModifier = "a"
+
+	report := file.ValidateAll()
+	require.NotEmpty(t, report.Findings)
+	require.Equal(t, ErrorCode("ACH-FIELD-UPPERCASE"), CodeOf(report.Findings[0]))
+
+	bs, err := json.Marshal(report.Findings[0])
+	require.NoError(t, err)
+	require.Contains(t, string(bs), `"code":"ACH-FIELD-UPPERCASE","category":"field"`)
+}
+
+// TestErrorCodes checks every error declared in the error files has a unique and well formed code.
+func TestErrorCodes(t *testing.T) {
+	pattern := regexp.MustCompile(`^ACH-(FIELD|BATCH|FILE)-[A-Z0-9]+(-[A-Z0-9]+)*$`)
+	seen := make(map[ErrorCode]bool)
+	check := func(code ErrorCode) {
+		t.Helper()
+		require.Regexp(t, pattern, string(code))
+		require.False(t, seen[code], "%s is used more than once", code)
+		seen[code] = true
+	}
+
+	for _, codes := range []map[error]ErrorCode{fieldErrorCodes, batchErrorCodes, fileErrorCodes} {
+		for _, code := range codes {
+			check(code)
+		}
+	}
+	for _, err := range []ErrorCoder{
+		ErrValidCheckDigit{}, ErrValidFieldLength{}, ErrRecordType{},
+		RecordWrongLengthErr{}, ErrUnknownRecordType{}, ErrFileUnknownSEC{},
+		ErrFileCalculatedControlEquality{}, ErrFileBatchNumberAscending{},
+		ErrBatchHeaderControlEquality{}, ErrBatchCalculatedControlEquality{}, ErrBatchAscending{},
+		ErrBatchCategory{}, ErrBatchTraceNumberNotODFI{}, ErrBatchAddendaTraceNumber{},
+		ErrBatchAddendaCount{}, ErrBatchRequiredAddendaCount{}, ErrBatchExpectedAddendaCount{},
+		ErrBatchServiceClassTranCode{}, ErrBatchAmount{}, ErrBatchIATNOC{},
+	} {
+		check(err.Code())
+	}
+
+	// every sentinel and error type needs a code
+	for _, path := range []string{"fieldErrors.go", "batchErrors.go", "fileErrors.go"} {
+		node, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		require.NoError(t, err)
+
+		var sentinels, coded []string
+		types := make(map[string]int)
+		ast.Inspect(node, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.ValueSpec:
+				if call, ok := n.Values[0].(*ast.CallExpr); ok && strings.HasPrefix(fmt.Sprint(call.Fun), "&{errors New") {
+					sentinels = append(sentinels, n.Names[0].Name)
+				}
+			case *ast.CompositeLit:
+				if _, ok := n.Type.(*ast.MapType); !ok {
+					return true
+				}
+				for _, elt := range n.Elts {
+					if kv, ok := elt.(*ast.KeyValueExpr); ok {
+						if ident, ok := kv.Key.(*ast.Ident); ok {
+							coded = append(coded, ident.Name)
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				// Batch.Error and IATBatch.Error create errors, so only methods without arguments are counted
+				if n.Recv != nil && n.Type.Params.NumFields() == 0 && (n.Name.Name == "Error" || n.Name.Name == "Code") {
+					recv := n.Recv.List[0].Type
+					if star, ok := recv.(*ast.StarExpr); ok {
+						recv = star.X
+					}
+					types[fmt.Sprint(recv)]++
+				}
+			}
+			return true
+		})
+		require.ElementsMatch(t, sentinels, coded, path)
+		for name, methods := range types {
+			require.Equal(t, 2, methods, "%s in %s", name, path)
+		}
+	}
+}
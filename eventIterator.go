@@ -0,0 +1,396 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// EventType identifies the record an Event holds.
+type EventType int
+
+const (
+	// FileHeaderEvent holds a FileHeader
+	FileHeaderEvent EventType = iota + 1
+	// BatchHeaderEvent holds a BatchHeader
+	BatchHeaderEvent
+	// IATBatchHeaderEvent holds an IATBatchHeader
+	IATBatchHeaderEvent
+	// EntryEvent holds an EntryDetail along with its addenda records
+	EntryEvent
+	// IATEntryEvent holds an IATEntryDetail along with its addenda records
+	IATEntryEvent
+	// ADVEntryEvent holds an ADVEntryDetail along with its Addenda99
+	ADVEntryEvent
+	// BatchControlEvent holds the BatchControl of a batch or IAT batch
+	BatchControlEvent
+	// ADVBatchControlEvent holds an ADVBatchControl
+	ADVBatchControlEvent
+	// FileControlEvent holds a FileControl
+	FileControlEvent
+	// ADVFileControlEvent holds an ADVFileControl
+	ADVFileControlEvent
+)
+
+func (t EventType) String() string {
+	switch t {
+	case FileHeaderEvent:
+		return "FileHeader"
+	case BatchHeaderEvent:
+		return "BatchHeader"
+	case IATBatchHeaderEvent:
+		return "IATBatchHeader"
+	case EntryEvent:
+		return "EntryDetail"
+	case IATEntryEvent:
+		return "IATEntryDetail"
+	case ADVEntryEvent:
+		return "ADVEntryDetail"
+	case BatchControlEvent:
+		return "BatchControl"
+	case ADVBatchControlEvent:
+		return "ADVBatchControl"
+	case FileControlEvent:
+		return "FileControl"
+	case ADVFileControlEvent:
+		return "ADVFileControl"
+	}
+	return "unknown"
+}
+
+// Event is a record read by an EventIterator. Only the field matching its Type is set.
+type Event struct {
+	Type EventType
+
+	FileHeader      *FileHeader
+	BatchHeader     *BatchHeader
+	IATBatchHeader  *IATBatchHeader
+	Entry           *EntryDetail
+	IATEntry        *IATEntryDetail
+	ADVEntry        *ADVEntryDetail
+	BatchControl    *BatchControl
+	ADVBatchControl *ADVBatchControl
+	FileControl     *FileControl
+	ADVFileControl  *ADVFileControl
+}
+
+// EventIterator reads a file one record at a time, including IAT and ADV batches and control records.
+// Entries are returned once their addenda records have been read. Only the current record is kept in memory.
+//
+// Records are validated as they're read but batches and the file are not, as that requires all of their
+// entries. Use Reader to check a whole file.
+type EventIterator struct {
+	reader     *Reader
+	scanner    *bufio.Scanner
+	cachedLine string
+
+	// err is returned by the first call to Next when the input couldn't be read
+	err error
+
+	// batch is the Standard Entry Class Code of the current batch, or empty between batches
+	batch string
+	// adv is set once an ADV batch has been read
+	adv bool
+	// pending is the entry whose addenda records are being read
+	pending *Event
+
+	// reuseEntries is set by callers which don't keep records, such as ValidateStream, so each entry is
+	// parsed into entry and returned in event instead of allocating new ones
+	reuseEntries bool
+	entry        EntryDetail
+	event        Event
+}
+
+// NewEventIterator returns an EventIterator reading from r. Like NewReader, windows-1252 input is decoded into UTF-8.
+func NewEventIterator(r io.Reader) *EventIterator {
+	reader := NewReader(strings.NewReader("")) // the input is not used, we only parse records
+
+	out := &EventIterator{
+		reader: reader,
+	}
+
+	// charset.Reader will decode windows-1252 strings into utf-8 as NewReader does.
+	rr, err := charset.NewReader(r, "text/plain")
+	if err != nil {
+		if err != io.EOF && err != io.ErrUnexpectedEOF {
+			out.err = err
+		}
+		rr = strings.NewReader("")
+	}
+	out.scanner = bufio.NewScanner(rr)
+
+	// lines are split like Reader splits them, so files without line endings are read one record at a time
+	out.scanner.Buffer(nil, maxLineSize)
+	out.scanner.Split(reader.scanLine)
+	return out
+}
+
+// SetValidation sets the ValidateOpts records are validated with.
+func (i *EventIterator) SetValidation(opts *ValidateOpts) {
+	if i.reader != nil {
+		i.reader.SetValidation(opts)
+	}
+}
+
+// Next returns the next record of the file. A nil Event and error are returned once the input is exhausted.
+// Records which fail to parse are skipped after their error is returned, so Next can be called again.
+func (i *EventIterator) Next() (*Event, error) {
+	if err := i.err; err != nil {
+		i.err = nil
+		return nil, err
+	}
+	for {
+		line, ok := i.nextRecord()
+		if !ok {
+			if err := i.scanner.Err(); err != nil {
+				return nil, err
+			}
+			pending := i.pending
+			i.pending = nil
+			return pending, nil
+		}
+
+		// an entry is finished by the first record which isn't one of its addenda
+		if i.pending != nil && !strings.HasPrefix(line, entryAddendaPos) {
+			i.cachedLine = line
+			pending := i.pending
+			i.pending = nil
+			return pending, nil
+		}
+
+		event, err := i.parse(line)
+		if err != nil {
+			return nil, err
+		}
+		if event != nil {
+			return event, nil
+		}
+	}
+}
+
+// nextRecord returns the next non-blank record. Like Reader, lines longer than a record are split
+// every 94 characters and each part counts as a line.
+func (i *EventIterator) nextRecord() (string, bool) {
+	if i.cachedLine != "" {
+		line := i.cachedLine
+		i.cachedLine = ""
+		return line, true
+	}
+	for {
+		if !i.scanner.Scan() {
+			return "", false
+		}
+		line := i.scanner.Text()
+		i.reader.lineNum++
+		i.reader.offset = i.reader.lineOffset
+		if !allSpaces(line) {
+			return line, true
+		}
+	}
+}
+
+// parse reads line as a record. Addenda records are added to the pending entry and return no Event.
+func (i *EventIterator) parse(line string) (*Event, error) {
+	r := i.reader
+	if len(line) < RecordLength {
+		padded, err := rightPadShortLine(line)
+		if err != nil {
+			return nil, r.parseError(err)
+		}
+		line = padded
+	}
+	r.line = line
+	opts := r.File.validateOpts
+
+	switch line[:1] {
+	case fileHeaderPos:
+		r.recordName = "FileHeader"
+		fh := NewFileHeader()
+		fh.SetValidation(opts)
+		fh.Parse(line)
+		fh.source = r.currentSource()
+		if err := maybeValidate(&fh, opts); err != nil {
+			return nil, r.parseError(err)
+		}
+		i.batch, i.adv = "", false
+		return &Event{Type: FileHeaderEvent, FileHeader: &fh}, nil
+
+	case batchHeaderPos:
+		r.recordName = "BatchHeader"
+		if line[50:53] == IAT || strings.TrimSpace(line[04:20]) == IATCOR {
+			bh := NewIATBatchHeader()
+			bh.Parse(line)
+			bh.source = r.currentSource()
+			if err := maybeValidate(bh, opts); err != nil {
+				return nil, r.parseError(err)
+			}
+			i.batch = IAT
+			return &Event{Type: IATBatchHeaderEvent, IATBatchHeader: bh}, nil
+		}
+		bh := NewBatchHeader()
+		bh.SetValidation(opts)
+		bh.Parse(line)
+		bh.source = r.currentSource()
+		if err := maybeValidate(bh, opts); err != nil {
+			return nil, r.parseError(err)
+		}
+		i.batch = bh.StandardEntryClassCode
+		i.adv = i.adv || i.batch == ADV
+		return &Event{Type: BatchHeaderEvent, BatchHeader: bh}, nil
+
+	case entryDetailPos:
+		r.recordName = "EntryDetail"
+		switch i.batch {
+		case "":
+			return nil, r.parseError(ErrFileEntryOutsideBatch)
+		case IAT:
+			ed := NewIATEntryDetail()
+			ed.Parse(line)
+			ed.source = r.currentSource()
+			if err := maybeValidate(ed, opts); err != nil {
+				return nil, r.parseError(err)
+			}
+			i.pending = &Event{Type: IATEntryEvent, IATEntry: ed}
+		case ADV:
+			ed := NewADVEntryDetail()
+			ed.Parse(line)
+			ed.source = r.currentSource()
+			if err := maybeValidate(ed, opts); err != nil {
+				return nil, r.parseError(err)
+			}
+			i.pending = &Event{Type: ADVEntryEvent, ADVEntry: ed}
+		default:
+			ed := i.newEntryDetail()
+			ed.SetValidation(opts)
+			ed.Parse(line)
+			ed.source = r.currentSource()
+			if err := maybeValidate(ed, opts); err != nil {
+				return nil, r.parseError(err)
+			}
+			i.pending = i.entryEvent(ed)
+		}
+		return nil, nil
+
+	case entryAddendaPos:
+		r.recordName = "Addenda"
+		return nil, i.parseAddenda()
+
+	case batchControlPos:
+		r.recordName = "BatchControl"
+		if i.batch == "" {
+			return nil, r.parseError(ErrFileBatchControlOutsideBatch)
+		}
+		isADV := i.batch == ADV
+		i.batch = ""
+		if isADV {
+			bc := NewADVBatchControl()
+			bc.Parse(line)
+			bc.source = r.currentSource()
+			if err := maybeValidate(bc, opts); err != nil {
+				return nil, r.parseError(err)
+			}
+			return &Event{Type: ADVBatchControlEvent, ADVBatchControl: bc}, nil
+		}
+		bc := NewBatchControl()
+		bc.SetValidation(opts)
+		bc.Parse(line)
+		bc.source = r.currentSource()
+		if err := maybeValidate(bc, opts); err != nil {
+			return nil, r.parseError(err)
+		}
+		return &Event{Type: BatchControlEvent, BatchControl: bc}, nil
+
+	case fileControlPos:
+		if line[:2] == "99" {
+			// final blocking padding
+			return nil, nil
+		}
+		r.recordName = "FileControl"
+		if i.adv {
+			fc := NewADVFileControl()
+			fc.Parse(line)
+			fc.source = r.currentSource()
+			if err := maybeValidate(&fc, opts); err != nil {
+				return nil, r.parseError(err)
+			}
+			return &Event{Type: ADVFileControlEvent, ADVFileControl: &fc}, nil
+		}
+		fc := NewFileControl()
+		fc.Parse(line)
+		fc.source = r.currentSource()
+		if err := maybeValidate(&fc, opts); err != nil {
+			return nil, r.parseError(err)
+		}
+		return &Event{Type: FileControlEvent, FileControl: &fc}, nil
+	}
+	return nil, r.parseError(NewErrUnknownRecordType(line[:1]))
+}
+
+// newEntryDetail returns the EntryDetail to parse the next entry into.
+func (i *EventIterator) newEntryDetail() *EntryDetail {
+	if !i.reuseEntries {
+		return NewEntryDetail()
+	}
+	i.entry = EntryDetail{Category: CategoryForward}
+	return &i.entry
+}
+
+// entryEvent returns the Event for ed.
+func (i *EventIterator) entryEvent(ed *EntryDetail) *Event {
+	if !i.reuseEntries {
+		return &Event{Type: EntryEvent, Entry: ed}
+	}
+	i.event = Event{Type: EntryEvent, Entry: ed}
+	return &i.event
+}
+
+// parseAddenda adds the addenda record being parsed to the pending entry.
+func (i *EventIterator) parseAddenda() error {
+	r := i.reader
+	if i.pending == nil {
+		if i.batch == "" {
+			return r.parseError(ErrFileAddendaOutsideBatch)
+		}
+		return r.parseError(ErrFileAddendaOutsideEntry)
+	}
+
+	switch i.pending.Type {
+	case IATEntryEvent:
+		if i.pending.IATEntry.AddendaRecordIndicator != 1 {
+			return r.parseError(fieldError("AddendaRecordIndicator", ErrIATBatchAddendaIndicator))
+		}
+		if err := r.switchIATAddenda(i.pending.IATEntry); err != nil {
+			return r.parseError(err)
+		}
+	case ADVEntryEvent:
+		if i.pending.ADVEntry.AddendaRecordIndicator != 1 {
+			return r.parseError(fieldError("AddendaRecordIndicator", ErrBatchAddendaIndicator))
+		}
+		return r.parseADVEntryAddenda(i.pending.ADVEntry)
+	default:
+		if i.pending.Entry.AddendaRecordIndicator != 1 {
+			return r.parseError(fieldError("AddendaRecordIndicator", ErrBatchAddendaIndicator))
+		}
+		return r.parseEntryAddenda(i.pending.Entry)
+	}
+	return nil
+}
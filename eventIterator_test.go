@@ -0,0 +1,179 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readEvents(t *testing.T, iter *EventIterator) []*Event {
+	t.Helper()
+
+	var events []*Event
+	for {
+		event, err := iter.Next()
+		require.NoError(t, err)
+		if event == nil {
+			return events
+		}
+		events = append(events, event)
+	}
+}
+
+func eventTypes(events []*Event) []EventType {
+	var out []EventType
+	for _, event := range events {
+		out = append(out, event.Type)
+	}
+	return out
+}
+
+func TestEventIterator(t *testing.T) {
+	fd, err := os.Open(filepath.Join("test", "testdata", "return-WEB.ach"))
+	require.NoError(t, err)
+	defer fd.Close()
+
+	events := readEvents(t, NewEventIterator(fd))
+	require.Equal(t, []EventType{
+		FileHeaderEvent,
+		BatchHeaderEvent, EntryEvent, BatchControlEvent,
+		BatchHeaderEvent, EntryEvent, BatchControlEvent,
+		FileControlEvent,
+	}, eventTypes(events))
+
+	entry := events[2].Entry
+	require.NotNil(t, entry.Addenda99)
+	require.Equal(t, CategoryReturn, entry.Category)
+	require.Equal(t, 3, entry.SourceLine())
+
+	require.Equal(t, 2, events[7].FileControl.BatchCount)
+	require.Equal(t, "FileControl", events[7].Type.String())
+}
+
+func TestEventIterator__IAT(t *testing.T) {
+	fd, err := os.Open(filepath.Join("test", "testdata", "20180716-IAT-A17-A18.ach"))
+	require.NoError(t, err)
+	defer fd.Close()
+
+	events := readEvents(t, NewEventIterator(fd))
+	require.Equal(t, []EventType{
+		FileHeaderEvent,
+		IATBatchHeaderEvent, IATEntryEvent, BatchControlEvent,
+		IATBatchHeaderEvent, IATEntryEvent, BatchControlEvent,
+		FileControlEvent,
+	}, eventTypes(events))
+
+	entry := events[2].IATEntry
+	require.NotNil(t, entry.Addenda10)
+	require.NotNil(t, entry.Addenda16)
+	require.Len(t, entry.Addenda17, 2)
+	require.Len(t, entry.Addenda18, 5)
+	require.Equal(t, 18, events[3].BatchControl.SourceLine())
+}
+
+func TestEventIterator__ADV(t *testing.T) {
+	fd, err := os.Open(filepath.Join("examples", "testdata", "adv-read.ach"))
+	require.NoError(t, err)
+	defer fd.Close()
+
+	events := readEvents(t, NewEventIterator(fd))
+	require.Equal(t, []EventType{
+		FileHeaderEvent, BatchHeaderEvent, ADVEntryEvent, ADVEntryEvent, ADVBatchControlEvent, ADVFileControlEvent,
+	}, eventTypes(events))
+	require.Equal(t, ADV, events[1].BatchHeader.StandardEntryClassCode)
+	require.Equal(t, 2, events[4].ADVBatchControl.EntryAddendaCount)
+}
+
+func TestEventIterator__FixedWidth(t *testing.T) {
+	fd, err := os.Open(filepath.Join("test", "testdata", "ppd-debit-fixedLength.ach"))
+	require.NoError(t, err)
+	defer fd.Close()
+
+	events := readEvents(t, NewEventIterator(fd))
+	require.Equal(t, []EventType{
+		FileHeaderEvent, BatchHeaderEvent, EntryEvent, BatchControlEvent, FileControlEvent,
+	}, eventTypes(events))
+	require.Equal(t, 3, events[2].Entry.SourceLine())
+	require.Equal(t, int64(2*RecordLength), events[2].Entry.SourceOffset())
+}
+
+// fixedWidthFile returns a file of n entries written without line endings.
+func fixedWidthFile(t *testing.T, n int) []byte {
+	t.Helper()
+
+	b := NewBuilder("121042882", "231380104").
+		CreatedAt(time.Date(2023, time.December, 21, 10, 30, 0, 0, time.UTC)).
+		Batch(PPD, Company{
+			Name:               "Acme Corp",
+			Identification:     "121042882",
+			EntryDescription:   "PAYROLL",
+			EffectiveEntryDate: time.Date(2023, time.December, 26, 0, 0, 0, 0, time.UTC),
+		})
+	for i := 0; i < n; i++ {
+		b.Credit("231380104", "12345678", 100+i, "Jane Doe")
+	}
+	file, err := b.Build()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.LineEnding = ""
+	require.NoError(t, w.Write(file))
+	return buf.Bytes()
+}
+
+func TestEventIterator__FixedWidthLarge(t *testing.T) {
+	bs := fixedWidthFile(t, 1000)
+	require.Greater(t, len(bs), 64*1024)
+
+	events := readEvents(t, NewEventIterator(bytes.NewReader(bs)))
+	require.Len(t, events, 1004)
+	require.Equal(t, EntryEvent, events[1001].Type)
+	require.Equal(t, 1002, events[1001].Entry.SourceLine())
+	require.Equal(t, int64(1001*RecordLength), events[1001].Entry.SourceOffset())
+}
+
+func TestEventIterator__Errors(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	lines := strings.Split(string(bs), "\n")
+
+	// an entry before the batch header
+	input := strings.Join(append([]string{lines[0], lines[2]}, lines[1:]...), "\n")
+	iter := NewEventIterator(strings.NewReader(input))
+
+	event, err := iter.Next()
+	require.NoError(t, err)
+	require.Equal(t, FileHeaderEvent, event.Type)
+
+	_, err = iter.Next()
+	require.ErrorIs(t, err, ErrFileEntryOutsideBatch)
+
+	// the rest of the file is still read
+	events := readEvents(t, iter)
+	require.Equal(t, []EventType{
+		BatchHeaderEvent, EntryEvent, BatchControlEvent, FileControlEvent,
+	}, eventTypes(events))
+}
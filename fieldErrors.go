@@ -110,12 +110,55 @@ var (
 	ErrIATBatchAddendaIndicator = errors.New("is invalid for addenda record(s) found")
 )
 
+// fieldErrorCodes are the stable codes of the field errors above, see CodeOf
+var fieldErrorCodes = map[error]ErrorCode{
+	ErrNonAlphanumeric:                     "ACH-FIELD-NON-ALPHANUMERIC",
+	ErrUpperAlpha:                          "ACH-FIELD-UPPERCASE",
+	ErrFieldInclusion:                      "ACH-FIELD-MANDATORY-DEFAULT",
+	ErrConstructor:                         "ACH-FIELD-CONSTRUCTOR",
+	ErrFieldRequired:                       "ACH-FIELD-REQUIRED",
+	ErrServiceClass:                        "ACH-FIELD-SERVICE-CLASS-CODE",
+	ErrSECCode:                             "ACH-FIELD-SEC-CODE",
+	ErrOrigStatusCode:                      "ACH-FIELD-ORIGINATOR-STATUS-CODE",
+	ErrAddendaTypeCode:                     "ACH-FIELD-ADDENDA-TYPE-CODE",
+	ErrTransactionCode:                     "ACH-FIELD-TRANSACTION-CODE",
+	ErrIdentificationNumber:                "ACH-FIELD-IDENTIFICATION-NUMBER",
+	ErrCardTransactionType:                 "ACH-FIELD-CARD-TRANSACTION-TYPE",
+	ErrValidMonth:                          "ACH-FIELD-DATE-MONTH",
+	ErrValidDay:                            "ACH-FIELD-DATE-DAY",
+	ErrValidYear:                           "ACH-FIELD-DATE-YEAR",
+	ErrValidState:                          "ACH-FIELD-US-STATE",
+	ErrValidISO3166:                        "ACH-FIELD-COUNTRY-CODE",
+	ErrValidISO4217:                        "ACH-FIELD-CURRENCY-CODE",
+	ErrNegativeAmount:                      "ACH-FIELD-AMOUNT-NEGATIVE",
+	ErrAddenda98ChangeCode:                 "ACH-FIELD-ADDENDA98-CHANGE-CODE",
+	ErrAddenda98RefusedChangeCode:          "ACH-FIELD-ADDENDA98-REFUSED-CHANGE-CODE",
+	ErrAddenda98RefusedTraceSequenceNumber: "ACH-FIELD-ADDENDA98-REFUSED-TRACE-SEQUENCE",
+	ErrAddenda98CorrectedData:              "ACH-FIELD-ADDENDA98-CORRECTED-DATA",
+	ErrAddenda99ReturnCode:                 "ACH-FIELD-ADDENDA99-RETURN-CODE",
+	ErrAddenda99DishonoredReturnCode:       "ACH-FIELD-ADDENDA99-DISHONORED-RETURN-CODE",
+	ErrAddenda99ContestedReturnCode:        "ACH-FIELD-ADDENDA99-CONTESTED-RETURN-CODE",
+	ErrBatchCORAddenda:                     "ACH-BATCH-COR-ADDENDA",
+	ErrRecordSize:                          "ACH-FIELD-RECORD-SIZE",
+	ErrBlockingFactor:                      "ACH-FIELD-BLOCKING-FACTOR",
+	ErrFormatCode:                          "ACH-FIELD-FORMAT-CODE",
+	ErrForeignExchangeIndicator:            "ACH-FIELD-FOREIGN-EXCHANGE-INDICATOR",
+	ErrForeignExchangeReferenceIndicator:   "ACH-FIELD-FOREIGN-EXCHANGE-REFERENCE-INDICATOR",
+	ErrTransactionTypeCode:                 "ACH-FIELD-TRANSACTION-TYPE-CODE",
+	ErrIDNumberQualifier:                   "ACH-FIELD-ID-NUMBER-QUALIFIER",
+	ErrIATBatchAddendaIndicator:            "ACH-BATCH-IAT-ADDENDA-INDICATOR",
+}
+
 // FieldError is returned for errors at a field level in a record
 type FieldError struct {
 	FieldName string      // field name where error happened
 	Value     interface{} // value that cause error
 	Err       error       // context of the error.
 	Msg       string      // deprecated
+
+	// Line is the line the record was read from when the error was found by a File or batch,
+	// see SourceLine. It is 0 when the record wasn't read by a Reader.
+	Line int
 }
 
 // Error message is constructed
@@ -131,6 +174,11 @@ func (e *FieldError) Unwrap() error {
 	return e.Err
 }
 
+// Code returns the ErrorCode of the error FieldError wraps
+func (e *FieldError) Code() ErrorCode {
+	return CodeOf(e.Err)
+}
+
 func fieldError(field string, err error, values ...interface{}) error {
 	if err == nil {
 		return nil
@@ -149,6 +197,23 @@ func fieldError(field string, err error, values ...interface{}) error {
 	return &fe
 }
 
+// fieldErrors collects the first problem with each field of a record. The records fill it in their
+// validateAll methods, which run the checks of Validate without stopping at the first problem.
+type fieldErrors []error
+
+// add appends a FieldError for field unless err is nil or field already has a problem.
+func (errs *fieldErrors) add(field string, err error, values ...interface{}) {
+	if err == nil {
+		return
+	}
+	for _, e := range *errs {
+		if errorFieldName(e) == field {
+			return
+		}
+	}
+	*errs = append(*errs, fieldError(field, err, values...))
+}
+
 // ErrValidCheckDigit is the error given when the observed check digit does not match the calculated one
 type ErrValidCheckDigit struct {
 	Message              string
@@ -167,6 +232,11 @@ func (e ErrValidCheckDigit) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-FIELD-ROUTING-CHECKDIGIT
+func (e ErrValidCheckDigit) Code() ErrorCode {
+	return "ACH-FIELD-ROUTING-CHECKDIGIT"
+}
+
 // ErrValidFieldLength is the error given when the field does not have the correct length
 type ErrValidFieldLength struct {
 	Message        string
@@ -185,6 +255,11 @@ func (e ErrValidFieldLength) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-FIELD-LENGTH
+func (e ErrValidFieldLength) Code() ErrorCode {
+	return "ACH-FIELD-LENGTH"
+}
+
 // ErrRecordType is the error given when the field does not have the right record type
 type ErrRecordType struct {
 	Message      string
@@ -202,3 +277,8 @@ func NewErrRecordType(expectedType int) ErrRecordType {
 func (e ErrRecordType) Error() string {
 	return e.Message
 }
+
+// Code returns ACH-FIELD-RECORD-TYPE
+func (e ErrRecordType) Code() ErrorCode {
+	return "ACH-FIELD-RECORD-TYPE"
+}
@@ -69,6 +69,18 @@ type File struct {
 	// ReturnEntries is a slice of references to file.Batches that contain return entries
 	ReturnEntries []Batcher `json:"ReturnEntries"`
 
+	// LeadingLines are lines before the FileHeader, such as transmission headers, which were kept because
+	// ValidateOpts.PreserveUnknownRecords was set. Writer writes them before the FileHeader.
+	LeadingLines []string `json:"leadingLines,omitempty"`
+
+	// UnknownRecords are lines between the FileHeader and FileControl which aren't Nacha records.
+	// They are kept when ValidateOpts.PreserveUnknownRecords is set and written back in the same positions.
+	UnknownRecords []UnknownRecord `json:"unknownRecords,omitempty"`
+
+	// TrailingLines are lines after the FileControl and block padding which were kept because
+	// ValidateOpts.PreserveUnknownRecords was set. Writer writes them after the padding.
+	TrailingLines []string `json:"trailingLines,omitempty"`
+
 	validateOpts *ValidateOpts
 }
 
@@ -81,7 +93,10 @@ func NewFile() *File {
 }
 
 type file struct {
-	ID string `json:"id"`
+	ID             string          `json:"id"`
+	LeadingLines   []string        `json:"leadingLines"`
+	UnknownRecords []UnknownRecord `json:"unknownRecords"`
+	TrailingLines  []string        `json:"trailingLines"`
 }
 
 type fileHeader struct {
@@ -152,6 +167,9 @@ func FileFromJSONWith(bs []byte, opts *ValidateOpts) (*File, error) {
 		return nil, fmt.Errorf("problem reading File: %v", err)
 	}
 	file.ID = f.ID
+	file.LeadingLines = f.LeadingLines
+	file.UnknownRecords = f.UnknownRecords
+	file.TrailingLines = f.TrailingLines
 	if opts != nil {
 		file.SetValidation(opts)
 	}
@@ -712,6 +730,28 @@ type ValidateOpts struct {
 
 	// AllowInvalidAmounts will skip verifying the Amount is valid for the TransactionCode and entry type.
 	AllowInvalidAmounts bool `json:"allowInvalidAmounts"`
+
+	// SameDay validates a File against the Same Day ACH rules. IAT entries and entries over the
+	// SameDayEntryLimit are rejected, and each EffectiveEntryDate must be the banking day the file is created.
+	SameDay bool `json:"sameDay"`
+
+	// PreserveUnknownRecords keeps lines which aren't Nacha records, such as transmission headers, on the File
+	// instead of rejecting them with ErrUnknownRecordType. Writer writes them back in the same positions.
+	PreserveUnknownRecords bool `json:"preserveUnknownRecords"`
+
+	// Severities changes how serious the problems found by a rule are. Rules are named after the field
+	// they check, optionally prefixed with the record type, e.g. "IndividualName" or "Batch.TraceNumber".
+	// Problems downgraded to SeverityWarning are reported by ValidateAllWith but don't fail validation.
+	// A Reader keeps records with only warnings, see Reader.Warnings.
+	//
+	// Unknown severities are treated as SeverityError.
+	Severities map[string]Severity `json:"severities,omitempty"`
+}
+
+// Merge combines v and other, keeping options enabled in either. Severities and CheckTransactionCode
+// from other replace those of v. Neither ValidateOpts is modified.
+func (v *ValidateOpts) Merge(other *ValidateOpts) *ValidateOpts {
+	return v.merge(other)
 }
 
 // merge will combine two ValidateOpts structs and keep any non-zero field values.
@@ -741,6 +781,18 @@ func (v *ValidateOpts) merge(other *ValidateOpts) *ValidateOpts {
 		UnequalAddendaCounts:             v.UnequalAddendaCounts || other.UnequalAddendaCounts,
 		PreserveSpaces:                   v.PreserveSpaces || other.PreserveSpaces,
 		AllowInvalidAmounts:              v.AllowInvalidAmounts || other.AllowInvalidAmounts,
+		SameDay:                          v.SameDay || other.SameDay,
+		PreserveUnknownRecords:           v.PreserveUnknownRecords || other.PreserveUnknownRecords,
+	}
+
+	if len(v.Severities) > 0 || len(other.Severities) > 0 {
+		out.Severities = make(map[string]Severity)
+		for rule, severity := range v.Severities {
+			out.Severities[rule] = severity
+		}
+		for rule, severity := range other.Severities {
+			out.Severities[rule] = severity
+		}
 	}
 
 	if v.CheckTransactionCode != nil {
@@ -760,7 +812,10 @@ func (v *ValidateOpts) merge(other *ValidateOpts) *ValidateOpts {
 // opts passed in will override ValidateOpts set by SetValidation.
 // The underlying Batches and Entries on this File will use their own ValidateOpts if they are set.
 //
-// The first error encountered is returned.
+// The first error encountered is returned. Use ValidateAllWith for every error in the File.
+// When opts has Severities the first problem with SeverityError from ValidateAllWith is returned.
+//
+// Errors about records read by a Reader have the line of the record, see FieldError.Line and BatchError.Line.
 func (f *File) ValidateWith(opts *ValidateOpts) error {
 	if opts == nil {
 		opts = &ValidateOpts{}
@@ -769,10 +824,18 @@ func (f *File) ValidateWith(opts *ValidateOpts) error {
 	if opts.SkipAll {
 		return nil
 	}
+	if len(opts.Severities) > 0 {
+		for _, finding := range f.ValidateAllWith(opts).Findings {
+			if finding.Severity == SeverityError {
+				return finding.Err
+			}
+		}
+		return nil
+	}
 
 	if !opts.AllowMissingFileHeader {
 		if err := f.Header.ValidateWith(opts); err != nil {
-			return err
+			return atLine(err, f.Header.SourceLine())
 		}
 	}
 
@@ -790,7 +853,7 @@ func (f *File) ValidateWith(opts *ValidateOpts) error {
 
 		if !opts.AllowMissingFileControl {
 			if err := f.Control.Validate(); err != nil {
-				return err
+				return atLine(err, f.Control.SourceLine())
 			}
 		}
 		if err := f.isEntryAddendaCount(false); err != nil {
@@ -804,7 +867,15 @@ func (f *File) ValidateWith(opts *ValidateOpts) error {
 				return err
 			}
 		}
-		return f.isEntryHash(false)
+		if opts.SameDay {
+			if err := f.validateSameDay(); err != nil {
+				return err
+			}
+		}
+		if err := f.isEntryHash(false); err != nil {
+			return err
+		}
+		return f.validateRules()
 	}
 
 	// File contains ADV batches BatchADV
@@ -815,7 +886,7 @@ func (f *File) ValidateWith(opts *ValidateOpts) error {
 	}
 	if !opts.AllowMissingFileControl {
 		if err := f.ADVControl.Validate(); err != nil {
-			return err
+			return atLine(err, f.ADVControl.SourceLine())
 		}
 	}
 	if err := f.isEntryAddendaCount(true); err != nil {
@@ -824,7 +895,10 @@ func (f *File) ValidateWith(opts *ValidateOpts) error {
 	if err := f.isFileAmount(true); err != nil {
 		return err
 	}
-	return f.isEntryHash(true)
+	if err := f.isEntryHash(true); err != nil {
+		return err
+	}
+	return f.validateRules()
 }
 
 // isEntryAddendaCount is prepared by hashing the RDFI's 8-digit Routing Number in each entry.
@@ -17,10 +17,6 @@
 
 package ach
 
-import (
-	"unicode/utf8"
-)
-
 // FileControl record contains entry counts, dollar totals and hash
 // totals accumulated from each batch control record in the file.
 type FileControl struct {
@@ -44,62 +40,34 @@ type FileControl struct {
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 // Parse takes the input record string and parses the FileControl values
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (fc *FileControl) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each field cutoff save the buffer and reset
-		switch idx {
-		case 1:
-			// 1-1 Always "9"
-			reset()
-		case 7:
-			// 2-7 The total number of Batch Header Record in the file. For example: "000003
-			fc.BatchCount = fc.parseNumField(reset())
-		case 13:
-			// 8-13 e total number of blocks on the file, including the File Header and File Control records. One block is 10 lines, so it's effectively the number of lines in the file divided by 10.
-			fc.BlockCount = fc.parseNumField(reset())
-		case 21:
-			// 14-21 Total number of Entry Detail Record in the file
-			fc.EntryAddendaCount = fc.parseNumField(reset())
-		case 31:
-			// 22-31 Total of all positions 4-11 on each Entry Detail Record in the file. This is essentially the sum of all the RDFI routing numbers in the file.
-			// If the sum exceeds 10 digits (because you have lots of Entry Detail Records), lop off the most significant digits of the sum until there are only 10
-			fc.EntryHash = fc.parseNumField(reset())
-		case 43:
-			// 32-43 Number of cents of debit entries within the file
-			fc.TotalDebitEntryDollarAmountInFile = fc.parseNumField(reset())
-		case 55:
-			// 44-55 Number of cents of credit entries within the file
-			fc.TotalCreditEntryDollarAmountInFile = fc.parseNumField(reset())
-		case 94:
-			// 56-94 Reserved Always blank (just fill with spaces)
-			reset()
-		}
-	}
+	// 1-1 Always "9"
+	// 2-7 The total number of Batch Header Record in the file. For example: "000003
+	fc.BatchCount = fc.parseNumField(fields.field(1, 7))
+	// 8-13 e total number of blocks on the file, including the File Header and File Control records. One block is 10 lines, so it's effectively the number of lines in the file divided by 10.
+	fc.BlockCount = fc.parseNumField(fields.field(7, 13))
+	// 14-21 Total number of Entry Detail Record in the file
+	fc.EntryAddendaCount = fc.parseNumField(fields.field(13, 21))
+	// 22-31 Total of all positions 4-11 on each Entry Detail Record in the file. This is essentially the sum of all the RDFI routing numbers in the file.
+	// If the sum exceeds 10 digits (because you have lots of Entry Detail Records), lop off the most significant digits of the sum until there are only 10
+	fc.EntryHash = fc.parseNumField(fields.field(21, 31))
+	// 32-43 Number of cents of debit entries within the file
+	fc.TotalDebitEntryDollarAmountInFile = fc.parseNumField(fields.field(31, 43))
+	// 44-55 Number of cents of credit entries within the file
+	fc.TotalCreditEntryDollarAmountInFile = fc.parseNumField(fields.field(43, 55))
+	// 56-94 Reserved Always blank (just fill with spaces)
 }
 
 // NewFileControl returns a new FileControl with default values for none exported fields
@@ -109,19 +77,20 @@ func NewFileControl() FileControl {
 
 // String writes the FileControl struct to a 94 character string.
 func (fc *FileControl) String() string {
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(fileControlPos)
-	buf.WriteString(fc.BatchCountField())
-	buf.WriteString(fc.BlockCountField())
-	buf.WriteString(fc.EntryAddendaCountField())
-	buf.WriteString(fc.EntryHashField())
-	buf.WriteString(fc.TotalDebitEntryDollarAmountInFileField())
-	buf.WriteString(fc.TotalCreditEntryDollarAmountInFileField())
-	buf.WriteString("                                       ")
-
-	return buf.String()
+	return string(fc.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the FileControl record to dst and returns the extended buffer.
+func (fc *FileControl) AppendTo(dst []byte) []byte {
+	dst = append(dst, fileControlPos...)
+	dst = fc.appendNumericField(dst, fc.BatchCount, 6)
+	dst = fc.appendNumericField(dst, fc.BlockCount, 6)
+	dst = fc.appendNumericField(dst, fc.EntryAddendaCount, 8)
+	dst = fc.appendNumericField(dst, fc.EntryHash, 10)
+	dst = fc.appendNumericField(dst, fc.TotalDebitEntryDollarAmountInFile, 12)
+	dst = fc.appendNumericField(dst, fc.TotalCreditEntryDollarAmountInFile, 12)
+	dst = append(dst, "                                       "...)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -153,6 +122,27 @@ func (fc *FileControl) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (fc *FileControl) validateAll() []error {
+	var errs fieldErrors
+	if fc.BlockCount == 0 {
+		errs.add("BlockCount", ErrConstructor, fc.BlockCountField())
+	}
+	if fc.TotalCreditEntryDollarAmountInFile != 0 || fc.TotalDebitEntryDollarAmountInFile != 0 {
+		if fc.BatchCount == 0 {
+			errs.add("BatchCount", ErrConstructor, fc.BatchCountField())
+		}
+		if fc.EntryAddendaCount == 0 {
+			errs.add("EntryAddendaCount", ErrConstructor, fc.EntryAddendaCountField())
+		}
+		if fc.EntryHash == 0 {
+			errs.add("EntryHash", ErrConstructor, fc.EntryAddendaCountField())
+		}
+	}
+	return errs
+}
+
 // BatchCountField gets a string of the batch count zero padded
 func (fc *FileControl) BatchCountField() string {
 	return fc.numericField(fc.BatchCount, 6)
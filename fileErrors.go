@@ -39,16 +39,96 @@ var (
 	ErrFileBatchControlOutsideBatch = errors.New("batch control outside of batch")
 	// ErrFileConsecutiveBatchHeaders is the error given when multiple batch header records occur in sequence
 	ErrFileConsecutiveBatchHeaders = errors.New("consecutive Batch Headers in file")
+	// ErrFileRecordSkipped is the error given when Reader.ReadLenient skips a record after an invalid batch header or entry
+	ErrFileRecordSkipped = errors.New("record skipped after an invalid record")
+	// ErrFileStreamOrder is the error given when the streaming methods of Writer are called out of order
+	ErrFileStreamOrder = errors.New("streaming writer methods called out of order")
+	// ErrFileStreamSEC is the error given when an ADV or IAT batch is started with Writer.BeginBatch
+	ErrFileStreamSEC = errors.New("ADV and IAT batches can't be streamed")
 	// ErrFileADVOnly is the error given if an ADV only file has a non-ADV batch
 	ErrFileADVOnly = errors.New("file can only have ADV Batches")
 	// ErrFileIATSEC is the error given if an IAT batch uses the normal NewBatch
 	ErrFileIATSEC = errors.New("IAT Standard Entry Class Code should use iatBatch")
 	// ErrFileNoBatches is the error given if a file has no batches
 	ErrFileNoBatches = errors.New("must have []*Batches or []*IATBatches to be built")
+	// ErrFileNoReturnEntries is the error given when no entries are requested to be returned
+	ErrFileNoReturnEntries = errors.New("no entries to return")
+	// ErrFileReturnEntryNotFound is the error given when a returned trace number is not found in the file
+	ErrFileReturnEntryNotFound = errors.New("return entry not found in file")
+	// ErrFileReturnEntryDuplicate is the error given when a trace number is returned more than once
+	ErrFileReturnEntryDuplicate = errors.New("return entry is duplicated")
+	// ErrFileReturnEntryCategory is the error given when a returned entry is not a forward entry
+	ErrFileReturnEntryCategory = errors.New("only forward entries can be returned")
+	// ErrFileDishonoredEntryCategory is the error given when a dishonored entry is not a return entry
+	ErrFileDishonoredEntryCategory = errors.New("only return entries can be dishonored")
+	// ErrFileContestedEntryCategory is the error given when a contested entry is not a dishonored return entry
+	ErrFileContestedEntryCategory = errors.New("only dishonored return entries can be contested")
+	// ErrFileNoChangeEntries is the error given when no entries are requested to be corrected
+	ErrFileNoChangeEntries = errors.New("no entries to correct")
+	// ErrFileChangeEntryNotFound is the error given when a corrected trace number is not found in the file
+	ErrFileChangeEntryNotFound = errors.New("change entry not found in file")
+	// ErrFileChangeEntryDuplicate is the error given when a trace number is corrected more than once
+	ErrFileChangeEntryDuplicate = errors.New("change entry is duplicated")
+	// ErrFileChangeEntryCategory is the error given when a corrected entry is not a forward entry
+	ErrFileChangeEntryCategory = errors.New("only forward entries can be corrected")
+	// ErrFileNoReversalEntries is the error given when a reversal selects no entries
+	ErrFileNoReversalEntries = errors.New("no entries selected for reversal")
+	// ErrFileReversalEntryNotFound is the error given when a selected trace number or batch is not in the file
+	ErrFileReversalEntryNotFound = errors.New("reversal entry not found in file")
+	// ErrFileReversalWindow is the error given when a reversal is outside of five banking days from the original effective entry date
+	ErrFileReversalWindow = errors.New("reversal must be within five banking days of the original effective entry date")
+	// ErrFileCreationDateStale is the error given when a file is transmitted too many banking days after it was created
+	ErrFileCreationDateStale = errors.New("file creation date is stale")
+	// ErrTraceNumberODFI is the error given when trace numbers are allocated for an invalid ODFI routing number
+	ErrTraceNumberODFI = errors.New("is an invalid ODFI routing number for trace numbers")
+	// ErrTraceNumbersExhausted is the error given when every trace number sequence for an ODFI has been allocated.
+	// The allocator's SetLast starts the sequence over once older trace numbers can be reused.
+	ErrTraceNumbersExhausted = errors.New("has no trace numbers left to allocate")
+	// ErrValidationProfileNotFound is the error given when a validation profile isn't registered
+	ErrValidationProfileNotFound = errors.New("validation profile not found")
+	// ErrValidationProfileCycle is the error given when validation profiles extend each other in a loop
+	ErrValidationProfileCycle = errors.New("validation profile extends itself")
 
 	ErrInvalidJSON = errors.New("invalid JSON")
 )
 
+// fileErrorCodes are the stable codes of the file errors above, see CodeOf
+var fileErrorCodes = map[error]ErrorCode{
+	ErrFileTooLong:                  "ACH-FILE-TOO-LONG",
+	ErrFileHeader:                   "ACH-FILE-HEADER-COUNT",
+	ErrFileControl:                  "ACH-FILE-CONTROL-COUNT",
+	ErrFileEntryOutsideBatch:        "ACH-FILE-ENTRY-OUTSIDE-BATCH",
+	ErrFileAddendaOutsideBatch:      "ACH-FILE-ADDENDA-OUTSIDE-BATCH",
+	ErrFileAddendaOutsideEntry:      "ACH-FILE-ADDENDA-OUTSIDE-ENTRY",
+	ErrFileBatchControlOutsideBatch: "ACH-FILE-BATCH-CONTROL-OUTSIDE-BATCH",
+	ErrFileConsecutiveBatchHeaders:  "ACH-FILE-CONSECUTIVE-BATCH-HEADERS",
+	ErrFileRecordSkipped:            "ACH-FILE-RECORD-SKIPPED",
+	ErrFileStreamOrder:              "ACH-FILE-STREAM-ORDER",
+	ErrFileStreamSEC:                "ACH-FILE-STREAM-SEC",
+	ErrFileADVOnly:                  "ACH-FILE-ADV-ONLY",
+	ErrFileIATSEC:                   "ACH-FILE-IAT-SEC",
+	ErrFileNoBatches:                "ACH-FILE-NO-BATCHES",
+	ErrFileNoReturnEntries:          "ACH-FILE-RETURN-NO-ENTRIES",
+	ErrFileReturnEntryNotFound:      "ACH-FILE-RETURN-ENTRY-NOT-FOUND",
+	ErrFileReturnEntryDuplicate:     "ACH-FILE-RETURN-ENTRY-DUPLICATE",
+	ErrFileReturnEntryCategory:      "ACH-FILE-RETURN-ENTRY-CATEGORY",
+	ErrFileDishonoredEntryCategory:  "ACH-FILE-DISHONORED-ENTRY-CATEGORY",
+	ErrFileContestedEntryCategory:   "ACH-FILE-CONTESTED-ENTRY-CATEGORY",
+	ErrFileNoChangeEntries:          "ACH-FILE-CHANGE-NO-ENTRIES",
+	ErrFileChangeEntryNotFound:      "ACH-FILE-CHANGE-ENTRY-NOT-FOUND",
+	ErrFileChangeEntryDuplicate:     "ACH-FILE-CHANGE-ENTRY-DUPLICATE",
+	ErrFileChangeEntryCategory:      "ACH-FILE-CHANGE-ENTRY-CATEGORY",
+	ErrFileNoReversalEntries:        "ACH-FILE-REVERSAL-NO-ENTRIES",
+	ErrFileReversalEntryNotFound:    "ACH-FILE-REVERSAL-ENTRY-NOT-FOUND",
+	ErrFileReversalWindow:           "ACH-FILE-REVERSAL-WINDOW",
+	ErrFileCreationDateStale:        "ACH-FILE-CREATION-DATE-STALE",
+	ErrTraceNumberODFI:              "ACH-FILE-TRACE-NUMBER-ODFI",
+	ErrTraceNumbersExhausted:        "ACH-FILE-TRACE-NUMBERS-EXHAUSTED",
+	ErrValidationProfileNotFound:    "ACH-FILE-VALIDATION-PROFILE-NOT-FOUND",
+	ErrValidationProfileCycle:       "ACH-FILE-VALIDATION-PROFILE-CYCLE",
+	ErrInvalidJSON:                  "ACH-FILE-INVALID-JSON",
+}
+
 // RecordWrongLengthErr is the error given when a record is the wrong length
 type RecordWrongLengthErr struct {
 	Message string
@@ -67,6 +147,11 @@ func (e RecordWrongLengthErr) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-FILE-RECORD-LENGTH
+func (e RecordWrongLengthErr) Code() ErrorCode {
+	return "ACH-FILE-RECORD-LENGTH"
+}
+
 // ErrUnknownRecordType is the error given when a record does not have a known type
 type ErrUnknownRecordType struct {
 	Message string
@@ -85,6 +170,11 @@ func (e ErrUnknownRecordType) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-FILE-UNKNOWN-RECORD-TYPE
+func (e ErrUnknownRecordType) Code() ErrorCode {
+	return "ACH-FILE-UNKNOWN-RECORD-TYPE"
+}
+
 // ErrFileUnknownSEC is the error given when a record does not have a known type
 type ErrFileUnknownSEC struct {
 	Message string
@@ -103,6 +193,11 @@ func (e ErrFileUnknownSEC) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-FILE-UNKNOWN-SEC
+func (e ErrFileUnknownSEC) Code() ErrorCode {
+	return "ACH-FILE-UNKNOWN-SEC"
+}
+
 // ErrFileCalculatedControlEquality is the error given when the control record does not match the calculated value
 type ErrFileCalculatedControlEquality struct {
 	Message         string
@@ -125,6 +220,11 @@ func (e ErrFileCalculatedControlEquality) Error() string {
 	return e.Message
 }
 
+// Code returns ACH-FILE-CONTROL-OUT-OF-BALANCE
+func (e ErrFileCalculatedControlEquality) Code() ErrorCode {
+	return "ACH-FILE-CONTROL-OUT-OF-BALANCE"
+}
+
 // ErrFileBatchNumberAscending is the error given when the batch numbers in a file are not in ascending order
 type ErrFileBatchNumberAscending struct {
 	Message       string
@@ -144,3 +244,8 @@ func NewErrFileBatchNumberAscending(previous, current int) ErrFileBatchNumberAsc
 func (e ErrFileBatchNumberAscending) Error() string {
 	return e.Message
 }
+
+// Code returns ACH-FILE-BATCH-NUMBER-ASCENDING
+func (e ErrFileBatchNumberAscending) Code() ErrorCode {
+	return "ACH-FILE-BATCH-NUMBER-ASCENDING"
+}
@@ -98,6 +98,8 @@ type FileHeader struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// source is where the record was read from
+	source
 
 	validateOpts *ValidateOpts
 }
@@ -118,25 +120,25 @@ func NewFileHeader() FileHeader {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (fh *FileHeader) Parse(record string) {
-	if utf8.RuneCountInString(record) != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
-	runes := []rune(record)
 
 	// (character position 1-1) Always "1"
 	// (2-3) Always "01"
 	fh.priorityCode = "01"
 	// (4-13) A blank space followed by your ODFI's routing number. For example: " 121140399"
-	fh.ImmediateDestination = trimRoutingNumberLeadingZero(fh.parseStringField(string(runes[3:13])))
+	fh.ImmediateDestination = trimRoutingNumberLeadingZero(fh.parseStringField(fields.field(3, 13)))
 	// (14-23) A 10-digit number assigned to you by the ODFI once they approve you to originate ACH files through them
-	fh.ImmediateOrigin = trimRoutingNumberLeadingZero(fh.parseStringField(string(runes[13:23])))
+	fh.ImmediateOrigin = trimRoutingNumberLeadingZero(fh.parseStringField(fields.field(13, 23)))
 	// 24-29 Today's date in YYMMDD format
 	// must be after today's date.
-	fh.FileCreationDate = fh.validateSimpleDate(string(runes[23:29]))
+	fh.FileCreationDate = fh.validateSimpleDate(fields.field(23, 29))
 	// 30-33 The current time in HHmm format
-	fh.FileCreationTime = fh.validateSimpleTime(string(runes[29:33]))
+	fh.FileCreationTime = fh.validateSimpleTime(fields.field(29, 33))
 	// 35-37 Always "A"
-	fh.FileIDModifier = string(runes[33:34])
+	fh.FileIDModifier = fields.field(33, 34)
 	// 35-37 always "094"
 	fh.recordSize = "094"
 	// 38-39 always "10"
@@ -144,11 +146,11 @@ func (fh *FileHeader) Parse(record string) {
 	// 40 always "1"
 	fh.formatCode = "1"
 	// 41-63 The name of the ODFI. example "SILICON VALLEY BANK    "
-	fh.ImmediateDestinationName = fh.parseStringFieldWithOpts(string(runes[40:63]), fh.validateOpts)
+	fh.ImmediateDestinationName = fh.parseStringFieldWithOpts(fields.field(40, 63), fh.validateOpts)
 	// 64-86 ACH operator or sending point that is sending the file
-	fh.ImmediateOriginName = fh.parseStringFieldWithOpts(string(runes[63:86]), fh.validateOpts)
+	fh.ImmediateOriginName = fh.parseStringFieldWithOpts(fields.field(63, 86), fh.validateOpts)
 	// 87-94 Optional field that may be used to describe the ACH file for internal accounting purposes
-	fh.ReferenceCode = fh.parseStringFieldWithOpts(string(runes[86:94]), fh.validateOpts)
+	fh.ReferenceCode = fh.parseStringFieldWithOpts(fields.field(86, 94), fh.validateOpts)
 }
 
 func trimRoutingNumberLeadingZero(s string) string {
@@ -161,24 +163,25 @@ func trimRoutingNumberLeadingZero(s string) string {
 
 // String writes the FileHeader struct to a 94 character string.
 func (fh *FileHeader) String() string {
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(fileHeaderPos)
-	buf.WriteString(fh.priorityCode)
-	buf.WriteString(fh.ImmediateDestinationField())
-	buf.WriteString(fh.ImmediateOriginField())
-	buf.WriteString(fh.FileCreationDateField())
-	buf.WriteString(fh.FileCreationTimeField())
-	buf.WriteString(fh.FileIDModifier)
-	buf.WriteString(fh.recordSize)
-	buf.WriteString(fh.blockingFactor)
-	buf.WriteString(fh.formatCode)
-	buf.WriteString(fh.ImmediateDestinationNameField())
-	buf.WriteString(fh.ImmediateOriginNameField())
-	buf.WriteString(fh.ReferenceCodeField())
-
-	return buf.String()
+	return string(fh.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the FileHeader record to dst and returns the extended buffer.
+func (fh *FileHeader) AppendTo(dst []byte) []byte {
+	dst = append(dst, fileHeaderPos...)
+	dst = append(dst, fh.priorityCode...)
+	dst = append(dst, fh.ImmediateDestinationField()...)
+	dst = append(dst, fh.ImmediateOriginField()...)
+	dst = append(dst, fh.FileCreationDateField()...)
+	dst = append(dst, fh.FileCreationTimeField()...)
+	dst = append(dst, fh.FileIDModifier...)
+	dst = append(dst, fh.recordSize...)
+	dst = append(dst, fh.blockingFactor...)
+	dst = append(dst, fh.formatCode...)
+	dst = fh.appendAlphaField(dst, fh.ImmediateDestinationName, 23)
+	dst = fh.appendAlphaField(dst, fh.ImmediateOriginName, 23)
+	dst = fh.appendAlphaField(dst, fh.ReferenceCode, 8)
+	return dst
 }
 
 // SetValidation stores ValidateOpts on the FileHeader which are to be used to override
@@ -293,6 +296,70 @@ func (fh *FileHeader) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (fh *FileHeader) validateAll() []error {
+	opts := fh.validateOpts
+	if opts == nil {
+		opts = &ValidateOpts{}
+	}
+	var errs fieldErrors
+	if !opts.AllowMissingFileHeader {
+		if fh.ImmediateDestination == "" {
+			errs.add("ImmediateDestination", ErrConstructor, fh.ImmediateDestinationField())
+		}
+		if fh.ImmediateOrigin == "" {
+			errs.add("ImmediateOrigin", ErrConstructor, fh.ImmediateOriginField())
+		}
+		if fh.FileCreationDate == "" {
+			errs.add("FileCreationDate", ErrConstructor, fh.FileCreationDate)
+		}
+		if fh.FileIDModifier == "" {
+			errs.add("FileIDModifier", ErrConstructor, fh.FileIDModifier)
+		}
+		if fh.recordSize == "" {
+			errs.add("recordSize", ErrConstructor, fh.recordSize)
+		}
+		if fh.blockingFactor == "" {
+			errs.add("blockingFactor", ErrConstructor, fh.blockingFactor)
+		}
+		if fh.formatCode == "" {
+			errs.add("formatCode", ErrConstructor, fh.formatCode)
+		}
+	}
+	errs.add("FileIDModifier", fh.isUpperAlphanumeric(fh.FileIDModifier), fh.FileIDModifier)
+	if len(fh.FileIDModifier) != 1 {
+		errs.add("FileIDModifier", NewErrValidFieldLength(1), fh.FileIDModifier)
+	}
+	if fh.recordSize != "094" {
+		errs.add("recordSize", ErrRecordSize, fh.recordSize)
+	}
+	if fh.blockingFactor != "10" {
+		errs.add("blockingFactor", ErrBlockingFactor, fh.blockingFactor)
+	}
+	if fh.formatCode != "1" {
+		errs.add("formatCode", ErrFormatCode, fh.formatCode)
+	}
+	errs.add("ImmediateDestinationName", fh.isAlphanumeric(fh.ImmediateDestinationName), fh.ImmediateDestinationName)
+	if !opts.BypassOriginValidation {
+		if fh.ImmediateOrigin == zeroRoutingNumber9 || fh.ImmediateOrigin == zeroRoutingNumber10 {
+			errs.add("ImmediateOrigin", ErrConstructor, fh.ImmediateOrigin)
+		} else if opts.RequireABAOrigin {
+			errs.add("ImmediateOrigin", CheckRoutingNumber(fh.ImmediateOrigin), fh.ImmediateOrigin)
+		}
+	}
+	if !opts.BypassDestinationValidation {
+		if fh.ImmediateDestination == "000000000" {
+			errs.add("ImmediateDestination", ErrConstructor, fh.ImmediateDestination)
+		} else {
+			errs.add("ImmediateDestination", CheckRoutingNumber(fh.ImmediateDestination), fh.ImmediateDestination)
+		}
+	}
+	errs.add("ImmediateOriginName", fh.isAlphanumeric(fh.ImmediateOriginName), fh.ImmediateOriginName)
+	errs.add("ReferenceCode", fh.isAlphanumeric(fh.ReferenceCode), fh.ReferenceCode)
+	return errs
+}
+
 // ImmediateDestinationField gets the immediate destination number with zero padding
 func (fh *FileHeader) ImmediateDestinationField() string {
 	if fh.ImmediateDestination == "" {
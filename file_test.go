@@ -20,6 +20,7 @@ package ach
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -2191,3 +2192,44 @@ func TestFile_ValidateOpts_Merge(t *testing.T) {
 		require.NotNil(t, full.merge(empty))
 	})
 }
+
+func TestFile__ValidateSourceLine(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	file.Batches[0].GetEntries()[1].IndividualName = "Jöhn"
+	err = file.Validate()
+	require.ErrorIs(t, err, ErrNonAlphanumeric)
+
+	var be *BatchError
+	require.True(t, errors.As(err, &be))
+	require.Equal(t, 4, be.Line)
+	var fe *FieldError
+	require.True(t, errors.As(err, &fe))
+	require.Equal(t, "IndividualName", fe.FieldName)
+	require.Equal(t, 4, fe.Line)
+
+	// batch problems cite the batch header
+	file.Batches[0].GetEntries()[1].IndividualName = "John"
+	file.Batches[0].GetControl().EntryHash++
+	err = file.Validate()
+	require.True(t, errors.As(err, &be))
+	require.Equal(t, "EntryHash", be.FieldName)
+	require.Equal(t, 2, be.Line)
+
+	// the file header cites its own line
+	file.Batches[0].GetControl().EntryHash--
+	file.Header.ImmediateOriginName = "Bänk"
+	err = file.Validate()
+	require.True(t, errors.As(err, &fe))
+	require.Equal(t, "ImmediateOriginName", fe.FieldName)
+	require.Equal(t, 1, fe.Line)
+
+	// files which weren't read don't have lines
+	mock := mockFilePPD(t)
+	mock.Batches[0].GetEntries()[0].IndividualName = "Jöhn"
+	err = mock.Validate()
+	require.ErrorIs(t, err, ErrNonAlphanumeric)
+	require.True(t, errors.As(err, &be))
+	require.Equal(t, 0, be.Line)
+}
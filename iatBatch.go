@@ -93,6 +93,37 @@ func (iatBatch *IATBatch) verify() error {
 		return iatBatch.Error("FieldError", err)
 	}
 
+	for _, check := range iatBatch.checks() {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checks returns the rules verify applies to an IAT batch after its records are valid, in order.
+func (iatBatch *IATBatch) checks() []func() error {
+	customTraceNumbers := iatBatch.validateOpts != nil && iatBatch.validateOpts.CustomTraceNumbers
+
+	checks := []func() error{
+		iatBatch.isHeaderControlEquality,
+		func() error {
+			_, err := iatBatch.isBatchEntryCount()
+			return err
+		},
+	}
+	if !customTraceNumbers {
+		checks = append(checks, iatBatch.isSequenceAscending)
+	}
+	checks = append(checks, iatBatch.isBatchAmount, iatBatch.isEntryHash)
+	if !customTraceNumbers {
+		checks = append(checks, iatBatch.isTraceNumberODFI, iatBatch.isAddendaSequence)
+	}
+	return append(checks, iatBatch.isCategory)
+}
+
+// isHeaderControlEquality validates the fields shared by the IAT batch header and control match
+func (iatBatch *IATBatch) isHeaderControlEquality() error {
 	// validate batch header and control codes are the same
 	if (iatBatch.validateOpts == nil || !iatBatch.validateOpts.UnequalServiceClassCode) &&
 		iatBatch.Header.ServiceClassCode != iatBatch.Control.ServiceClassCode {
@@ -113,31 +144,6 @@ func (iatBatch *IATBatch) verify() error {
 	if err := iatBatch.Control.isAlphanumeric(iatBatch.Control.CompanyIdentification); err != nil {
 		return fieldError("CompanyIdentification", err, iatBatch.Control.CompanyIdentification)
 	}
-	if _, err := iatBatch.isBatchEntryCount(); err != nil {
-		return err
-	}
-	if iatBatch.validateOpts == nil || !iatBatch.validateOpts.CustomTraceNumbers {
-		if err := iatBatch.isSequenceAscending(); err != nil {
-			return err
-		}
-	}
-	if err := iatBatch.isBatchAmount(); err != nil {
-		return err
-	}
-	if err := iatBatch.isEntryHash(); err != nil {
-		return err
-	}
-	if iatBatch.validateOpts == nil || !iatBatch.validateOpts.CustomTraceNumbers {
-		if err := iatBatch.isTraceNumberODFI(); err != nil {
-			return err
-		}
-		if err := iatBatch.isAddendaSequence(); err != nil {
-			return err
-		}
-	}
-	if err := iatBatch.isCategory(); err != nil {
-		return err
-	}
 	return nil
 }
 
@@ -156,7 +162,7 @@ func (iatBatch *IATBatch) build() error {
 	for i, entry := range iatBatch.Entries {
 		// Verifies the required addenda* properties for an IAT entry detail are defined
 		if err := iatBatch.addendaFieldInclusion(entry); err != nil {
-			return err
+			return atLine(err, entry.SourceLine())
 		}
 
 		currentTraceNumberODFI, err := strconv.Atoi(entry.TraceNumberField()[:8])
@@ -284,11 +290,11 @@ func (iatBatch *IATBatch) Category() string {
 // isFieldInclusion iterates through all the records in the batch and verifies against default fields
 func (iatBatch *IATBatch) isFieldInclusion() error {
 	if err := iatBatch.Header.Validate(); err != nil {
-		return err
+		return atLine(err, iatBatch.Header.SourceLine())
 	}
 	for _, entry := range iatBatch.Entries {
 		if err := entry.Validate(); err != nil {
-			return err
+			return atLine(err, entry.SourceLine())
 		}
 		// Verifies the required Addenda* properties for an IAT entry detail are included
 		if err := iatBatch.addendaFieldInclusion(entry); err != nil {
@@ -297,56 +303,56 @@ func (iatBatch *IATBatch) isFieldInclusion() error {
 
 		// Verifies each Addenda* record is valid
 		if err := entry.Addenda10.Validate(); err != nil {
-			return err
+			return atLine(err, entry.Addenda10.SourceLine())
 		}
 		if err := entry.Addenda11.Validate(); err != nil {
-			return err
+			return atLine(err, entry.Addenda11.SourceLine())
 		}
 		if err := entry.Addenda12.Validate(); err != nil {
-			return err
+			return atLine(err, entry.Addenda12.SourceLine())
 		}
 		if err := entry.Addenda13.Validate(); err != nil {
-			return err
+			return atLine(err, entry.Addenda13.SourceLine())
 		}
 		if err := entry.Addenda14.Validate(); err != nil {
-			return err
+			return atLine(err, entry.Addenda14.SourceLine())
 		}
 		if err := entry.Addenda15.Validate(); err != nil {
-			return err
+			return atLine(err, entry.Addenda15.SourceLine())
 		}
 		if err := entry.Addenda16.Validate(); err != nil {
-			return err
+			return atLine(err, entry.Addenda16.SourceLine())
 		}
 		for _, Addenda17 := range entry.Addenda17 {
 			if err := Addenda17.Validate(); err != nil {
-				return err
+				return atLine(err, Addenda17.SourceLine())
 			}
 		}
 		for _, Addenda18 := range entry.Addenda18 {
 			if err := Addenda18.Validate(); err != nil {
-				return err
+				return atLine(err, Addenda18.SourceLine())
 			}
 		}
 
 		if entry.Category == CategoryNOC {
 			if entry.Addenda98 == nil {
-				return fieldError("Addenda98", ErrFieldInclusion)
+				return atLine(fieldError("Addenda98", ErrFieldInclusion), entry.SourceLine())
 			}
 			if err := entry.Addenda98.Validate(); err != nil {
-				return err
+				return atLine(err, entry.Addenda98.SourceLine())
 			}
 		}
 		if entry.Category == CategoryReturn {
 			if entry.Addenda99 == nil {
-				return fieldError("Addenda99", ErrFieldInclusion)
+				return atLine(fieldError("Addenda99", ErrFieldInclusion), entry.SourceLine())
 			}
 
 			if err := entry.Addenda99.Validate(); err != nil {
-				return err
+				return atLine(err, entry.Addenda99.SourceLine())
 			}
 		}
 	}
-	return iatBatch.Control.Validate()
+	return atLine(iatBatch.Control.Validate(), iatBatch.Control.SourceLine())
 }
 
 // isBatchEntryCount validate Entry count is accurate
@@ -18,10 +18,8 @@
 package ach
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
-	"unicode/utf8"
 
 	"github.com/moov-io/ach/internal/iso3166"
 	"github.com/moov-io/iso4217"
@@ -167,6 +165,8 @@ type IATBatchHeader struct {
 
 	// converters is composed for ACH to golang Converters
 	converters
+	// source is where the record was read from
+	source
 }
 
 const (
@@ -187,125 +187,87 @@ func NewIATBatchHeader() *IATBatchHeader {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (iatBh *IATBatchHeader) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// 1-1 Always "5"
-			reset()
-		case 4:
-			// 2-4 MixedCreditsAnDebits (220), CReditsOnly 9220), DebitsOnly (225)"
-			iatBh.ServiceClassCode = iatBh.parseNumField(reset())
-		case 20:
-			// 05-20  Blank except for corrected IAT entries
-			iatBh.IATIndicator = iatBh.parseStringField(reset())
-		case 22:
-			// 21-22 A code indicating currency conversion
-			// “FV” Fixed-to-Variable
-			// “VF” Variable-to-Fixed
-			// “FF” Fixed-to-Fixed
-			iatBh.ForeignExchangeIndicator = iatBh.parseStringField(reset())
-		case 23:
-			// 23-23 Foreign Exchange Reference Indicator – Refers to “Foreign Exchange Reference”
-			// field and is filled by the gateway operator. Valid entries are:
-			// 1 - Foreign Exchange Rate;
-			// 2 - Foreign Exchange Reference Number; or
-			// 3 - Space Filled
-			iatBh.ForeignExchangeReferenceIndicator = iatBh.parseNumField(reset())
-		case 38:
-			// 24-38 Contains either the foreign exchange rate used to execute the
-			// foreign exchange conversion of a cross-border entry or another
-			// reference to the foreign exchange transaction.
-			iatBh.ForeignExchangeReference = iatBh.parseStringField(reset())
-		case 40:
-			// 39-40  Receiver ISO Country Code - For entries
-			// destined to account holder in the U.S., this would be 'US'.
-			iatBh.ISODestinationCountryCode = iatBh.parseStringField(reset())
-		case 50:
-			// 41-50 For U.S. entities: the number assigned will be your tax ID
-			// For non-U.S. entities: the number assigned will be your DDA number,
-			// or the last 9 characters of your account number if it exceeds 9 characters
-			iatBh.OriginatorIdentification = iatBh.parseStringField(reset())
-		case 53:
-			// 51-53 IAT for both consumer and non consumer international payments
-			iatBh.StandardEntryClassCode = string(reset())
-		case 63:
-			// 54-63 Your description of the transaction. This text will appear on the receivers' bank statement.
-			// For example: "Payroll   "
-			iatBh.CompanyEntryDescription = strings.TrimSpace(reset())
-		case 66:
-			// 64-66 Originator ISO Currency Code
-			iatBh.ISOOriginatingCurrencyCode = iatBh.parseStringField(reset())
-		case 69:
-			// 67-69 Receiver ISO Currency Code
-			iatBh.ISODestinationCurrencyCode = iatBh.parseStringField(reset())
-		case 75:
-			// 70-75 Date transactions are to be posted to the receivers' account.
-			// You almost always want the transaction to post as soon as possible, so put tomorrow's date in YYMMDD format
-			iatBh.EffectiveEntryDate = iatBh.validateSimpleDate(reset())
-		case 78:
-			// 76-78 Always blank (just fill with spaces)
-			iatBh.SettlementDate = iatBh.validateSettlementDate(reset())
-		case 79:
-			// 79-79 Always 1
-			iatBh.OriginatorStatusCode = iatBh.parseNumField(reset())
-		case 87:
-			// 80-87 Your ODFI's routing number without the last digit. The last digit is simply a
-			// checksum digit, which is why it is not necessary
-			iatBh.ODFIIdentification = iatBh.parseStringField(reset())
-		case 94:
-			// 88-94 Sequential number of this Batch Header Record
-			// For example, put "1" if this is the first Batch Header Record in the file
-			iatBh.BatchNumber = iatBh.parseNumField(reset())
-		}
-	}
+	// 1-1 Always "5"
+	// 2-4 MixedCreditsAnDebits (220), CReditsOnly 9220), DebitsOnly (225)"
+	iatBh.ServiceClassCode = iatBh.parseNumField(fields.field(1, 4))
+	// 05-20  Blank except for corrected IAT entries
+	iatBh.IATIndicator = iatBh.parseStringField(fields.field(4, 20))
+	// 21-22 A code indicating currency conversion
+	// “FV” Fixed-to-Variable
+	// “VF” Variable-to-Fixed
+	// “FF” Fixed-to-Fixed
+	iatBh.ForeignExchangeIndicator = iatBh.parseStringField(fields.field(20, 22))
+	// 23-23 Foreign Exchange Reference Indicator – Refers to “Foreign Exchange Reference”
+	// field and is filled by the gateway operator. Valid entries are:
+	// 1 - Foreign Exchange Rate;
+	// 2 - Foreign Exchange Reference Number; or
+	// 3 - Space Filled
+	iatBh.ForeignExchangeReferenceIndicator = iatBh.parseNumField(fields.field(22, 23))
+	// 24-38 Contains either the foreign exchange rate used to execute the
+	// foreign exchange conversion of a cross-border entry or another
+	// reference to the foreign exchange transaction.
+	iatBh.ForeignExchangeReference = iatBh.parseStringField(fields.field(23, 38))
+	// 39-40  Receiver ISO Country Code - For entries
+	// destined to account holder in the U.S., this would be 'US'.
+	iatBh.ISODestinationCountryCode = iatBh.parseStringField(fields.field(38, 40))
+	// 41-50 For U.S. entities: the number assigned will be your tax ID
+	// For non-U.S. entities: the number assigned will be your DDA number,
+	// or the last 9 characters of your account number if it exceeds 9 characters
+	iatBh.OriginatorIdentification = iatBh.parseStringField(fields.field(40, 50))
+	// 51-53 IAT for both consumer and non consumer international payments
+	iatBh.StandardEntryClassCode = string(fields.field(50, 53))
+	// 54-63 Your description of the transaction. This text will appear on the receivers' bank statement.
+	// For example: "Payroll   "
+	iatBh.CompanyEntryDescription = strings.TrimSpace(fields.field(53, 63))
+	// 64-66 Originator ISO Currency Code
+	iatBh.ISOOriginatingCurrencyCode = iatBh.parseStringField(fields.field(63, 66))
+	// 67-69 Receiver ISO Currency Code
+	iatBh.ISODestinationCurrencyCode = iatBh.parseStringField(fields.field(66, 69))
+	// 70-75 Date transactions are to be posted to the receivers' account.
+	// You almost always want the transaction to post as soon as possible, so put tomorrow's date in YYMMDD format
+	iatBh.EffectiveEntryDate = iatBh.validateSimpleDate(fields.field(69, 75))
+	// 76-78 Always blank (just fill with spaces)
+	iatBh.SettlementDate = iatBh.validateSettlementDate(fields.field(75, 78))
+	// 79-79 Always 1
+	iatBh.OriginatorStatusCode = iatBh.parseNumField(fields.field(78, 79))
+	// 80-87 Your ODFI's routing number without the last digit. The last digit is simply a
+	// checksum digit, which is why it is not necessary
+	iatBh.ODFIIdentification = iatBh.parseStringField(fields.field(79, 87))
+	// 88-94 Sequential number of this Batch Header Record
+	// For example, put "1" if this is the first Batch Header Record in the file
+	iatBh.BatchNumber = iatBh.parseNumField(fields.field(87, 94))
 }
 
 // String writes the BatchHeader struct to a 94 character string.
 func (iatBh *IATBatchHeader) String() string {
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(batchHeaderPos)
-	buf.WriteString(fmt.Sprintf("%v", iatBh.ServiceClassCode))
-	buf.WriteString(iatBh.IATIndicatorField())
-	buf.WriteString(iatBh.ForeignExchangeIndicatorField())
-	buf.WriteString(iatBh.ForeignExchangeReferenceIndicatorField())
-	buf.WriteString(iatBh.ForeignExchangeReferenceField())
-	buf.WriteString(iatBh.ISODestinationCountryCodeField())
-	buf.WriteString(iatBh.OriginatorIdentificationField())
-	buf.WriteString(iatBh.StandardEntryClassCode)
-	buf.WriteString(iatBh.CompanyEntryDescriptionField())
-	buf.WriteString(iatBh.ISOOriginatingCurrencyCodeField())
-	buf.WriteString(iatBh.ISODestinationCurrencyCodeField())
-	buf.WriteString(iatBh.EffectiveEntryDateField())
-	buf.WriteString(iatBh.SettlementDateField())
-	buf.WriteString(fmt.Sprintf("%v", iatBh.OriginatorStatusCode))
-	buf.WriteString(iatBh.ODFIIdentificationField())
-	buf.WriteString(iatBh.BatchNumberField())
-
-	return buf.String()
+	return string(iatBh.AppendTo(make([]byte, 0, RecordLength)))
+}
+
+// AppendTo appends the IATBatchHeader record to dst and returns the extended buffer.
+func (iatBh *IATBatchHeader) AppendTo(dst []byte) []byte {
+	dst = append(dst, batchHeaderPos...)
+	dst = strconv.AppendInt(dst, int64(iatBh.ServiceClassCode), 10)
+	dst = iatBh.appendAlphaField(dst, iatBh.IATIndicator, 16)
+	dst = iatBh.appendAlphaField(dst, iatBh.ForeignExchangeIndicator, 2)
+	dst = iatBh.appendNumericField(dst, iatBh.ForeignExchangeReferenceIndicator, 1)
+	dst = append(dst, iatBh.ForeignExchangeReferenceField()...)
+	dst = iatBh.appendAlphaField(dst, iatBh.ISODestinationCountryCode, 2)
+	dst = iatBh.appendAlphaField(dst, iatBh.OriginatorIdentification, 10)
+	dst = append(dst, iatBh.StandardEntryClassCode...)
+	dst = iatBh.appendAlphaField(dst, iatBh.CompanyEntryDescription, 10)
+	dst = iatBh.appendAlphaField(dst, iatBh.ISOOriginatingCurrencyCode, 3)
+	dst = iatBh.appendAlphaField(dst, iatBh.ISODestinationCurrencyCode, 3)
+	dst = iatBh.appendStringField(dst, iatBh.EffectiveEntryDate, 6)
+	dst = iatBh.appendAlphaField(dst, iatBh.SettlementDate, 3)
+	dst = strconv.AppendInt(dst, int64(iatBh.OriginatorStatusCode), 10)
+	dst = iatBh.appendStringField(dst, iatBh.ODFIIdentification, 8)
+	dst = iatBh.appendNumericField(dst, iatBh.BatchNumber, 7)
+	return dst
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
@@ -385,6 +347,58 @@ func (iatBh *IATBatchHeader) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (iatBh *IATBatchHeader) validateAll() []error {
+	var errs fieldErrors
+	if iatBh.ServiceClassCode == 0 {
+		errs.add("ServiceClassCode", ErrFieldInclusion, strconv.Itoa(iatBh.ServiceClassCode))
+	}
+	if iatBh.ForeignExchangeIndicator == "" {
+		errs.add("ForeignExchangeIndicator", ErrFieldInclusion, iatBh.ForeignExchangeIndicator)
+	}
+	if iatBh.ForeignExchangeReferenceIndicator == 0 {
+		errs.add("ForeignExchangeReferenceIndicator", ErrFieldRequired, strconv.Itoa(iatBh.ForeignExchangeReferenceIndicator))
+	}
+	if iatBh.ISODestinationCountryCode == "" {
+		errs.add("ISODestinationCountryCode", ErrFieldInclusion, iatBh.ISODestinationCountryCode)
+	}
+	if iatBh.OriginatorIdentification == "" {
+		errs.add("OriginatorIdentification", ErrFieldInclusion, iatBh.OriginatorIdentification)
+	}
+	if iatBh.StandardEntryClassCode == "" {
+		errs.add("StandardEntryClassCode", ErrFieldInclusion, iatBh.StandardEntryClassCode)
+	}
+	if iatBh.CompanyEntryDescription == "" {
+		errs.add("CompanyEntryDescription", ErrFieldInclusion, iatBh.CompanyEntryDescription)
+	}
+	if iatBh.ISOOriginatingCurrencyCode == "" {
+		errs.add("ISOOriginatingCurrencyCode", ErrFieldInclusion, iatBh.ISOOriginatingCurrencyCode)
+	}
+	if iatBh.ISODestinationCurrencyCode == "" {
+		errs.add("ISODestinationCurrencyCode", ErrFieldInclusion, iatBh.ISODestinationCurrencyCode)
+	}
+	if iatBh.ODFIIdentification == "" {
+		errs.add("ODFIIdentification", ErrFieldInclusion, iatBh.ODFIIdentificationField())
+	}
+	errs.add("ServiceClassCode", iatBh.isServiceClass(iatBh.ServiceClassCode), strconv.Itoa(iatBh.ServiceClassCode))
+	errs.add("ForeignExchangeIndicator", iatBh.isForeignExchangeIndicator(iatBh.ForeignExchangeIndicator), iatBh.ForeignExchangeIndicator)
+	errs.add("ForeignExchangeReferenceIndicator", iatBh.isForeignExchangeReferenceIndicator(iatBh.ForeignExchangeReferenceIndicator), strconv.Itoa(iatBh.ForeignExchangeReferenceIndicator))
+	if !iso3166.Valid(iatBh.ISODestinationCountryCode) {
+		errs.add("ISODestinationCountryCode", ErrValidISO3166, iatBh.ISODestinationCountryCode)
+	}
+	errs.add("StandardEntryClassCode", iatBh.isSECCode(iatBh.StandardEntryClassCode), iatBh.StandardEntryClassCode)
+	errs.add("CompanyEntryDescription", iatBh.isAlphanumeric(iatBh.CompanyEntryDescription), iatBh.CompanyEntryDescription)
+	if _, exists := iso4217.Lookup(iatBh.ISOOriginatingCurrencyCode); !exists {
+		errs.add("ISOOriginatingCurrencyCode", ErrValidISO4217, iatBh.ISOOriginatingCurrencyCode)
+	}
+	if _, exists := iso4217.Lookup(iatBh.ISODestinationCurrencyCode); !exists {
+		errs.add("ISODestinationCurrencyCode", ErrValidISO4217, iatBh.ISODestinationCurrencyCode)
+	}
+	errs.add("OriginatorStatusCode", iatBh.isOriginatorStatusCode(iatBh.OriginatorStatusCode), strconv.Itoa(iatBh.OriginatorStatusCode))
+	return errs
+}
+
 // IATIndicatorField gets the IATIndicator left padded
 func (iatBh *IATBatchHeader) IATIndicatorField() string {
 	// should this be left padded
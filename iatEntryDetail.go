@@ -18,10 +18,8 @@
 package ach
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
-	"unicode/utf8"
 )
 
 // IATEntryDetail contains the actual transaction data for an individual entry.
@@ -136,6 +134,8 @@ type IATEntryDetail struct {
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// source is where the record was read from
+	source
 
 	validateOpts *ValidateOpts
 }
@@ -153,98 +153,59 @@ func NewIATEntryDetail() *IATEntryDetail {
 //
 // Parse provides no guarantee about all fields being filled in. Callers should make a Validate call to confirm successful parsing and data validity.
 func (iatEd *IATEntryDetail) Parse(record string) {
-	runeCount := utf8.RuneCountInString(record)
-	if runeCount != 94 {
+	fields, ok := newRecordFields(record)
+	if !ok {
 		return
 	}
 
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	reset := func() string {
-		out := buf.String()
-		buf.Reset()
-		return out
-	}
-
-	// We're going to process the record rune-by-rune and at each field cutoff save the value.
-	var idx int
-	for _, r := range record {
-		idx++
-
-		// Append rune to buffer
-		buf.WriteRune(r)
-
-		// At each cutoff save the buffer and reset
-		switch idx {
-		case 0, 1:
-			// do nothing, ignore "6" record type
-			reset()
-
-		case 3:
-			// 2-3 is checking credit 22 debit 27 savings credit 32 debit 37
-			iatEd.TransactionCode = iatEd.parseNumField(reset())
-		case 11:
-			// 4-11 the RDFI's routing number without the last digit.
-			iatEd.RDFIIdentification = iatEd.parseStringField(reset())
-		case 12:
-			// 12-12 The last digit of the RDFI's routing number
-			iatEd.CheckDigit = iatEd.parseStringField(reset())
-		case 16:
-			// 13-16 Number of addenda records
-			iatEd.AddendaRecords = iatEd.parseNumField(reset())
-		case 29:
-			// 17-29 reserved - Leave blank
-			reset()
-		case 39:
-			// 30-39 Number of cents you are debiting/crediting this account
-			iatEd.Amount = iatEd.parseNumField(reset())
-		case 74:
-			// 40-74 The foreign receiver's account number you are crediting/debiting
-			iatEd.DFIAccountNumber = iatEd.parseStringFieldWithOpts(reset(), iatEd.validateOpts)
-		case 76:
-			// 75-76 reserved Leave blank
-			reset()
-		case 77:
-			// 77 OFACScreeningIndicator
-			reset()
-			iatEd.OFACScreeningIndicator = " "
-		case 78:
-			// 78-78 Secondary SecondaryOFACScreeningIndicator
-			reset()
-			iatEd.SecondaryOFACScreeningIndicator = " "
-		case 79:
-			// 79-79 1 if addenda exists 0 if it does not
-			iatEd.AddendaRecordIndicator = iatEd.parseNumField(reset())
-		case 94:
-			// 80-94 An internal identification (alphanumeric) that you use to uniquely identify
-			// this Entry Detail Record This number should be unique to the transaction and will
-			// help identify the transaction in case of an inquiry
-			iatEd.TraceNumber = strings.TrimSpace(reset())
-		}
-	}
+	// 1-1 Always "6"
+	// 2-3 is checking credit 22 debit 27 savings credit 32 debit 37
+	iatEd.TransactionCode = iatEd.parseNumField(fields.field(1, 3))
+	// 4-11 the RDFI's routing number without the last digit.
+	iatEd.RDFIIdentification = iatEd.parseStringField(fields.field(3, 11))
+	// 12-12 The last digit of the RDFI's routing number
+	iatEd.CheckDigit = iatEd.parseStringField(fields.field(11, 12))
+	// 13-16 Number of addenda records
+	iatEd.AddendaRecords = iatEd.parseNumField(fields.field(12, 16))
+	// 17-29 reserved - Leave blank
+	// 30-39 Number of cents you are debiting/crediting this account
+	iatEd.Amount = iatEd.parseNumField(fields.field(29, 39))
+	// 40-74 The foreign receiver's account number you are crediting/debiting
+	iatEd.DFIAccountNumber = iatEd.parseStringFieldWithOpts(fields.field(39, 74), iatEd.validateOpts)
+	// 75-76 reserved Leave blank
+	// 77 OFACScreeningIndicator
+	iatEd.OFACScreeningIndicator = " "
+	// 78-78 Secondary SecondaryOFACScreeningIndicator
+	iatEd.SecondaryOFACScreeningIndicator = " "
+	// 79-79 1 if addenda exists 0 if it does not
+	iatEd.AddendaRecordIndicator = iatEd.parseNumField(fields.field(78, 79))
+	// 80-94 An internal identification (alphanumeric) that you use to uniquely identify
+	// this Entry Detail Record This number should be unique to the transaction and will
+	// help identify the transaction in case of an inquiry
+	iatEd.TraceNumber = strings.TrimSpace(fields.field(79, 94))
 }
 
 // String writes the EntryDetail struct to a 94 character string.
 func (iatEd *IATEntryDetail) String() string {
-	buf := getBuffer()
-	defer saveBuffer(buf)
-
-	buf.WriteString(entryDetailPos)
-	buf.WriteString(fmt.Sprintf("%v", iatEd.TransactionCode))
-	buf.WriteString(iatEd.RDFIIdentificationField())
-	buf.WriteString(iatEd.CheckDigit)
-	buf.WriteString(iatEd.AddendaRecordsField())
-	buf.WriteString("             ")
-	buf.WriteString(iatEd.AmountField())
-	buf.WriteString(iatEd.DFIAccountNumberField())
-	buf.WriteString("  ")
-	buf.WriteString(iatEd.OFACScreeningIndicatorField())
-	buf.WriteString(iatEd.SecondaryOFACScreeningIndicatorField())
-	buf.WriteString(fmt.Sprintf("%v", iatEd.AddendaRecordIndicator))
-	buf.WriteString(iatEd.TraceNumberField())
+	return string(iatEd.AppendTo(make([]byte, 0, RecordLength)))
+}
 
-	return buf.String()
+// AppendTo appends the IATEntryDetail record to dst and returns the extended buffer.
+func (iatEd *IATEntryDetail) AppendTo(dst []byte) []byte {
+	dst = append(dst, entryDetailPos...)
+	dst = strconv.AppendInt(dst, int64(iatEd.TransactionCode), 10)
+	dst = iatEd.appendStringField(dst, iatEd.RDFIIdentification, 8)
+	dst = append(dst, iatEd.CheckDigit...)
+	dst = iatEd.appendNumericField(dst, iatEd.AddendaRecords, 4)
+	dst = append(dst, "             "...)
+	dst = iatEd.appendNumericField(dst, iatEd.Amount, 10)
+	dst = iatEd.appendAlphaField(dst, iatEd.DFIAccountNumber, 35)
+	dst = append(dst, "  "...)
+	dst = iatEd.appendAlphaField(dst, iatEd.OFACScreeningIndicator, 1)
+	dst = iatEd.appendAlphaField(dst, iatEd.SecondaryOFACScreeningIndicator, 1)
+	dst = strconv.AppendInt(dst, int64(iatEd.AddendaRecordIndicator), 10)
+	dst = iatEd.appendStringField(dst, iatEd.TraceNumber, 15)
+	return dst
 }
 
 // SetValidation stores ValidateOpts on the EntryDetail which are to be used to override
@@ -311,6 +272,43 @@ func (iatEd *IATEntryDetail) fieldInclusion() error {
 	return nil
 }
 
+// validateAll returns the first problem with each field of the record. The first one is the problem
+// Validate returns, the others are what Validate would find if it didn't stop there.
+func (iatEd *IATEntryDetail) validateAll() []error {
+	var errs fieldErrors
+	if iatEd.TransactionCode == 0 {
+		errs.add("TransactionCode", ErrConstructor, strconv.Itoa(iatEd.TransactionCode))
+	}
+	if iatEd.RDFIIdentification == "" {
+		errs.add("RDFIIdentification", ErrConstructor, iatEd.RDFIIdentificationField())
+	}
+	if iatEd.AddendaRecords == 0 {
+		errs.add("AddendaRecords", ErrConstructor, strconv.Itoa(iatEd.AddendaRecords))
+	}
+	if iatEd.DFIAccountNumber == "" {
+		errs.add("DFIAccountNumber", ErrConstructor, iatEd.DFIAccountNumber)
+	}
+	if iatEd.AddendaRecordIndicator == 0 {
+		errs.add("AddendaRecordIndicator", ErrConstructor, strconv.Itoa(iatEd.AddendaRecordIndicator))
+	}
+	if iatEd.TraceNumber == "" {
+		errs.add("TraceNumber", ErrConstructor, iatEd.TraceNumberField())
+	}
+	if iatEd.validateOpts != nil && iatEd.validateOpts.CheckTransactionCode != nil {
+		errs.add("TransactionCode", iatEd.validateOpts.CheckTransactionCode(iatEd.TransactionCode), strconv.Itoa(iatEd.TransactionCode))
+	} else {
+		errs.add("TransactionCode", iatEd.isTransactionCode(iatEd.TransactionCode), strconv.Itoa(iatEd.TransactionCode))
+	}
+	errs.add("DFIAccountNumber", iatEd.isAlphanumeric(iatEd.DFIAccountNumber), iatEd.DFIAccountNumber)
+	calculated := CalculateCheckDigit(iatEd.RDFIIdentificationField())
+	if edCheckDigit, err := strconv.Atoi(iatEd.CheckDigit); err != nil {
+		errs.add("CheckDigit", err, iatEd.CheckDigit)
+	} else if calculated != edCheckDigit {
+		errs.add("RDFIIdentification", NewErrValidCheckDigit(calculated), iatEd.CheckDigit)
+	}
+	return errs
+}
+
 func (iatEd *IATEntryDetail) isCorrection() bool {
 	return iatEd.Addenda98 != nil
 }
@@ -32,6 +32,10 @@ type Iterator struct {
 	reader     *Reader
 	scanner    *bufio.Scanner
 	cachedLine string
+
+	// consumed is the number of bytes scanned, lineOffset is the byte offset of the last line scanned
+	consumed   int64
+	lineOffset int64
 }
 
 // NewIterator returns an Iterator
@@ -43,9 +47,20 @@ func NewIterator(r io.Reader) *Iterator {
 		reader:  reader,
 		scanner: bufio.NewScanner(r),
 	}
+	out.scanner.Split(out.scanLines)
 	return out
 }
 
+// scanLines splits lines like bufio.ScanLines and keeps track of their byte offsets.
+func (i *Iterator) scanLines(data []byte, atEOF bool) (int, []byte, error) {
+	advance, token, err := bufio.ScanLines(data, atEOF)
+	if token != nil {
+		i.lineOffset = i.consumed
+	}
+	i.consumed += int64(advance)
+	return advance, token, err
+}
+
 func (i *Iterator) SetValidation(opts *ValidateOpts) {
 	if i.reader != nil {
 		i.reader.SetValidation(opts)
@@ -88,6 +103,7 @@ func (i *Iterator) NextEntry() (*BatchHeader, *EntryDetail, error) {
 		for i.scanner.Scan() {
 			line = i.scanner.Text()
 			i.reader.lineNum++
+			i.reader.offset = i.lineOffset
 			if allSpaces(line) {
 				continue
 			}
@@ -134,6 +150,7 @@ func (i *Iterator) NextEntry() (*BatchHeader, *EntryDetail, error) {
 				if i.scanner.Scan() {
 					foundLine := i.scanner.Text()
 					i.reader.lineNum++
+					i.reader.offset = i.lineOffset
 					if foundLine == "" {
 						break
 					}
@@ -85,6 +85,10 @@ type Conditions struct {
 
 	// MaxDollarAmount will limit each merged file's total dollar amount.
 	MaxDollarAmount int64 `json:"maxDollarAmount"`
+
+	// TraceNumbers renumbers each merged entry with the next trace number for its batch's ODFI.
+	// The incoming files are not modified.
+	TraceNumbers TraceNumberAllocator `json:"-"`
 }
 
 // MergeFilesWith is a function for consolidating an array of ACH Files into a few files as possible.
@@ -144,6 +148,10 @@ type MergeDirOptions struct {
 	// The value should be the file extension for ValidateOpts files.
 	ValidateOptsExtension string
 
+	// ValidationProfile is the name of a registered validation profile used to read each file.
+	// ValidateOpts from ValidateOptsExtension files are merged on top of the profile.
+	ValidationProfile string
+
 	// ParseWorkers is the concurrent number of ACH file reader/parser goroutines
 	// Default: 10
 	ParseWorkers int
@@ -191,6 +199,14 @@ func MergeDir(dir string, conditions Conditions, opts *MergeDirOptions) ([]*File
 	if opts.AcceptFile == nil {
 		opts.AcceptFile = DefaultFileAcceptor
 	}
+	var profile *ValidateOpts
+	if opts.ValidationProfile != "" {
+		var err error
+		profile, err = ValidationProfileOpts(opts.ValidationProfile)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if opts.FS != nil {
 		// Go running on windows does not support os.DirFS properly
 		// See: https://github.com/golang/go/issues/44279
@@ -254,7 +270,7 @@ func MergeDir(dir string, conditions Conditions, opts *MergeDirOptions) ([]*File
 		g.Go(func() error {
 			defer parsingGroup.Done()
 
-			return queueFileForMerging(pathsCtx, discoveredPaths, &setup, sorted, mergableFiles, opts)
+			return queueFileForMerging(pathsCtx, discoveredPaths, &setup, sorted, mergableFiles, opts, profile)
 		})
 	}
 	g.Go(func() error {
@@ -330,7 +346,7 @@ func walkDir(fsys fs.FS, dir string, opts *MergeDirOptions, discoveredPaths chan
 	return nil
 }
 
-func queueFileForMerging(ctx context.Context, discoveredPaths chan string, setup *sync.Once, sorted *outFile, mergableFiles chan *File, opts *MergeDirOptions) error {
+func queueFileForMerging(ctx context.Context, discoveredPaths chan string, setup *sync.Once, sorted *outFile, mergableFiles chan *File, opts *MergeDirOptions, profile *ValidateOpts) error {
 	for {
 		select {
 		case path := <-discoveredPaths:
@@ -354,7 +370,7 @@ func queueFileForMerging(ctx context.Context, discoveredPaths chan string, setup
 			}
 
 			// Load any ValidateOpts that exist
-			validateOpts := readValidateOptsFromFile(path, opts)
+			validateOpts := profile.merge(readValidateOptsFromFile(path, opts))
 
 			// Read the file
 			file, err = readFile(opts.FS, path, as, validateOpts)
@@ -595,6 +611,16 @@ func convertToFiles(sorted *outFile, conditions Conditions) ([]*File, error) {
 				}
 
 			merge:
+				// Renumber a copy of the entry so incoming files are left untouched
+				if conditions.TraceNumbers != nil {
+					trace, err := conditions.TraceNumbers.Next(nextBatch.header.ODFIIdentification)
+					if err != nil {
+						return nil, fmt.Errorf("renumbering entry %s: %w", nextEntry.TraceNumber, err)
+					}
+					nextEntry = copyEntryDetail(nextEntry)
+					setAllocatedTraceNumber(nextEntry, trace)
+				}
+
 				// Add the entry to the current batch
 				batch.AddEntry(nextEntry)
 
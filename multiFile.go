@@ -0,0 +1,129 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/moov-io/base"
+)
+
+// NextFile reads the next File from input containing several files one after another, each with
+// its own FileHeader and FileControl. A FileHeader following the records of a file starts the next file.
+// NextFile returns a nil File and error once the input is exhausted.
+//
+// Problems with a file are returned along with it, so callers can continue with the next file.
+// Errors reading the input, or reading more than the maximum number of lines, are returned without a File.
+// Line numbers and byte offsets count from the start of the input rather than each file.
+func (r *Reader) NextFile() (*File, error) {
+	if r.scanner == nil {
+		return nil, errors.New("nil scanner")
+	}
+
+	for {
+		line, offset, ok := r.nextHeader, r.nextHeaderOffset, r.nextHeader != ""
+		if ok {
+			r.nextHeader = ""
+		} else {
+			line, offset, ok = r.nextLine()
+		}
+		if !ok {
+			break
+		}
+
+		if strings.HasPrefix(line, fileHeaderPos) && r.fileStarted() {
+			r.nextHeader, r.nextHeaderOffset = line, offset
+			return r.nextFile()
+		}
+		if err := r.processLine(line, offset); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !r.fileStarted() {
+		return nil, nil
+	}
+	return r.nextFile()
+}
+
+// ReadAll reads every File from input containing several files one after another, see NextFile.
+// The problems with each file are combined into the returned error.
+func (r *Reader) ReadAll() ([]File, error) {
+	var files []File
+	var errs base.ErrorList
+	for {
+		file, err := r.NextFile()
+		if file == nil {
+			if err != nil {
+				return files, err
+			}
+			break
+		}
+		var el base.ErrorList
+		if errors.As(err, &el) {
+			for i := range el {
+				errs.Add(el[i])
+			}
+		} else if err != nil {
+			errs.Add(err)
+		}
+		files = append(files, *file)
+	}
+	if errs.Empty() {
+		return files, nil
+	}
+	return files, errs
+}
+
+// fileStarted returns true when records, or problems, of a file have been read.
+func (r *Reader) fileStarted() bool {
+	return r.records > 0 || !r.errors.Empty()
+}
+
+// nextFile returns the File read so far and resets the Reader to read another file with the same ValidateOpts.
+func (r *Reader) nextFile() (*File, error) {
+	file, err := r.finishFile()
+
+	opts := r.File.validateOpts
+	r.File = File{}
+	r.File.SetValidation(opts)
+	r.IATCurrentBatch = IATBatch{}
+	r.currentBatch = nil
+	r.recordName = ""
+	r.errors = nil
+	r.diagnostics, r.diagnosed = nil, 0
+	r.records = 0
+	r.resyncing, r.skipAddenda, r.lastBatchIAT = false, false, false
+
+	return &file, err
+}
+
+// WriteAll writes files one after another, each padded to a multiple of 10 records.
+// Each File is validated unless BypassValidation is enabled.
+func (w *Writer) WriteAll(files []File) error {
+	for i := range files {
+		if err := w.Write(&files[i]); err != nil {
+			return fmt.Errorf("file %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base"
+	"github.com/stretchr/testify/require"
+)
+
+func multiFileInput(t *testing.T, names ...string) string {
+	t.Helper()
+
+	var files []File
+	for _, name := range names {
+		file, err := readACHFilepath(filepath.Join("test", "testdata", name))
+		require.NoError(t, err)
+		files = append(files, *file)
+	}
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).WriteAll(files))
+	return buf.String()
+}
+
+func TestReader__ReadAll(t *testing.T) {
+	input := multiFileInput(t, "ppd-debit.ach", "ppd-mixedDebitCredit.ach")
+
+	files, err := NewReader(strings.NewReader(input)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	require.Len(t, files[0].Batches, 1)
+	require.Equal(t, 1, files[0].Header.SourceLine())
+	require.Equal(t, 5, files[0].Control.SourceLine())
+
+	// line numbers continue from the first file
+	require.Equal(t, 11, files[1].Header.SourceLine())
+	require.Equal(t, int64(10*95), files[1].Header.SourceOffset())
+	require.Len(t, files[1].Batches, 1)
+	require.Len(t, files[1].Batches[0].GetEntries(), 3)
+
+	// writing the files again gives the same transmission
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).WriteAll(files))
+	require.Equal(t, input, buf.String())
+}
+
+func TestReader__NextFile(t *testing.T) {
+	input := multiFileInput(t, "ppd-debit.ach", "ppd-debit.ach", "ppd-mixedDebitCredit.ach")
+	r := NewReader(strings.NewReader(input))
+
+	var count int
+	for {
+		file, err := r.NextFile()
+		require.NoError(t, err)
+		if file == nil {
+			break
+		}
+		count++
+		require.Equal(t, 1+10*(count-1), file.Header.SourceLine())
+	}
+	require.Equal(t, 3, count)
+
+	file, err := r.NextFile()
+	require.NoError(t, err)
+	require.Nil(t, file)
+}
+
+func TestReader__ReadAllErrors(t *testing.T) {
+	input := multiFileInput(t, "ppd-debit.ach", "ppd-debit.ach")
+	lines := strings.Split(input, "\n")
+	input = strings.Join(append(lines[:4:4], lines[5:]...), "\n") // drop the first FileControl
+
+	files, err := NewReader(strings.NewReader(input)).ReadAll()
+	require.Error(t, err)
+	require.True(t, base.Has(err, ErrFileControl))
+	require.Len(t, files, 2)
+	require.Len(t, files[1].Batches, 1)
+
+	// ValidateOpts apply to every file
+	r := NewReader(strings.NewReader(input))
+	r.SetValidation(&ValidateOpts{AllowMissingFileControl: true})
+	files, err = r.ReadAll()
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+}
+
+func TestReader__NextFileDiagnostics(t *testing.T) {
+	input := multiFileInput(t, "ppd-debit.ach", "ppd-debit.ach")
+	lines := strings.Split(input, "\n")
+	lines[2] = lines[2][:1] + "99" + lines[2][3:] // invalid transaction code in the first file
+
+	r := NewReader(strings.NewReader(strings.Join(lines, "\n")))
+	r.lenient = true
+
+	file, err := r.NextFile()
+	require.NotNil(t, file)
+	require.Error(t, err)
+
+	// the problems of the first file are forgotten with its errors
+	require.Empty(t, r.diagnostics)
+	require.Zero(t, r.diagnosed)
+
+	file, err = r.NextFile()
+	require.NotNil(t, file)
+	require.NoError(t, err)
+	require.Empty(t, r.diagnostics)
+}
+
+func TestReader__ReadAllMaxLines(t *testing.T) {
+	input := multiFileInput(t, "ppd-debit.ach", "ppd-debit.ach")
+
+	r := NewReader(strings.NewReader(input))
+	r.SetMaxLines(15)
+	files, err := r.ReadAll()
+	require.True(t, base.Has(err, ErrFileTooLong))
+	require.Len(t, files, 1)
+}
+
+func TestWriter__WriteAll(t *testing.T) {
+	input := multiFileInput(t, "ppd-debit.ach", "ppd-mixedDebitCredit.ach")
+
+	// each file is padded to a multiple of 10 records
+	lines := strings.Split(strings.TrimSuffix(input, "\n"), "\n")
+	require.Len(t, lines, 20)
+	require.Equal(t, paddingLine, lines[9])
+	require.True(t, strings.HasPrefix(lines[10], fileHeaderPos))
+
+	// invalid files are reported by their position
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	invalid := *file
+	invalid.Header.ImmediateOrigin = ""
+	err = NewWriter(&bytes.Buffer{}).WriteAll([]File{*file, invalid})
+	require.ErrorContains(t, err, "file 2: ")
+}
@@ -1,50 +0,0 @@
-// Licensed to The Moov Authors under one or more contributor
-// license agreements. See the NOTICE file distributed with
-// this work for additional information regarding copyright
-// ownership. The Moov Authors licenses this file to you under
-// the Apache License, Version 2.0 (the "License"); you may
-// not use this file except in compliance with the License.
-// You may obtain a copy of the License at
-//
-//     http://www.apache.org/licenses/LICENSE-2.0
-//
-// Unless required by applicable law or agreed to in writing,
-// software distributed under the License is distributed on an
-// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
-// KIND, either express or implied.  See the License for the
-// specific language governing permissions and limitations
-// under the License.
-
-package ach
-
-import (
-	"bytes"
-	"sync"
-)
-
-var (
-	byteBufferPool = sync.Pool{
-		New: func() any {
-			return newBuffer()
-		},
-	}
-)
-
-func newBuffer() *bytes.Buffer {
-	var sb bytes.Buffer
-	sb.Grow(94)
-	return &sb
-}
-
-func getBuffer() *bytes.Buffer {
-	sb, ok := byteBufferPool.Get().(*bytes.Buffer)
-	if !ok {
-		return newBuffer()
-	}
-	return sb
-}
-
-func saveBuffer(sb *bytes.Buffer) {
-	sb.Reset()
-	byteBufferPool.Put(sb)
-}
@@ -70,8 +70,40 @@ type Reader struct {
 	// errors holds each error encountered when attempting to parse the file
 	errors base.ErrorList
 
+	// warnings holds the problems which the ValidateOpts Severities downgraded to SeverityWarning
+	warnings base.ErrorList
+
 	// skipBatchAccumulation is a flag to skip .AddBatch
 	skipBatchAccumulation bool
+
+	// offset is the byte offset of the record being parsed, consumed is the number of bytes read from the input
+	// and lineOffset is the byte offset of the last line read
+	offset     int64
+	consumed   int64
+	lineOffset int64
+
+	// lenient is set by ReadLenient to keep parsing past records which can't be parsed
+	lenient bool
+
+	// resyncing is set by an invalid batch header in lenient mode. Records are skipped until the next batch header.
+	resyncing bool
+
+	// skipAddenda is set by an invalid entry in lenient mode, so its addenda aren't added to another entry
+	skipAddenda bool
+
+	// lastBatchIAT is set when the last batch being parsed was an IAT batch
+	lastBatchIAT bool
+
+	// diagnostics are the problems found by ReadLenient, diagnosed is the number of errors they cover
+	diagnostics []ReadDiagnostic
+	diagnosed   int
+
+	// records is the number of Nacha records parsed, not counting block padding
+	records int
+
+	// nextHeader is the FileHeader line, and its byte offset, which NextFile read from the following file
+	nextHeader       string
+	nextHeaderOffset int64
 }
 
 // error returns a new ParseError based on err
@@ -89,6 +121,11 @@ func (r *Reader) parseError(err error) error {
 	}
 }
 
+// currentSource returns the position of the record being parsed.
+func (r *Reader) currentSource() source {
+	return source{line: r.lineNum, offset: r.offset}
+}
+
 // addCurrentBatch creates the current batch type for the file being read. A successful
 // current batch will be added to r.File once parsed.
 func (r *Reader) addCurrentBatch(batch Batcher) {
@@ -110,6 +147,12 @@ func (r *Reader) SetValidation(opts *ValidateOpts) {
 	r.File.SetValidation(opts)
 }
 
+// Warnings returns the problems found while reading which the Severities of the ValidateOpts downgraded
+// to SeverityWarning. Records with only warnings are kept in the File. Each warning is a *base.ParseError.
+func (r *Reader) Warnings() base.ErrorList {
+	return r.warnings
+}
+
 // ReadFile attempts to open a file at path and read the contents before closing
 // and returning the parsed ACH File.
 func ReadFile(path string) (*File, error) {
@@ -157,6 +200,8 @@ func NewReader(r io.Reader) *Reader {
 	}
 	if rr != nil {
 		out.scanner = bufio.NewScanner(rr)
+		out.scanner.Buffer(nil, maxLineSize)
+		out.scanner.Split(out.scanLine)
 	}
 
 	return out
@@ -168,6 +213,9 @@ func (r *Reader) SetMaxLines(max int) {
 
 const lineLength = 94
 
+// maxLineSize is the longest line (in bytes) Reader accepts, which only applies to unknown records being preserved.
+const maxLineSize = 1024 * 1024
+
 // Read reads each line in the underlying io.Reader and returns a File and any errors encountered.
 //
 // Read enforces ACH formatting rules and the first character of each line determines which parser is used.
@@ -182,65 +230,138 @@ func (r *Reader) Read() (File, error) {
 	if r.scanner == nil {
 		return r.File, errors.New("nil scanner")
 	}
-	// r.scanner.Split(scanLines)
-	r.scanner.Split(bufio.ScanRunes)
-
-	// Accumulate the current line
-	var currentLineRuneCount int
-	currentLine := getBuffer()
-	defer saveBuffer(currentLine)
-
-	for r.scanner.Scan() {
-		char := r.scanner.Text()
-		switch char {
-		case "\n", "\r":
-			// Skip accumulating the newline, but parse the line
-			if currentLineRuneCount > 0 {
-				goto fullLine
-			}
-		default:
-			currentLineRuneCount += 1
-			currentLine.WriteString(char)
-		}
 
-		if currentLineRuneCount < lineLength {
-			continue // next rune
+	for {
+		line, offset, ok := r.nextLine()
+		if !ok {
+			break
 		}
-
-		// We have a full line to parse
-	fullLine:
-		r.lineNum++
-		if r.lineNum > r.maxLines {
-			r.errors.Add(ErrFileTooLong)
-			return r.File, r.errors
+		if err := r.processLine(line, offset); err != nil {
+			return r.File, err
 		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return r.File, err
+	}
+	return r.finishFile()
+}
 
-		// skip the buffered line if it's blank
-		line := currentLine.String()
-		if !blankLine(line) {
-			// hand off the line to be parsed
-			err := r.readLine(line)
-			if err != nil {
-				r.errors.Add(err)
+// nextLine returns the next line of input along with its byte offset.
+func (r *Reader) nextLine() (string, int64, bool) {
+	if !r.scanner.Scan() {
+		return "", 0, false
+	}
+	return string(r.scanner.Bytes()), r.lineOffset, true
+}
+
+// scanLine splits the input into lines for nextLine. Lines end at a line ending or after 94 characters,
+// as some files are written without line endings. Line endings between lines are skipped.
+//
+// ACH files are ASCII so lines are sliced from data without copying. Lines with invalid UTF-8 are copied
+// with each invalid byte replaced by utf8.RuneError.
+func (r *Reader) scanLine(data []byte, atEOF bool) (int, []byte, error) {
+	start := 0
+	for start < len(data) && (data[start] == '\n' || data[start] == '\r') {
+		start++
+	}
+	if start == len(data) {
+		r.consumed += int64(start)
+		return start, nil, nil
+	}
+
+	// Unknown records being preserved are read up to their line ending
+	limit := lineLength
+	if r.preservingLine(string(data[start : start+1])) {
+		limit = -1
+	}
+
+	var runes int
+	valid := true
+	end := start
+	for end < len(data) && runes != limit {
+		c := data[end]
+		if c == '\n' || c == '\r' {
+			break
+		}
+		if c < utf8.RuneSelf {
+			end++
+		} else {
+			if !atEOF && !utf8.FullRune(data[end:]) {
+				break // wait for the rest of the character
 			}
+			rn, size := utf8.DecodeRune(data[end:])
+			valid = valid && (rn != utf8.RuneError || size > 1)
+			end += size
 		}
+		runes++
+	}
 
-		// reset the read buffer
-		currentLine.Reset()
-		currentLineRuneCount = 0
+	advance := end
+	switch {
+	case runes == limit:
+	case end < len(data):
+		if data[end] == '\n' || data[end] == '\r' {
+			advance++ // drop the line ending
+		} else {
+			return r.requestMore(start)
+		}
+	case !atEOF:
+		return r.requestMore(start)
 	}
-	if err := r.scanner.Err(); err != nil {
-		return r.File, err
+
+	token := data[start:end]
+	if !valid {
+		token = replaceInvalidUTF8(token)
 	}
+	r.lineOffset = r.consumed + int64(start)
+	r.consumed += int64(advance)
+	return advance, token, nil
+}
 
-	// Flush anything that's left over after the scanner completes
-	if currentLineRuneCount > 0 {
-		err := r.readLine(currentLine.String())
-		if err != nil {
-			r.errors.Add(err)
+// replaceInvalidUTF8 returns a copy of line with each invalid byte replaced by utf8.RuneError.
+func replaceInvalidUTF8(line []byte) []byte {
+	out := make([]byte, 0, len(line)+2*utf8.UTFMax)
+	for len(line) > 0 {
+		rn, size := utf8.DecodeRune(line)
+		if rn == utf8.RuneError && size == 1 {
+			out = utf8.AppendRune(out, utf8.RuneError)
+		} else {
+			out = append(out, line[:size]...)
 		}
+		line = line[size:]
+	}
+	return out
+}
+
+// requestMore skips the line endings before a partial line and asks for more input.
+func (r *Reader) requestMore(skipped int) (int, []byte, error) {
+	r.consumed += int64(skipped)
+	return skipped, nil, nil
+}
+
+// processLine hands off a line read from the input to be parsed. An error is returned when the input is too long.
+func (r *Reader) processLine(line string, offset int64) error {
+	r.lineNum++
+	if r.lineNum > r.maxLines {
+		r.errors.Add(ErrFileTooLong)
+		return r.errors
+	}
+
+	// skip the line if it's blank
+	if blankLine(line) {
+		return nil
 	}
+	r.line = ""
+	r.offset = offset
+	if err := r.readLine(line); err != nil {
+		r.errors.Add(err)
+	}
+	r.diagnose(line)
+	return nil
+}
 
+// finishFile checks the File read so far is complete and returns it with any errors encountered.
+func (r *Reader) finishFile() (File, error) {
 	// Add a lingering Batch to the file if there was no BatchControl record.
 	// This is common when files just contain a BatchHeader and EntryDetail records.
 	if r.currentBatch != nil {
@@ -295,6 +416,9 @@ func blankLine(line string) bool {
 }
 
 func (r *Reader) readLine(line string) error {
+	if r.preserveUnknownRecord(line) {
+		return nil
+	}
 	lineLength := utf8.RuneCountInString(line)
 	switch {
 	case r.lineNum == 1 && lineLength > RecordLength:
@@ -347,22 +471,44 @@ func rightPadShortLine(s string) (string, error) {
 }
 
 func (r *Reader) processFixedWidthFile(line string) error {
-	// It should be safe to parse this byte by byte since ACH files are ASCII only.
-	record := ""
-	for i, c := range line {
-		record = record + string(c)
-		if i > 0 && (i+1)%RecordLength == 0 {
-			r.line = record
+	// Slice each record from line, counting characters in case any aren't ASCII.
+	lineOffset := r.offset
+	start, chars := 0, 0
+	for i := range line {
+		chars++
+		if chars%RecordLength == 0 {
+			_, size := utf8.DecodeRuneInString(line[i:])
+			end := i + size
+			r.line = line[start:end]
+			r.offset = lineOffset + int64(start)
 			if err := r.parseLine(); err != nil {
-				return err
+				if !r.lenient {
+					return err
+				}
+				r.errors.Add(err)
+				r.diagnose(line)
 			}
-			record = ""
+			start = end
 		}
 	}
 	return nil
 }
 
 func (r *Reader) parseLine() error {
+	var err error
+	if r.lenient {
+		err = r.parseLineLenient()
+	} else {
+		err = r.parseRecord()
+	}
+	if err == nil && !strings.HasPrefix(r.line, "99") {
+		r.records++
+	}
+	return err
+}
+
+// parseRecord parses r.line with the parser for its record type.
+func (r *Reader) parseRecord() error {
 	switch r.line[:1] {
 	case fileHeaderPos:
 		if err := r.parseFileHeader(); err != nil {
@@ -405,7 +551,7 @@ func (r *Reader) parseLine() error {
 			if !r.skipBatchAccumulation {
 				r.File.AddBatch(batch)
 			}
-			if err := maybeValidate(batch, r.File.validateOpts); err != nil {
+			if err := r.validateBatch(batch); err != nil {
 				r.recordName = "Batches"
 				return r.parseError(err)
 			}
@@ -416,7 +562,7 @@ func (r *Reader) parseLine() error {
 			if !r.skipBatchAccumulation {
 				r.File.AddIATBatch(batch)
 			}
-			if err := maybeValidate(&batch, r.File.validateOpts); err != nil {
+			if err := r.validateIATBatch(&batch); err != nil {
 				r.recordName = "Batches"
 				return r.parseError(err)
 			}
@@ -487,8 +633,9 @@ func (r *Reader) parseFileHeader() error {
 		return ErrFileHeader
 	}
 	r.File.Header.Parse(r.line)
+	r.File.Header.source = r.currentSource()
 
-	if err := maybeValidate(&r.File.Header, r.File.validateOpts); err != nil {
+	if err := r.validate("FileHeader", &r.File.Header); err != nil {
 		return r.parseError(err)
 	}
 	return nil
@@ -502,7 +649,8 @@ func (r *Reader) parseBatchHeader() error {
 	bh := NewBatchHeader()
 	bh.SetValidation(r.File.validateOpts)
 	bh.Parse(r.line)
-	if err := maybeValidate(bh, r.File.validateOpts); err != nil {
+	bh.source = r.currentSource()
+	if err := r.validate("BatchHeader", bh); err != nil {
 		return r.parseError(err)
 	}
 
@@ -527,14 +675,16 @@ func (r *Reader) parseEntryDetail() error {
 		ed := NewEntryDetail()
 		ed.SetValidation(r.File.validateOpts)
 		ed.Parse(r.line)
-		if err := maybeValidate(ed, r.File.validateOpts); err != nil {
+		ed.source = r.currentSource()
+		if err := r.validate("EntryDetail", ed); err != nil {
 			return r.parseError(err)
 		}
 		r.currentBatch.AddEntry(ed)
 	} else {
 		ed := NewADVEntryDetail()
 		ed.Parse(r.line)
-		if err := maybeValidate(ed, r.File.validateOpts); err != nil {
+		ed.source = r.currentSource()
+		if err := r.validate("ADVEntryDetail", ed); err != nil {
 			return r.parseError(err)
 		}
 		r.currentBatch.AddADVEntry(ed)
@@ -553,81 +703,11 @@ func (r *Reader) parseAddenda() error {
 		if len(r.currentBatch.GetEntries()) == 0 {
 			return ErrFileAddendaOutsideEntry
 		}
-		entryIndex := len(r.currentBatch.GetEntries()) - 1
-		entry := r.currentBatch.GetEntries()[entryIndex]
+		entry := r.currentBatch.GetEntries()[len(r.currentBatch.GetEntries())-1]
 
 		if entry.AddendaRecordIndicator == 1 {
-			switch r.line[1:3] {
-			case "02":
-				addenda02 := NewAddenda02()
-				addenda02.Parse(r.line)
-				if err := maybeValidate(addenda02, r.File.validateOpts); err != nil {
-					return r.parseError(err)
-				}
-				r.currentBatch.GetEntries()[entryIndex].Addenda02 = addenda02
-			case "05":
-				addenda05 := NewAddenda05()
-				addenda05.Parse(r.line)
-				if err := maybeValidate(addenda05, r.File.validateOpts); err != nil {
-					return r.parseError(err)
-				}
-				r.currentBatch.GetEntries()[entryIndex].AddAddenda05(addenda05)
-			case "98":
-				// The Addenda98 and Addenda98Refused records have their change code in the same spot,
-				// but refused records have a different set of values.
-				switch {
-				case IsRefusedChangeCode(r.line[3:6]):
-					addenda98Refused := NewAddenda98Refused()
-					addenda98Refused.Parse(r.line)
-					if err := maybeValidate(addenda98Refused, r.File.validateOpts); err != nil {
-						return r.parseError(err)
-					}
-					r.currentBatch.GetEntries()[entryIndex].Category = CategoryNOC
-					r.currentBatch.GetEntries()[entryIndex].Addenda98Refused = addenda98Refused
-
-				default:
-					addenda98 := NewAddenda98()
-					addenda98.Parse(r.line)
-					if err := maybeValidate(addenda98, r.File.validateOpts); err != nil {
-						return r.parseError(err)
-					}
-					r.currentBatch.GetEntries()[entryIndex].Category = CategoryNOC
-					r.currentBatch.GetEntries()[entryIndex].Addenda98 = addenda98
-				}
-			case "99":
-				// Addenda99, Addenda99Dishonored, Addenda99Contested records both have their code
-				// in the same spot, so we need to determine which to parse by the value.
-				switch {
-				case IsDishonoredReturnCode(r.line[3:6]):
-					addenda99Dishonored := NewAddenda99Dishonored()
-					addenda99Dishonored.Parse(r.line)
-					addenda99Dishonored.SetValidation(r.File.validateOpts)
-					if err := maybeValidate(addenda99Dishonored, r.File.validateOpts); err != nil {
-						return r.parseError(err)
-					}
-					r.currentBatch.GetEntries()[entryIndex].Addenda99Dishonored = addenda99Dishonored
-					r.currentBatch.GetEntries()[entryIndex].Category = CategoryDishonoredReturn
-
-				case IsContestedReturnCode(r.line[3:6]):
-					addenda99Contested := NewAddenda99Contested()
-					addenda99Contested.Parse(r.line)
-					addenda99Contested.SetValidation(r.File.validateOpts)
-					if err := maybeValidate(addenda99Contested, r.File.validateOpts); err != nil {
-						return r.parseError(err)
-					}
-					r.currentBatch.GetEntries()[entryIndex].Addenda99Contested = addenda99Contested
-					r.currentBatch.GetEntries()[entryIndex].Category = CategoryDishonoredReturnContested
-
-				default:
-					addenda99 := NewAddenda99()
-					addenda99.Parse(r.line)
-					addenda99.SetValidation(r.File.validateOpts)
-					if err := maybeValidate(addenda99, r.File.validateOpts); err != nil {
-						return r.parseError(err)
-					}
-					r.currentBatch.GetEntries()[entryIndex].Addenda99 = addenda99
-					r.currentBatch.GetEntries()[entryIndex].Category = CategoryReturn
-				}
+			if err := r.parseEntryAddenda(entry); err != nil {
+				return err
 			}
 		} else {
 			return r.parseError(r.currentBatch.Error("AddendaRecordIndicator", ErrBatchAddendaIndicator))
@@ -640,6 +720,90 @@ func (r *Reader) parseAddenda() error {
 	return nil
 }
 
+// parseEntryAddenda parses r.line as an addenda record of entry
+func (r *Reader) parseEntryAddenda(entry *EntryDetail) error {
+	switch r.line[1:3] {
+	case "02":
+		addenda02 := NewAddenda02()
+		addenda02.Parse(r.line)
+		addenda02.source = r.currentSource()
+		if err := r.validate("Addenda02", addenda02); err != nil {
+			return r.parseError(err)
+		}
+		entry.Addenda02 = addenda02
+	case "05":
+		addenda05 := NewAddenda05()
+		addenda05.Parse(r.line)
+		addenda05.source = r.currentSource()
+		if err := r.validate("Addenda05", addenda05); err != nil {
+			return r.parseError(err)
+		}
+		entry.AddAddenda05(addenda05)
+	case "98":
+		// The Addenda98 and Addenda98Refused records have their change code in the same spot,
+		// but refused records have a different set of values.
+		switch {
+		case IsRefusedChangeCode(r.line[3:6]):
+			addenda98Refused := NewAddenda98Refused()
+			addenda98Refused.Parse(r.line)
+			addenda98Refused.source = r.currentSource()
+			if err := r.validate("Addenda98Refused", addenda98Refused); err != nil {
+				return r.parseError(err)
+			}
+			entry.Category = CategoryNOC
+			entry.Addenda98Refused = addenda98Refused
+
+		default:
+			addenda98 := NewAddenda98()
+			addenda98.Parse(r.line)
+			addenda98.source = r.currentSource()
+			if err := r.validate("Addenda98", addenda98); err != nil {
+				return r.parseError(err)
+			}
+			entry.Category = CategoryNOC
+			entry.Addenda98 = addenda98
+		}
+	case "99":
+		// Addenda99, Addenda99Dishonored, Addenda99Contested records both have their code
+		// in the same spot, so we need to determine which to parse by the value.
+		switch {
+		case IsDishonoredReturnCode(r.line[3:6]):
+			addenda99Dishonored := NewAddenda99Dishonored()
+			addenda99Dishonored.Parse(r.line)
+			addenda99Dishonored.source = r.currentSource()
+			addenda99Dishonored.SetValidation(r.File.validateOpts)
+			if err := r.validate("Addenda99Dishonored", addenda99Dishonored); err != nil {
+				return r.parseError(err)
+			}
+			entry.Addenda99Dishonored = addenda99Dishonored
+			entry.Category = CategoryDishonoredReturn
+
+		case IsContestedReturnCode(r.line[3:6]):
+			addenda99Contested := NewAddenda99Contested()
+			addenda99Contested.Parse(r.line)
+			addenda99Contested.source = r.currentSource()
+			addenda99Contested.SetValidation(r.File.validateOpts)
+			if err := r.validate("Addenda99Contested", addenda99Contested); err != nil {
+				return r.parseError(err)
+			}
+			entry.Addenda99Contested = addenda99Contested
+			entry.Category = CategoryDishonoredReturnContested
+
+		default:
+			addenda99 := NewAddenda99()
+			addenda99.Parse(r.line)
+			addenda99.source = r.currentSource()
+			addenda99.SetValidation(r.File.validateOpts)
+			if err := r.validate("Addenda99", addenda99); err != nil {
+				return r.parseError(err)
+			}
+			entry.Addenda99 = addenda99
+			entry.Category = CategoryReturn
+		}
+	}
+	return nil
+}
+
 // parseADVAddenda takes the input record string and create an Addenda99 appended to the last ADVEntryDetail
 func (r *Reader) parseADVAddenda() error {
 	if r.currentBatch == nil {
@@ -649,22 +813,26 @@ func (r *Reader) parseADVAddenda() error {
 		return ErrFileAddendaOutsideEntry
 	}
 
-	entryIndex := len(r.currentBatch.GetADVEntries()) - 1
-	entry := r.currentBatch.GetADVEntries()[entryIndex]
+	entry := r.currentBatch.GetADVEntries()[len(r.currentBatch.GetADVEntries())-1]
 
 	if entry.AddendaRecordIndicator != 1 {
 		return r.parseError(r.currentBatch.Error("AddendaRecordIndicator", ErrBatchAddendaIndicator))
 	}
+	return r.parseADVEntryAddenda(entry)
+}
 
+// parseADVEntryAddenda parses r.line as the Addenda99 of entry
+func (r *Reader) parseADVEntryAddenda(entry *ADVEntryDetail) error {
 	addenda99 := NewAddenda99()
 	addenda99.Parse(r.line)
+	addenda99.source = r.currentSource()
 
-	if err := maybeValidate(addenda99, r.File.validateOpts); err != nil {
+	if err := r.validate("Addenda99", addenda99); err != nil {
 		return r.parseError(err)
 	}
 
-	r.currentBatch.GetADVEntries()[entryIndex].Category = CategoryReturn
-	r.currentBatch.GetADVEntries()[entryIndex].Addenda99 = addenda99
+	entry.Category = CategoryReturn
+	entry.Addenda99 = addenda99
 
 	return nil
 }
@@ -679,19 +847,22 @@ func (r *Reader) parseBatchControl() error {
 	if r.currentBatch != nil {
 		if r.currentBatch.GetHeader().StandardEntryClassCode == ADV {
 			r.currentBatch.GetADVControl().Parse(r.line)
-			if err := maybeValidate(r.currentBatch.GetADVControl(), r.File.validateOpts); err != nil {
+			r.currentBatch.GetADVControl().source = r.currentSource()
+			if err := r.validate("ADVBatchControl", r.currentBatch.GetADVControl()); err != nil {
 				return r.parseError(err)
 			}
 		} else {
 			r.currentBatch.GetControl().SetValidation(r.File.validateOpts)
 			r.currentBatch.GetControl().Parse(r.line)
-			if err := maybeValidate(r.currentBatch.GetControl(), r.File.validateOpts); err != nil {
+			r.currentBatch.GetControl().source = r.currentSource()
+			if err := r.validate("BatchControl", r.currentBatch.GetControl()); err != nil {
 				return r.parseError(err)
 			}
 		}
 	} else {
 		r.IATCurrentBatch.GetControl().Parse(r.line)
-		if err := maybeValidate(r.IATCurrentBatch.GetControl(), r.File.validateOpts); err != nil {
+		r.IATCurrentBatch.GetControl().source = r.currentSource()
+		if err := r.validate("BatchControl", r.IATCurrentBatch.GetControl()); err != nil {
 			return r.parseError(err)
 		}
 
@@ -709,7 +880,8 @@ func (r *Reader) parseFileControl() error {
 			return ErrFileControl
 		}
 		r.File.Control.Parse(r.line)
-		if err := maybeValidate(&r.File.Control, r.File.validateOpts); err != nil {
+		r.File.Control.source = r.currentSource()
+		if err := r.validate("FileControl", &r.File.Control); err != nil {
 			return r.parseError(err)
 		}
 	} else {
@@ -718,7 +890,8 @@ func (r *Reader) parseFileControl() error {
 			return ErrFileControl
 		}
 		r.File.ADVControl.Parse(r.line)
-		if err := maybeValidate(&r.File.ADVControl, r.File.validateOpts); err != nil {
+		r.File.ADVControl.source = r.currentSource()
+		if err := r.validate("ADVFileControl", &r.File.ADVControl); err != nil {
 			return r.parseError(err)
 		}
 	}
@@ -734,7 +907,8 @@ func (r *Reader) parseIATBatchHeader() error {
 	// Ensure we have a valid IAT BatchHeader before building a batch.
 	bh := NewIATBatchHeader()
 	bh.Parse(r.line)
-	if err := maybeValidate(bh, r.File.validateOpts); err != nil {
+	bh.source = r.currentSource()
+	if err := r.validate("IATBatchHeader", bh); err != nil {
 		return r.parseError(err)
 	}
 
@@ -755,7 +929,8 @@ func (r *Reader) parseIATEntryDetail() error {
 
 	ed := NewIATEntryDetail()
 	ed.Parse(r.line)
-	if err := maybeValidate(ed, r.File.validateOpts); err != nil {
+	ed.source = r.currentSource()
+	if err := r.validate("IATEntryDetail", ed); err != nil {
 		return r.parseError(err)
 	}
 	r.IATCurrentBatch.AddEntry(ed)
@@ -769,11 +944,10 @@ func (r *Reader) parseIATAddenda() error {
 	if r.IATCurrentBatch.GetEntries() == nil {
 		return ErrFileAddendaOutsideEntry
 	}
-	entryIndex := len(r.IATCurrentBatch.GetEntries()) - 1
-	entry := r.IATCurrentBatch.GetEntries()[entryIndex]
+	entry := r.IATCurrentBatch.GetEntries()[len(r.IATCurrentBatch.GetEntries())-1]
 
 	if entry.AddendaRecordIndicator == 1 {
-		err := r.switchIATAddenda(entryIndex)
+		err := r.switchIATAddenda(entry)
 		if err != nil {
 			return r.parseError(err)
 		}
@@ -783,23 +957,23 @@ func (r *Reader) parseIATAddenda() error {
 	return nil
 }
 
-func (r *Reader) switchIATAddenda(entryIndex int) error {
+func (r *Reader) switchIATAddenda(entry *IATEntryDetail) error {
 	switch r.line[1:3] {
 	// IAT mandatory and optional Addenda
 	case "10", "11", "12", "13", "14", "15", "16", "17", "18":
-		err := r.mandatoryOptionalIATAddenda(entryIndex)
+		err := r.mandatoryOptionalIATAddenda(entry)
 		if err != nil {
 			return err
 		}
 	// IATNOC
 	case "98":
-		err := r.nocIATAddenda(entryIndex)
+		err := r.nocIATAddenda(entry)
 		if err != nil {
 			return err
 		}
 	// IAT return Addenda
 	case "99":
-		err := r.returnIATAddenda(entryIndex)
+		err := r.returnIATAddenda(entry)
 		if err != nil {
 			return err
 		}
@@ -809,96 +983,107 @@ func (r *Reader) switchIATAddenda(entryIndex int) error {
 
 // mandatoryOptionalIATAddenda parses and validates mandatory IAT addenda records: Addenda10,
 // Addenda11, Addenda12, Addenda13, Addenda14, Addenda15, Addenda16, Addenda17, Addenda18
-func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
+func (r *Reader) mandatoryOptionalIATAddenda(entry *IATEntryDetail) error {
 	switch r.line[1:3] {
 	case "10":
 		addenda10 := NewAddenda10()
 		addenda10.Parse(r.line)
-		if err := maybeValidate(addenda10, r.File.validateOpts); err != nil {
+		addenda10.source = r.currentSource()
+		if err := r.validate("Addenda10", addenda10); err != nil {
 			return err
 		}
-		r.IATCurrentBatch.Entries[entryIndex].Addenda10 = addenda10
+		entry.Addenda10 = addenda10
 	case "11":
 		addenda11 := NewAddenda11()
 		addenda11.Parse(r.line)
-		if err := maybeValidate(addenda11, r.File.validateOpts); err != nil {
+		addenda11.source = r.currentSource()
+		if err := r.validate("Addenda11", addenda11); err != nil {
 			return err
 		}
-		r.IATCurrentBatch.Entries[entryIndex].Addenda11 = addenda11
+		entry.Addenda11 = addenda11
 	case "12":
 		addenda12 := NewAddenda12()
 		addenda12.Parse(r.line)
-		if err := maybeValidate(addenda12, r.File.validateOpts); err != nil {
+		addenda12.source = r.currentSource()
+		if err := r.validate("Addenda12", addenda12); err != nil {
 			return err
 		}
-		r.IATCurrentBatch.Entries[entryIndex].Addenda12 = addenda12
+		entry.Addenda12 = addenda12
 	case "13":
 		addenda13 := NewAddenda13()
 		addenda13.Parse(r.line)
-		if err := maybeValidate(addenda13, r.File.validateOpts); err != nil {
+		addenda13.source = r.currentSource()
+		if err := r.validate("Addenda13", addenda13); err != nil {
 			return err
 		}
-		r.IATCurrentBatch.Entries[entryIndex].Addenda13 = addenda13
+		entry.Addenda13 = addenda13
 	case "14":
 		addenda14 := NewAddenda14()
 		addenda14.Parse(r.line)
-		if err := maybeValidate(addenda14, r.File.validateOpts); err != nil {
+		addenda14.source = r.currentSource()
+		if err := r.validate("Addenda14", addenda14); err != nil {
 			return err
 		}
-		r.IATCurrentBatch.Entries[entryIndex].Addenda14 = addenda14
+		entry.Addenda14 = addenda14
 	case "15":
 		addenda15 := NewAddenda15()
 		addenda15.Parse(r.line)
-		if err := maybeValidate(addenda15, r.File.validateOpts); err != nil {
+		addenda15.source = r.currentSource()
+		if err := r.validate("Addenda15", addenda15); err != nil {
 			return err
 		}
-		r.IATCurrentBatch.Entries[entryIndex].Addenda15 = addenda15
+		entry.Addenda15 = addenda15
 	case "16":
 		addenda16 := NewAddenda16()
 		addenda16.Parse(r.line)
-		if err := maybeValidate(addenda16, r.File.validateOpts); err != nil {
+		addenda16.source = r.currentSource()
+		if err := r.validate("Addenda16", addenda16); err != nil {
 			return err
 		}
-		r.IATCurrentBatch.Entries[entryIndex].Addenda16 = addenda16
+		entry.Addenda16 = addenda16
 	case "17":
 		addenda17 := NewAddenda17()
 		addenda17.Parse(r.line)
-		if err := maybeValidate(addenda17, r.File.validateOpts); err != nil {
+		addenda17.source = r.currentSource()
+		if err := r.validate("Addenda17", addenda17); err != nil {
 			return err
 		}
-		r.IATCurrentBatch.Entries[entryIndex].AddAddenda17(addenda17)
+		entry.AddAddenda17(addenda17)
 	case "18":
 		addenda18 := NewAddenda18()
 		addenda18.Parse(r.line)
-		if err := maybeValidate(addenda18, r.File.validateOpts); err != nil {
+		addenda18.source = r.currentSource()
+		if err := r.validate("Addenda18", addenda18); err != nil {
 			return err
 		}
-		r.IATCurrentBatch.Entries[entryIndex].AddAddenda18(addenda18)
+		entry.AddAddenda18(addenda18)
 	}
 	return nil
 }
 
 // nocIATAddenda parses and validates IAT NOC record Addenda98
-func (r *Reader) nocIATAddenda(entryIndex int) error {
+func (r *Reader) nocIATAddenda(entry *IATEntryDetail) error {
 	addenda98 := NewAddenda98()
 	addenda98.Parse(r.line)
-	if err := maybeValidate(addenda98, r.File.validateOpts); err != nil {
+	addenda98.source = r.currentSource()
+	if err := r.validate("Addenda98", addenda98); err != nil {
 		return err
 	}
-	r.IATCurrentBatch.Entries[entryIndex].Addenda98 = addenda98
-	r.IATCurrentBatch.Entries[entryIndex].Category = CategoryNOC
+	entry.Addenda98 = addenda98
+	entry.Category = CategoryNOC
 	return nil
 }
 
 // returnIATAddenda parses and validates IAT return record Addenda99
-func (r *Reader) returnIATAddenda(entryIndex int) error {
+func (r *Reader) returnIATAddenda(entry *IATEntryDetail) error {
 	addenda99 := NewAddenda99()
 	addenda99.Parse(r.line)
-	if err := maybeValidate(addenda99, r.File.validateOpts); err != nil {
+	addenda99.source = r.currentSource()
+	if err := r.validate("Addenda99", addenda99); err != nil {
 		return err
 	}
-	r.IATCurrentBatch.Entries[entryIndex].Addenda99 = addenda99
-	r.IATCurrentBatch.Entries[entryIndex].Category = CategoryReturn
+	entry.Addenda99 = addenda99
+	entry.Category = CategoryReturn
 	return nil
 }
 
@@ -912,3 +1097,53 @@ func maybeValidate(rec canValidate, opts *ValidateOpts) error {
 	}
 	return rec.Validate()
 }
+
+// validate checks rec, named like the Record of a ValidationFinding, with the ValidateOpts of the File.
+// Problems the Severities downgrade to SeverityWarning are added to the warnings and the first error is returned.
+func (r *Reader) validate(record string, rec recordValidator) error {
+	opts := r.File.validateOpts
+	if opts == nil || len(opts.Severities) == 0 || opts.SkipAll {
+		return maybeValidate(rec, opts)
+	}
+	report := &ValidationReport{opts: opts}
+	report.addRecord(ValidationFinding{Record: record, Line: r.lineNum}, rec)
+	return r.warn(report, r.recordName)
+}
+
+// validateBatch checks batch like validate. Its records were checked as they were read,
+// so only the warnings about the whole batch are added.
+func (r *Reader) validateBatch(batch Batcher) error {
+	opts := r.File.validateOpts
+	if opts == nil || len(opts.Severities) == 0 || opts.SkipAll {
+		return maybeValidate(batch, opts)
+	}
+	report := &ValidationReport{opts: opts}
+	report.validateBatch(0, batch)
+	return r.warn(report, "Batches")
+}
+
+// validateIATBatch checks batch like validateBatch.
+func (r *Reader) validateIATBatch(batch *IATBatch) error {
+	opts := r.File.validateOpts
+	if opts == nil || len(opts.Severities) == 0 || opts.SkipAll {
+		return maybeValidate(batch, opts)
+	}
+	report := &ValidationReport{opts: opts}
+	report.validateIATBatch(0, batch)
+	return r.warn(report, "Batches")
+}
+
+// warn adds the warnings in report to the warnings of the Reader and returns the first error. Batch reports
+// only add the warnings about the whole batch, as its records were checked as they were read.
+func (r *Reader) warn(report *ValidationReport, record string) error {
+	for _, finding := range report.Findings {
+		if finding.Severity == SeverityError {
+			return finding.Err
+		}
+		if record == "Batches" && finding.Record != "Batch" {
+			continue
+		}
+		r.warnings.Add(&base.ParseError{Line: finding.Line, Record: record, Err: finding.Err})
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/moov-io/base"
+)
+
+// ReadDiagnostic is one problem found by Reader.ReadLenient.
+type ReadDiagnostic struct {
+	// Line is the line number (starting at 1) of the record. It is 0 for problems with the whole file,
+	// such as a missing FileControl.
+	Line int
+
+	// Record is the type of record on the line, e.g. "BatchHeader", "IATEntryDetail" or "Addenda05".
+	Record string
+
+	// FieldName is the name of the field with the problem, if known.
+	FieldName string
+
+	// StartColumn and EndColumn are the first and last columns (starting at 1) of FieldName.
+	// They are 0 when the field or its position isn't known.
+	StartColumn int
+	EndColumn   int
+
+	// Raw is the text of the line as it was read.
+	Raw string
+
+	// BatchIndex is the index of the batch in File.Batches, or File.IATBatches when IAT is set, the line
+	// belongs to. Lines which aren't part of a parsed batch belong to the batch before them.
+	// It is -1 for lines before the first batch.
+	BatchIndex int
+
+	// IAT is set when BatchIndex refers to File.IATBatches.
+	IAT bool
+
+	// Err is the problem, usually a *base.ParseError. ErrFileRecordSkipped is given for records
+	// which were skipped after an invalid batch header or entry.
+	Err error
+}
+
+func (d ReadDiagnostic) Error() string {
+	var buf strings.Builder
+	if d.Line > 0 {
+		buf.WriteString(fmt.Sprintf("line %d ", d.Line))
+	}
+	buf.WriteString(d.Record)
+	if d.StartColumn > 0 {
+		buf.WriteString(fmt.Sprintf(" (columns %d-%d)", d.StartColumn, d.EndColumn))
+	}
+	buf.WriteString(fmt.Sprintf(": %v", d.Err))
+	return buf.String()
+}
+
+// Unwrap returns the problem, so errors.Is and errors.As can be used on diagnostics.
+func (d ReadDiagnostic) Unwrap() error {
+	return d.Err
+}
+
+// MarshalJSON writes the diagnostic with its error message, as errors don't encode to JSON.
+func (d ReadDiagnostic) MarshalJSON() ([]byte, error) {
+	var message string
+	if d.Err != nil {
+		message = d.Err.Error()
+	}
+	return json.Marshal(struct {
+		Line        int           `json:"line"`
+		Record      string        `json:"record"`
+		FieldName   string        `json:"fieldName,omitempty"`
+		StartColumn int           `json:"startColumn,omitempty"`
+		EndColumn   int           `json:"endColumn,omitempty"`
+		Raw         string        `json:"raw,omitempty"`
+		BatchIndex  int           `json:"batchIndex"`
+		IAT         bool          `json:"iat,omitempty"`
+		Error       string        `json:"error"`
+		Code        ErrorCode     `json:"code,omitempty"`
+		Category    ErrorCategory `json:"category,omitempty"`
+	}{
+		Line:        d.Line,
+		Record:      d.Record,
+		FieldName:   d.FieldName,
+		StartColumn: d.StartColumn,
+		EndColumn:   d.EndColumn,
+		Raw:         d.Raw,
+		BatchIndex:  d.BatchIndex,
+		IAT:         d.IAT,
+		Error:       message,
+		Code:        CodeOf(d.Err),
+		Category:    CodeOf(d.Err).Category(),
+	})
+}
+
+// ReadLenient reads a File like Read, but keeps parsing past records which are invalid. As much of the
+// File as could be parsed is returned along with a diagnostic for every problem, in the order they were found.
+//
+// Records after an invalid batch header are skipped until the next batch header, as they don't have a
+// batch to be added to. Addenda records of an invalid entry are skipped as well. Invalid and skipped
+// records aren't part of the File, but their text is kept in the diagnostics with the batch they're nearest to.
+//
+// The error is only set when the input couldn't be read.
+func (r *Reader) ReadLenient() (File, []ReadDiagnostic, error) {
+	r.lenient = true
+	defer func() {
+		r.lenient = false
+	}()
+
+	file, err := r.Read()
+	if err != nil {
+		if _, ok := err.(base.ErrorList); !ok {
+			return file, r.diagnostics, err
+		}
+	}
+
+	// Keep an IAT batch which is missing its BatchControl, as Read does for other batches
+	if r.IATCurrentBatch.Header != nil {
+		r.File.AddIATBatch(r.IATCurrentBatch)
+		r.IATCurrentBatch = IATBatch{}
+	}
+
+	// Problems found after the last line, like a missing FileControl
+	r.diagnose("")
+
+	return r.File, r.diagnostics, nil
+}
+
+// parseLineLenient parses r.line, skipping records which can't be added to the File after an earlier
+// record was invalid.
+func (r *Reader) parseLineLenient() error {
+	switch r.line[:1] {
+	case fileHeaderPos, fileControlPos:
+		r.resyncing, r.skipAddenda = false, false
+
+	case batchHeaderPos:
+		r.resyncing, r.skipAddenda = false, false
+
+		// Keep a batch without entries instead of dropping the batch header which follows it
+		if r.currentBatch != nil && len(r.currentBatch.GetEntries()) == 0 {
+			batch := r.currentBatch
+			r.currentBatch = nil
+			batch.SetValidation(r.File.validateOpts)
+			r.File.AddBatch(batch)
+			r.errors.Add(ErrFileConsecutiveBatchHeaders)
+		}
+		// Read replaces an IAT batch which wasn't closed by a BatchControl, so keep it as well
+		if r.IATCurrentBatch.Header != nil {
+			if len(r.IATCurrentBatch.GetEntries()) == 0 {
+				r.errors.Add(ErrFileConsecutiveBatchHeaders)
+			}
+			r.IATCurrentBatch.SetValidation(r.File.validateOpts)
+			r.File.AddIATBatch(r.IATCurrentBatch)
+			r.IATCurrentBatch = IATBatch{}
+		}
+
+	case entryDetailPos:
+		if r.resyncing {
+			return ErrFileRecordSkipped
+		}
+		r.skipAddenda = false
+
+	case entryAddendaPos:
+		if r.resyncing || r.skipAddenda {
+			return ErrFileRecordSkipped
+		}
+
+	default:
+		if r.resyncing {
+			return ErrFileRecordSkipped
+		}
+	}
+
+	err := r.parseRecord()
+	if err != nil {
+		switch r.line[:1] {
+		case batchHeaderPos:
+			r.resyncing = true
+		case entryDetailPos:
+			r.skipAddenda = true
+		}
+	}
+	return err
+}
+
+// diagnose adds a ReadDiagnostic for each error found while reading line. line is empty for problems
+// found after the last line.
+func (r *Reader) diagnose(line string) {
+	if !r.lenient {
+		return
+	}
+	for _, err := range r.errors[r.diagnosed:] {
+		r.diagnostics = append(r.diagnostics, r.diagnostic(line, err))
+	}
+	r.diagnosed = len(r.errors)
+
+	switch {
+	case r.currentBatch != nil:
+		r.lastBatchIAT = false
+	case r.IATCurrentBatch.Header != nil:
+		r.lastBatchIAT = true
+	}
+}
+
+func (r *Reader) diagnostic(line string, err error) ReadDiagnostic {
+	d := ReadDiagnostic{
+		Line:       r.lineNum,
+		Raw:        line,
+		BatchIndex: -1,
+		Err:        err,
+	}
+	if line == "" {
+		d.Line = 0
+		d.Record = r.recordName
+		return d
+	}
+
+	// Fixed width files have every record on one line, so only keep the record being parsed
+	record := line
+	if r.line != "" && utf8.RuneCountInString(line) > RecordLength {
+		d.Raw = r.line
+	}
+	if r.line != "" {
+		record = r.line
+	}
+	d.BatchIndex, d.IAT = r.nearestBatch()
+	d.Record = r.recordType(record, d.BatchIndex, d.IAT)
+
+	var fe *FieldError
+	var be *BatchError
+	switch {
+	case errors.As(err, &fe):
+		d.FieldName = fe.FieldName
+	case errors.As(err, &be):
+		d.FieldName = be.FieldName
+	}
+	d.StartColumn, d.EndColumn = fieldColumns(d.Record, d.FieldName)
+	return d
+}
+
+// nearestBatch returns the index of the batch being read, or of the last batch read.
+func (r *Reader) nearestBatch() (int, bool) {
+	switch {
+	case r.currentBatch != nil:
+		return len(r.File.Batches), false
+	case r.IATCurrentBatch.Header != nil:
+		return len(r.File.IATBatches), true
+	case r.lastBatchIAT:
+		return len(r.File.IATBatches) - 1, true
+	}
+	return len(r.File.Batches) - 1, false
+}
+
+// recordType returns the name of the type of record in line, which belongs to the batch at batchIndex.
+func (r *Reader) recordType(line string, batchIndex int, iat bool) string {
+	if len(line) < RecordLength {
+		return r.recordName
+	}
+	adv := false
+	if !iat {
+		switch {
+		case r.currentBatch != nil:
+			adv = r.currentBatch.GetHeader().StandardEntryClassCode == ADV
+		case batchIndex >= 0 && batchIndex < len(r.File.Batches):
+			adv = r.File.Batches[batchIndex].GetHeader().StandardEntryClassCode == ADV
+		}
+	}
+
+	switch line[:1] {
+	case fileHeaderPos:
+		return "FileHeader"
+	case batchHeaderPos:
+		if line[50:53] == IAT || strings.TrimSpace(line[04:20]) == IATCOR {
+			return "IATBatchHeader"
+		}
+		return "BatchHeader"
+	case entryDetailPos:
+		switch {
+		case iat:
+			return "IATEntryDetail"
+		case adv:
+			return "ADVEntryDetail"
+		}
+		return "EntryDetail"
+	case entryAddendaPos:
+		return "Addenda" + line[1:3]
+	case batchControlPos:
+		if adv {
+			return "ADVBatchControl"
+		}
+		return "BatchControl"
+	case fileControlPos:
+		if r.File.IsADV() {
+			return "ADVFileControl"
+		}
+		return "FileControl"
+	}
+	return r.recordName
+}
@@ -0,0 +1,156 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readLenientLines(t *testing.T, lines []string) (File, []ReadDiagnostic) {
+	t.Helper()
+
+	file, diagnostics, err := NewReader(strings.NewReader(strings.Join(lines, "\n"))).ReadLenient()
+	require.NoError(t, err)
+	return file, diagnostics
+}
+
+func TestReader__ReadLenient(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("test", "testdata", "flattenBatchesMultipleBatchHeaders.ach"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(bs)), "\n")
+
+	lines[1] = "5999" + lines[1][4:]  // invalid ServiceClassCode in the first batch header
+	lines[10] = "699" + lines[10][3:] // invalid TransactionCode of the first entry in the second batch
+
+	file, diagnostics := readLenientLines(t, lines)
+	require.Len(t, diagnostics, 11)
+
+	// the first batch is skipped
+	require.Equal(t, 2, diagnostics[0].Line)
+	require.Equal(t, "BatchHeader", diagnostics[0].Record)
+	require.Equal(t, "ServiceClassCode", diagnostics[0].FieldName)
+	require.Equal(t, 2, diagnostics[0].StartColumn)
+	require.Equal(t, 4, diagnostics[0].EndColumn)
+	require.Equal(t, -1, diagnostics[0].BatchIndex)
+	require.Equal(t, lines[1], diagnostics[0].Raw)
+	for _, d := range diagnostics[1:8] {
+		require.ErrorIs(t, d, ErrFileRecordSkipped)
+		require.Equal(t, lines[d.Line-1], d.Raw)
+		require.Equal(t, -1, d.BatchIndex)
+	}
+	require.Equal(t, "BatchControl", diagnostics[7].Record)
+
+	// the invalid entry and its addenda are skipped, so the batch is out of balance
+	require.Equal(t, 11, diagnostics[8].Line)
+	require.Equal(t, "EntryDetail", diagnostics[8].Record)
+	require.Equal(t, "TransactionCode", diagnostics[8].FieldName)
+	require.Equal(t, 2, diagnostics[8].StartColumn)
+	require.Equal(t, 3, diagnostics[8].EndColumn)
+	require.Equal(t, 0, diagnostics[8].BatchIndex)
+	require.Equal(t, "line 11 EntryDetail (columns 2-3): line:11 record:EntryDetail *ach.FieldError TransactionCode 99 is an invalid Transaction Code", diagnostics[8].Error())
+
+	require.Equal(t, 12, diagnostics[9].Line)
+	require.Equal(t, "Addenda05", diagnostics[9].Record)
+	require.ErrorIs(t, diagnostics[9], ErrFileRecordSkipped)
+
+	require.Equal(t, 17, diagnostics[10].Line)
+	require.Equal(t, "BatchControl", diagnostics[10].Record)
+	require.Equal(t, 0, diagnostics[10].BatchIndex)
+	require.ErrorIs(t, diagnostics[10], NewErrBatchCalculatedControlEquality(4, 6))
+
+	// the rest of the file is read
+	require.Equal(t, "231380104", file.Header.ImmediateDestination)
+	require.Len(t, file.Batches, 3)
+	require.Equal(t, 2, file.Batches[0].GetHeader().BatchNumber)
+	require.Len(t, file.Batches[0].GetEntries(), 2)
+	require.Equal(t, 3, file.Batches[1].GetHeader().BatchNumber)
+	require.Len(t, file.Batches[1].GetEntries(), 3)
+	require.Equal(t, 4, file.Control.BatchCount)
+}
+
+func TestReader__ReadLenientMissingRecords(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(bs)), "\n")
+
+	// consecutive batch headers and no FileControl
+	lines = append(lines[:2], lines[1:4]...)
+	file, diagnostics := readLenientLines(t, lines)
+	require.Len(t, diagnostics, 2)
+
+	require.Equal(t, 3, diagnostics[0].Line)
+	require.Equal(t, 1, diagnostics[0].BatchIndex)
+	require.ErrorIs(t, diagnostics[0], ErrFileConsecutiveBatchHeaders)
+
+	require.Equal(t, 0, diagnostics[1].Line)
+	require.Equal(t, "FileControl", diagnostics[1].Record)
+	require.Empty(t, diagnostics[1].Raw)
+	require.ErrorIs(t, diagnostics[1], ErrFileControl)
+
+	require.Len(t, file.Batches, 2)
+	require.Empty(t, file.Batches[0].GetEntries())
+	require.Len(t, file.Batches[1].GetEntries(), 1)
+
+	bs, err = json.Marshal(diagnostics[0])
+	require.NoError(t, err)
+	require.Contains(t, string(bs), `"line":3,"record":"BatchHeader"`)
+	require.Contains(t, string(bs), `"code":"ACH-FILE-CONSECUTIVE-BATCH-HEADERS","category":"file"`)
+}
+
+func TestReader__ReadLenientError(t *testing.T) {
+	_, diagnostics, err := NewReader(iotest.ErrReader(errors.New("bad read"))).ReadLenient()
+	require.EqualError(t, err, "nil scanner")
+	require.Empty(t, diagnostics)
+}
+
+func TestReader__ReadLenientIAT(t *testing.T) {
+	fd, err := os.Open(filepath.Join("test", "testdata", "iat-invalidEntryDetail.ach"))
+	require.NoError(t, err)
+	defer fd.Close()
+
+	file, diagnostics, err := NewReader(fd).ReadLenient()
+	require.NoError(t, err)
+	require.Len(t, diagnostics, 13)
+
+	require.Equal(t, "IATEntryDetail", diagnostics[1].Record)
+	require.Equal(t, 0, diagnostics[1].BatchIndex)
+	require.True(t, diagnostics[1].IAT)
+	for _, d := range diagnostics[2:11] {
+		require.ErrorIs(t, d, ErrFileRecordSkipped)
+		require.True(t, d.IAT)
+	}
+	require.Equal(t, "Addenda10", diagnostics[2].Record)
+
+	// the IAT batch without entries is kept
+	require.Equal(t, 14, diagnostics[12].Line)
+	require.Equal(t, "IATBatchHeader", diagnostics[12].Record)
+	require.Equal(t, 1, diagnostics[12].BatchIndex)
+	require.ErrorIs(t, diagnostics[12], ErrFileConsecutiveBatchHeaders)
+
+	require.Len(t, file.IATBatches, 2)
+	require.Empty(t, file.IATBatches[0].GetEntries())
+	require.Len(t, file.IATBatches[1].GetEntries(), 1)
+}
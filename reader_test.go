@@ -27,6 +27,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/moov-io/base"
 	"github.com/stretchr/testify/require"
@@ -2018,6 +2019,29 @@ func TestReader__LongLine(t *testing.T) {
 	}
 }
 
+func TestReader__SmallReads(t *testing.T) {
+	for _, name := range []string{"ppd-debit.ach", "ppd-debit-fixedLength.ach", "web-debit.ach"} {
+		bs, err := os.ReadFile(filepath.Join("test", "testdata", name))
+		require.NoError(t, err)
+
+		expected, err := NewReader(bytes.NewReader(bs)).Read()
+		require.NoError(t, err)
+
+		// lines split across reads are put back together
+		file, err := NewReader(iotest.OneByteReader(bytes.NewReader(bs))).Read()
+		require.NoError(t, err, name)
+		require.Equal(t, expected.Header, file.Header, name)
+		require.Equal(t, expected.Batches[0].GetEntries(), file.Batches[0].GetEntries(), name)
+		require.Equal(t, expected.Control, file.Control, name)
+		require.Equal(t, expected.Batches[0].GetEntries()[0].SourceOffset(), file.Batches[0].GetEntries()[0].SourceOffset())
+	}
+}
+
+func TestReader__replaceInvalidUTF8(t *testing.T) {
+	// each invalid byte is replaced, like reading the input one character at a time
+	require.Equal(t, "a\uFFFD\uFFFDb’", string(replaceInvalidUTF8([]byte("a\xff\xfeb’"))))
+}
+
 func TestReader__morphing(t *testing.T) {
 	out := trimSpacesFromLongLine(strings.Repeat("a", 94) + "    ")
 	if len(out) != 94 {
@@ -2176,3 +2200,62 @@ func TestReadFile_PreserveSpacesOptEnabled(t *testing.T) {
 		t.Errorf("Expected company id: '%s', Actual: '%s'", expectedCompanyId, batchControlCompanyId)
 	}
 }
+
+func TestReader__Severities(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	input := strings.Replace(string(bs), "Receiver Account Name", "Receiver Account Näme", 1)
+
+	// the entry is an error by default
+	r := NewReader(strings.NewReader(input))
+	_, err = r.Read()
+	require.True(t, base.Has(err, ErrNonAlphanumeric))
+	require.Empty(t, r.Warnings())
+
+	opts := &ValidateOpts{
+		Severities: map[string]Severity{"IndividualName": SeverityWarning},
+	}
+	r = NewReader(strings.NewReader(input))
+	r.SetValidation(opts)
+	file, err := r.Read()
+	require.NoError(t, err)
+	require.Len(t, file.Batches, 1)
+	require.Len(t, file.Batches[0].GetEntries(), 1)
+	require.Contains(t, file.Batches[0].GetEntries()[0].IndividualName, "Näme")
+
+	warnings := r.Warnings()
+	require.Len(t, warnings, 1)
+	require.ErrorIs(t, warnings[0], ErrNonAlphanumeric)
+	var pe *base.ParseError
+	require.ErrorAs(t, warnings[0], &pe)
+	require.Equal(t, 3, pe.Line)
+	require.Equal(t, "EntryDetail", pe.Record)
+
+	report := file.ValidateAllWith(opts)
+	require.True(t, report.Valid())
+	require.Len(t, report.Warnings(), 1)
+	require.NoError(t, file.ValidateWith(opts))
+}
+
+func TestReader__SeveritiesBatch(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+	// trace numbers are no longer ascending
+	input := strings.Replace(string(bs), "Credit Account 1        0121042880000002", "Credit Account 1        0121042880000005", 1)
+
+	opts := &ValidateOpts{
+		Severities: map[string]Severity{"Batch.TraceNumber": SeverityWarning},
+	}
+	r := NewReader(strings.NewReader(input))
+	r.SetValidation(opts)
+	file, err := r.Read()
+	require.NoError(t, err)
+	require.Len(t, file.Batches, 1)
+
+	warnings := r.Warnings()
+	require.Len(t, warnings, 1)
+	require.ErrorContains(t, warnings[0], "must be in ascending order")
+	var pe *base.ParseError
+	require.ErrorAs(t, warnings[0], &pe)
+	require.Equal(t, "Batches", pe.Record)
+}
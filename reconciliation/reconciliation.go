@@ -0,0 +1,300 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package reconciliation matches returns and Notifications of Change back to the
+// entries of originated ACH files by their trace numbers.
+//
+//	idx := reconciliation.NewIndex(originated...)
+//	report := idx.Reconcile(returnFiles...)
+//	for _, m := range report.Matched {
+//	    fmt.Printf("%s was returned with %s\n", m.Original.TraceNumber, m.Response.Code)
+//	}
+package reconciliation
+
+import (
+	"github.com/moov-io/ach"
+)
+
+// Kind is the type of a received Response
+type Kind string
+
+const (
+	// KindReturn is given for entries with an Addenda99 record
+	KindReturn Kind = "return"
+	// KindNOC is given for entries with an Addenda98 record
+	KindNOC Kind = "noc"
+)
+
+// Original is an entry of an originated file
+type Original struct {
+	// FileID is the ID of the originated ach.File
+	FileID      string `json:"fileID,omitempty"`
+	BatchNumber int    `json:"batchNumber"`
+	SEC         string `json:"sec"`
+	IAT         bool   `json:"iat,omitempty"`
+
+	TraceNumber        string `json:"traceNumber"`
+	TransactionCode    int    `json:"transactionCode"`
+	RDFIIdentification string `json:"rdfiIdentification"`
+	DFIAccountNumber   string `json:"dfiAccountNumber"`
+	Amount             int    `json:"amount"`
+}
+
+// Response is a return or NOC entry received in response to an Original
+type Response struct {
+	Kind Kind `json:"kind"`
+
+	// FileID is the ID of the received ach.File
+	FileID      string `json:"fileID,omitempty"`
+	BatchNumber int    `json:"batchNumber"`
+	IAT         bool   `json:"iat,omitempty"`
+
+	// TraceNumber is the trace number of the return or NOC entry and OriginalTrace
+	// is the trace number of the Original it responds to.
+	TraceNumber   string `json:"traceNumber"`
+	OriginalTrace string `json:"originalTrace"`
+
+	// Code is the return or change code
+	Code            string `json:"code"`
+	TransactionCode int    `json:"transactionCode"`
+	Amount          int    `json:"amount"`
+}
+
+// Match pairs a Response with the Original it responds to
+type Match struct {
+	Original Original `json:"original"`
+	Response Response `json:"response"`
+}
+
+// Duplicate is reported when more than one Original has the same trace number or when an
+// Original received more than one Response of the same Kind. Duplicates are never matched.
+type Duplicate struct {
+	OriginalTrace string     `json:"originalTrace"`
+	Originals     []Original `json:"originals"`
+	Responses     []Response `json:"responses"`
+}
+
+// Report is the result of reconciling received files against an Index
+type Report struct {
+	// Matched holds each Response which matched exactly one Original
+	Matched []Match `json:"matched"`
+
+	// Unmatched holds each Response without an Original
+	Unmatched []Response `json:"unmatched"`
+
+	// AmountMismatches holds returns whose amount differs from their Original
+	AmountMismatches []Match `json:"amountMismatches"`
+
+	// Duplicates holds ambiguous Originals and repeated Responses
+	Duplicates []Duplicate `json:"duplicates"`
+}
+
+// Index holds the entries of originated files by trace number
+type Index struct {
+	originals map[string][]Original
+}
+
+// NewIndex returns an Index of the entries in files
+func NewIndex(files ...*ach.File) *Index {
+	idx := &Index{
+		originals: make(map[string][]Original),
+	}
+	for i := range files {
+		idx.Add(files[i])
+	}
+	return idx
+}
+
+// Add indexes each forward entry of file. Returns and NOCs in file are skipped.
+func (idx *Index) Add(file *ach.File) {
+	if file == nil {
+		return
+	}
+	for _, batch := range file.Batches {
+		bh := batch.GetHeader()
+		if bh == nil {
+			continue
+		}
+		for _, entry := range batch.GetEntries() {
+			if entry.Category != ach.CategoryForward {
+				continue
+			}
+			idx.add(Original{
+				FileID:             file.ID,
+				BatchNumber:        bh.BatchNumber,
+				SEC:                bh.StandardEntryClassCode,
+				TraceNumber:        entry.TraceNumberField(),
+				TransactionCode:    entry.TransactionCode,
+				RDFIIdentification: entry.RDFIIdentificationField(),
+				DFIAccountNumber:   entry.DFIAccountNumber,
+				Amount:             entry.Amount,
+			})
+		}
+	}
+	for _, batch := range file.IATBatches {
+		if batch.Header == nil {
+			continue
+		}
+		for _, entry := range batch.Entries {
+			if entry.Category != ach.CategoryForward {
+				continue
+			}
+			idx.add(Original{
+				FileID:             file.ID,
+				BatchNumber:        batch.Header.BatchNumber,
+				SEC:                ach.IAT,
+				IAT:                true,
+				TraceNumber:        entry.TraceNumberField(),
+				TransactionCode:    entry.TransactionCode,
+				RDFIIdentification: entry.RDFIIdentificationField(),
+				DFIAccountNumber:   entry.DFIAccountNumber,
+				Amount:             entry.Amount,
+			})
+		}
+	}
+}
+
+func (idx *Index) add(o Original) {
+	idx.originals[o.TraceNumber] = append(idx.originals[o.TraceNumber], o)
+}
+
+// Len returns the number of indexed entries
+func (idx *Index) Len() int {
+	var n int
+	for _, originals := range idx.originals {
+		n += len(originals)
+	}
+	return n
+}
+
+// Reconcile matches every return and NOC entry in files against the Index.
+func (idx *Index) Reconcile(files ...*ach.File) *Report {
+	report := &Report{
+		Matched:          []Match{},
+		Unmatched:        []Response{},
+		AmountMismatches: []Match{},
+		Duplicates:       []Duplicate{},
+	}
+
+	// Collect responses in file order, grouping repeated responses to the same Original
+	type key struct {
+		kind  Kind
+		trace string
+	}
+	var order []key
+	grouped := make(map[key][]Response)
+	for _, file := range files {
+		for _, resp := range responses(file) {
+			k := key{kind: resp.Kind, trace: resp.OriginalTrace}
+			if _, exists := grouped[k]; !exists {
+				order = append(order, k)
+			}
+			grouped[k] = append(grouped[k], resp)
+		}
+	}
+
+	for _, k := range order {
+		resps := grouped[k]
+		originals := idx.originals[k.trace]
+
+		switch {
+		case len(originals) == 0:
+			report.Unmatched = append(report.Unmatched, resps...)
+
+		case len(originals) > 1 || len(resps) > 1:
+			report.Duplicates = append(report.Duplicates, Duplicate{
+				OriginalTrace: k.trace,
+				Originals:     originals,
+				Responses:     resps,
+			})
+
+		default:
+			m := Match{Original: originals[0], Response: resps[0]}
+			if m.Response.Kind == KindReturn && m.Response.Amount != m.Original.Amount {
+				report.AmountMismatches = append(report.AmountMismatches, m)
+			} else {
+				report.Matched = append(report.Matched, m)
+			}
+		}
+	}
+	return report
+}
+
+// responses returns each return and NOC entry of file
+func responses(file *ach.File) []Response {
+	if file == nil {
+		return nil
+	}
+	var out []Response
+	for _, batch := range file.Batches {
+		bh := batch.GetHeader()
+		if bh == nil {
+			continue
+		}
+		for _, entry := range batch.GetEntries() {
+			resp := Response{
+				FileID:          file.ID,
+				BatchNumber:     bh.BatchNumber,
+				TraceNumber:     entry.TraceNumberField(),
+				TransactionCode: entry.TransactionCode,
+				Amount:          entry.Amount,
+			}
+			switch {
+			case entry.Addenda99 != nil:
+				resp.Kind = KindReturn
+				resp.OriginalTrace = entry.Addenda99.OriginalTraceField()
+				resp.Code = entry.Addenda99.ReturnCode
+			case entry.Addenda98 != nil:
+				resp.Kind = KindNOC
+				resp.OriginalTrace = entry.Addenda98.OriginalTraceField()
+				resp.Code = entry.Addenda98.ChangeCode
+			default:
+				continue
+			}
+			out = append(out, resp)
+		}
+	}
+	for _, batch := range file.IATBatches {
+		if batch.Header == nil {
+			continue
+		}
+		for _, entry := range batch.Entries {
+			resp := Response{
+				FileID:          file.ID,
+				BatchNumber:     batch.Header.BatchNumber,
+				IAT:             true,
+				TraceNumber:     entry.TraceNumberField(),
+				TransactionCode: entry.TransactionCode,
+				Amount:          entry.Amount,
+			}
+			switch {
+			case entry.Addenda99 != nil:
+				resp.Kind = KindReturn
+				resp.OriginalTrace = entry.Addenda99.OriginalTraceField()
+				resp.Code = entry.Addenda99.ReturnCode
+			case entry.Addenda98 != nil:
+				resp.Kind = KindNOC
+				resp.OriginalTrace = entry.Addenda98.OriginalTraceField()
+				resp.Code = entry.Addenda98.ChangeCode
+			default:
+				continue
+			}
+			out = append(out, resp)
+		}
+	}
+	return out
+}
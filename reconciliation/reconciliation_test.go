@@ -0,0 +1,136 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package reconciliation
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/ach"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readFile(t *testing.T, name string) *ach.File {
+	t.Helper()
+
+	file, err := ach.ReadFile(filepath.Join("..", "test", "testdata", name))
+	require.NoError(t, err)
+	return file
+}
+
+func TestReconcile(t *testing.T) {
+	ppd := readFile(t, "ppd-debit.ach")
+	iat := readFile(t, "iat-debit.ach")
+
+	idx := NewIndex(ppd, iat)
+	require.Equal(t, 2, idx.Len())
+
+	ppdReturns, err := ppd.CreateReturns([]ach.ReturnEntry{{TraceNumber: "121042880000001", ReturnCode: "R01"}})
+	require.NoError(t, err)
+	iatReturns, err := iat.CreateReturns([]ach.ReturnEntry{{TraceNumber: "231380100000001", ReturnCode: "R03"}})
+	require.NoError(t, err)
+
+	report := idx.Reconcile(ppdReturns, iatReturns)
+	require.Len(t, report.Matched, 2)
+	require.Empty(t, report.Unmatched)
+	require.Empty(t, report.AmountMismatches)
+	require.Empty(t, report.Duplicates)
+
+	m := report.Matched[0]
+	require.Equal(t, KindReturn, m.Response.Kind)
+	require.Equal(t, "R01", m.Response.Code)
+	require.Equal(t, "121042880000001", m.Original.TraceNumber)
+	require.Equal(t, ach.PPD, m.Original.SEC)
+
+	m = report.Matched[1]
+	require.True(t, m.Original.IAT)
+	require.True(t, m.Response.IAT)
+	require.Equal(t, "231380100000001", m.Response.OriginalTrace)
+}
+
+func TestReconcile__NOC(t *testing.T) {
+	ppd := readFile(t, "ppd-debit.ach")
+
+	cor, err := ppd.CreateNotificationOfChange([]ach.ChangeEntry{
+		{TraceNumber: "121042880000001", ChangeCode: "C01", CorrectedData: &ach.CorrectedData{AccountNumber: "54321"}},
+	})
+	require.NoError(t, err)
+
+	report := NewIndex(ppd).Reconcile(cor)
+	require.Len(t, report.Matched, 1)
+	require.Equal(t, KindNOC, report.Matched[0].Response.Kind)
+	require.Equal(t, "C01", report.Matched[0].Response.Code)
+}
+
+func TestReconcile__Problems(t *testing.T) {
+	ppd := readFile(t, "ppd-debit.ach")
+	idx := NewIndex(ppd)
+
+	returns, err := ppd.CreateReturns([]ach.ReturnEntry{{TraceNumber: "121042880000001", ReturnCode: "R01"}})
+	require.NoError(t, err)
+
+	t.Run("unmatched", func(t *testing.T) {
+		report := NewIndex().Reconcile(returns)
+		require.Len(t, report.Unmatched, 1)
+		require.Equal(t, "121042880000001", report.Unmatched[0].OriginalTrace)
+	})
+
+	t.Run("amount mismatch", func(t *testing.T) {
+		other, err := ppd.CreateReturns([]ach.ReturnEntry{{TraceNumber: "121042880000001", ReturnCode: "R01"}})
+		require.NoError(t, err)
+		other.Batches[0].GetEntries()[0].Amount = 5000
+
+		report := idx.Reconcile(other)
+		require.Empty(t, report.Matched)
+		require.Len(t, report.AmountMismatches, 1)
+		require.Equal(t, 5000, report.AmountMismatches[0].Response.Amount)
+		require.Equal(t, 100000000, report.AmountMismatches[0].Original.Amount)
+	})
+
+	t.Run("duplicate returns", func(t *testing.T) {
+		report := idx.Reconcile(returns, returns)
+		require.Empty(t, report.Matched)
+		require.Len(t, report.Duplicates, 1)
+		require.Len(t, report.Duplicates[0].Responses, 2)
+		require.Len(t, report.Duplicates[0].Originals, 1)
+	})
+
+	t.Run("duplicate originals", func(t *testing.T) {
+		report := NewIndex(ppd, ppd).Reconcile(returns)
+		require.Empty(t, report.Matched)
+		require.Len(t, report.Duplicates, 1)
+		require.Len(t, report.Duplicates[0].Originals, 2)
+	})
+}
+
+func TestReport__JSON(t *testing.T) {
+	ppd := readFile(t, "ppd-debit.ach")
+	returns, err := ppd.CreateReturns([]ach.ReturnEntry{{TraceNumber: "121042880000001", ReturnCode: "R01"}})
+	require.NoError(t, err)
+
+	bs, err := json.Marshal(NewIndex(ppd).Reconcile(returns))
+	require.NoError(t, err)
+
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal(bs, &out))
+	require.Len(t, out["matched"], 1)
+	require.Len(t, out["unmatched"], 0)
+	require.Contains(t, string(bs), `"originalTrace":"121042880000001"`)
+}
@@ -0,0 +1,174 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+// recordColumns are the first and last columns (starting at 1) of the fields of each record type,
+// as laid out by the NACHA rules.
+var recordColumns = map[string]map[string][2]int{
+	"FileHeader": {
+		"PriorityCode":             {2, 3},
+		"ImmediateDestination":     {4, 13},
+		"ImmediateOrigin":          {14, 23},
+		"FileCreationDate":         {24, 29},
+		"FileCreationTime":         {30, 33},
+		"FileIDModifier":           {34, 34},
+		"RecordSize":               {35, 37},
+		"BlockingFactor":           {38, 39},
+		"FormatCode":               {40, 40},
+		"ImmediateDestinationName": {41, 63},
+		"ImmediateOriginName":      {64, 86},
+		"ReferenceCode":            {87, 94},
+	},
+	"BatchHeader": {
+		"ServiceClassCode":         {2, 4},
+		"CompanyName":              {5, 20},
+		"CompanyDiscretionaryData": {21, 40},
+		"CompanyIdentification":    {41, 50},
+		"StandardEntryClassCode":   {51, 53},
+		"CompanyEntryDescription":  {54, 63},
+		"CompanyDescriptiveDate":   {64, 69},
+		"EffectiveEntryDate":       {70, 75},
+		"SettlementDate":           {76, 78},
+		"OriginatorStatusCode":     {79, 79},
+		"ODFIIdentification":       {80, 87},
+		"BatchNumber":              {88, 94},
+	},
+	"EntryDetail": {
+		"TransactionCode":        {2, 3},
+		"RDFIIdentification":     {4, 11},
+		"CheckDigit":             {12, 12},
+		"DFIAccountNumber":       {13, 29},
+		"Amount":                 {30, 39},
+		"IdentificationNumber":   {40, 54},
+		"IndividualName":         {55, 76},
+		"DiscretionaryData":      {77, 78},
+		"AddendaRecordIndicator": {79, 79},
+		"TraceNumber":            {80, 94},
+	},
+	"Addenda02": {
+		"TypeCode":                      {2, 3},
+		"ReferenceInformationOne":       {4, 10},
+		"ReferenceInformationTwo":       {11, 13},
+		"TerminalIdentificationCode":    {14, 19},
+		"TransactionSerialNumber":       {20, 25},
+		"TransactionDate":               {26, 29},
+		"AuthorizationCodeOrExpireDate": {30, 35},
+		"TerminalLocation":              {36, 62},
+		"TerminalCity":                  {63, 77},
+		"TerminalState":                 {78, 79},
+		"TraceNumber":                   {80, 94},
+	},
+	"Addenda05": {
+		"TypeCode":                  {2, 3},
+		"PaymentRelatedInformation": {4, 83},
+		"SequenceNumber":            {84, 87},
+		"EntryDetailSequenceNumber": {88, 94},
+	},
+	"Addenda98": {
+		"TypeCode":      {2, 3},
+		"ChangeCode":    {4, 6},
+		"OriginalTrace": {7, 21},
+		"OriginalDFI":   {28, 35},
+		"CorrectedData": {36, 64},
+		"TraceNumber":   {80, 94},
+	},
+	"Addenda99": {
+		"TypeCode":           {2, 3},
+		"ReturnCode":         {4, 6},
+		"OriginalTrace":      {7, 21},
+		"DateOfDeath":        {22, 27},
+		"OriginalDFI":        {28, 35},
+		"AddendaInformation": {36, 79},
+		"TraceNumber":        {80, 94},
+	},
+	"BatchControl": {
+		"ServiceClassCode":             {2, 4},
+		"EntryAddendaCount":            {5, 10},
+		"EntryHash":                    {11, 20},
+		"TotalDebitEntryDollarAmount":  {21, 32},
+		"TotalCreditEntryDollarAmount": {33, 44},
+		"CompanyIdentification":        {45, 54},
+		"MessageAuthenticationCode":    {55, 73},
+		"ODFIIdentification":           {80, 87},
+		"BatchNumber":                  {88, 94},
+	},
+	"FileControl": {
+		"BatchCount":                         {2, 7},
+		"BlockCount":                         {8, 13},
+		"EntryAddendaCount":                  {14, 21},
+		"EntryHash":                          {22, 31},
+		"TotalDebitEntryDollarAmountInFile":  {32, 43},
+		"TotalCreditEntryDollarAmountInFile": {44, 55},
+	},
+	"IATBatchHeader": {
+		"ServiceClassCode":                  {2, 4},
+		"IATIndicator":                      {5, 20},
+		"ForeignExchangeIndicator":          {21, 22},
+		"ForeignExchangeReferenceIndicator": {23, 23},
+		"ForeignExchangeReference":          {24, 38},
+		"ISODestinationCountryCode":         {39, 40},
+		"OriginatorIdentification":          {41, 50},
+		"StandardEntryClassCode":            {51, 53},
+		"CompanyEntryDescription":           {54, 63},
+		"ISOOriginatingCurrencyCode":        {64, 66},
+		"ISODestinationCurrencyCode":        {67, 69},
+		"EffectiveEntryDate":                {70, 75},
+		"SettlementDate":                    {76, 78},
+		"OriginatorStatusCode":              {79, 79},
+		"ODFIIdentification":                {80, 87},
+		"BatchNumber":                       {88, 94},
+	},
+	"IATEntryDetail": {
+		"TransactionCode":                 {2, 3},
+		"RDFIIdentification":              {4, 11},
+		"CheckDigit":                      {12, 12},
+		"AddendaRecords":                  {13, 16},
+		"Amount":                          {30, 39},
+		"DFIAccountNumber":                {40, 74},
+		"OFACScreeningIndicator":          {77, 77},
+		"SecondaryOFACScreeningIndicator": {78, 78},
+		"AddendaRecordIndicator":          {79, 79},
+		"TraceNumber":                     {80, 94},
+	},
+	"ADVEntryDetail": {
+		"TransactionCode":          {2, 3},
+		"RDFIIdentification":       {4, 11},
+		"CheckDigit":               {12, 12},
+		"DFIAccountNumber":         {13, 27},
+		"Amount":                   {28, 39},
+		"AdviceRoutingNumber":      {40, 48},
+		"FileIdentification":       {49, 53},
+		"ACHOperatorData":          {54, 54},
+		"IndividualName":           {55, 76},
+		"DiscretionaryData":        {77, 78},
+		"AddendaRecordIndicator":   {79, 79},
+		"ACHOperatorRoutingNumber": {80, 87},
+		"JulianDay":                {88, 90},
+		"SequenceNumber":           {91, 94},
+	},
+}
+
+// fieldColumns returns the first and last columns (starting at 1) of field in record,
+// or zeros when they aren't known.
+func fieldColumns(record, field string) (int, int) {
+	cols, exists := recordColumns[record][field]
+	if !exists {
+		return 0, 0
+	}
+	return cols[0], cols[1]
+}
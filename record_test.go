@@ -20,6 +20,9 @@ package ach
 import (
 	"bytes"
 	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
 )
 
 // testFileRecord validates a file record
@@ -260,3 +263,31 @@ func BenchmarkBuildFile(b *testing.B) {
 		testBuildFile(b)
 	}
 }
+
+func TestRecords__AppendTo(t *testing.T) {
+	fh, fc, advFC := mockFileHeader(), mockFileControl(), mockADVFileControl()
+	records := []interface {
+		String() string
+		AppendTo(dst []byte) []byte
+	}{
+		&fh, mockBatchPPDHeader(), mockEntryDetail(), mockBatchControl(), &fc,
+		mockAddenda02(), mockAddenda05(), mockAddenda98(), mockAddenda98Refused(),
+		mockAddenda99(), mockAddenda99Contested(), mockAddenda99Dishonored(),
+		mockIATBatchHeaderFF(), mockIATEntryDetail(), mockAddenda10(), mockAddenda11(), mockAddenda12(),
+		mockAddenda13(), mockAddenda14(), mockAddenda15(), mockAddenda16(), mockAddenda17(), mockAddenda18(),
+		mockADVEntryDetail(), mockADVBatchControl(), &advFC,
+	}
+	for _, record := range records {
+		line := record.String()
+		require.Equal(t, RecordLength, utf8.RuneCountInString(line), "%T", record)
+
+		// records are appended after what's already in dst
+		out := record.AppendTo([]byte("prefix"))
+		require.Equal(t, "prefix"+line, string(out), "%T", record)
+	}
+
+	// missing addenda records append nothing
+	var addenda05 *Addenda05
+	require.Empty(t, addenda05.AppendTo(nil))
+	require.Equal(t, "", addenda05.String())
+}
@@ -0,0 +1,420 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// RepairOpts turns off fixes made by File.Repair. Every fix is made when RepairOpts is nil or empty.
+type RepairOpts struct {
+	// SkipCheckDigits keeps the CheckDigit of entries which don't match their RDFI routing number.
+	SkipCheckDigits bool `json:"skipCheckDigits"`
+
+	// SkipAddendaIndicators keeps the AddendaRecordIndicator of entries which don't match their addenda records.
+	SkipAddendaIndicators bool `json:"skipAddendaIndicators"`
+
+	// SkipBatchNumbers keeps batch numbers which aren't in ascending order.
+	SkipBatchNumbers bool `json:"skipBatchNumbers"`
+}
+
+// RepairChange is a field modified by File.Repair.
+type RepairChange struct {
+	// Record is the type of record which was modified, e.g. "EntryDetail" or "BatchControl".
+	Record string `json:"record"`
+
+	// BatchIndex is the index of the batch in File.Batches, or File.IATBatches when IAT is set.
+	// It is -1 for file level records.
+	BatchIndex int `json:"batchIndex"`
+
+	// IAT is set when BatchIndex refers to File.IATBatches.
+	IAT bool `json:"iat,omitempty"`
+
+	// EntryIndex is the index of the entry in its batch. It is -1 for records which aren't part of an entry.
+	EntryIndex int `json:"entryIndex"`
+
+	// TraceNumber of the entry, if any, after the File was repaired.
+	TraceNumber string `json:"traceNumber,omitempty"`
+
+	// FieldName is the name of the modified field.
+	FieldName string `json:"fieldName"`
+
+	// Old and New are the values of the field before and after the repair.
+	Old string `json:"old"`
+	New string `json:"new"`
+
+	// Reason describes why the field was modified.
+	Reason string `json:"reason"`
+}
+
+func (c RepairChange) String() string {
+	where := c.Record
+	switch {
+	case c.EntryIndex >= 0 && c.TraceNumber != "":
+		where = fmt.Sprintf("batch %d entry %d (trace number %s) %s", c.BatchIndex, c.EntryIndex, c.TraceNumber, c.Record)
+	case c.EntryIndex >= 0:
+		where = fmt.Sprintf("batch %d entry %d %s", c.BatchIndex, c.EntryIndex, c.Record)
+	case c.BatchIndex >= 0:
+		where = fmt.Sprintf("batch %d %s", c.BatchIndex, c.Record)
+	}
+	return fmt.Sprintf("%s %s changed from %q to %q: %s", where, c.FieldName, c.Old, c.New, c.Reason)
+}
+
+// repairReasons describes why File.Repair changes a field. Fields which aren't listed are totals
+// calculated from the other records.
+var repairReasons = map[string]string{
+	"CheckDigit":                "check digit is calculated from the RDFI routing number",
+	"AddendaRecordIndicator":    "indicator must match the addenda records of the entry",
+	"AddendaRecords":            "count must match the addenda records of the entry",
+	"BatchNumber":               "batch numbers must be in ascending order",
+	"TraceNumber":               "trace number must start with the ODFI routing number of the batch",
+	"SequenceNumber":            "records are numbered in the order they appear",
+	"EntryDetailSequenceNumber": "addenda must have the sequence number of their entry's trace number",
+	"BlockCount":                "block count includes the lines of 9s padding the last block to 10 records",
+}
+
+// Repair fixes mechanical problems which make f invalid and returns every field it changed, in the order of the records:
+//
+//   - CheckDigit of entries which doesn't match their RDFI routing number
+//   - AddendaRecordIndicator of entries which doesn't match their addenda records
+//   - batch numbers which aren't in ascending order, by numbering every batch from 1
+//   - batch and file control totals, entry hashes and counts, including the BlockCount covering 9-padding
+//   - trace and sequence numbers which Create would set
+//
+// Totals are calculated the same way as Create. Repair is deterministic, so repairing the same file gives
+// the same changes. Problems which can't be fixed mechanically are left alone and the validation error of
+// the repaired File is returned with the changes.
+func (f *File) Repair(opts *RepairOpts) ([]RepairChange, error) {
+	if opts == nil {
+		opts = &RepairOpts{}
+	}
+	r := &repairLog{}
+
+	renumber := !opts.SkipBatchNumbers && !f.batchNumbersAscending()
+	batchNumber := 0
+	for i, b := range f.Batches {
+		batchNumber++
+		if err := r.repairBatch(i, b, batchNumber, renumber, opts); err != nil {
+			return r.changes, err
+		}
+	}
+	for i := range f.IATBatches {
+		batchNumber++
+		if err := r.repairIATBatch(i, &f.IATBatches[i], batchNumber, renumber, opts); err != nil {
+			return r.changes, err
+		}
+	}
+
+	file := repairChange("FileControl", -1, -1)
+	if f.IsADV() {
+		before := f.ADVControl
+		if err := f.Create(); err != nil {
+			return r.changes, err
+		}
+		f.ADVControl.ID = before.ID
+		r.diff(file.at("ADVFileControl"), before, f.ADVControl, "totals are calculated from the batches of the file")
+	} else {
+		before := f.Control
+		if err := f.Create(); err != nil {
+			return r.changes, err
+		}
+		f.Control.ID = before.ID
+		r.diff(file, before, f.Control, "totals are calculated from the batches of the file")
+	}
+
+	return r.changes, f.Validate()
+}
+
+// batchNumbersAscending reports if the batch numbers of f are in ascending order, including its IAT batches.
+func (f *File) batchNumbersAscending() bool {
+	last := 0
+	for _, b := range f.Batches {
+		if b.GetHeader().BatchNumber <= last {
+			return false
+		}
+		last = b.GetHeader().BatchNumber
+	}
+	for _, b := range f.IATBatches {
+		if b.GetHeader().BatchNumber <= last {
+			return false
+		}
+		last = b.GetHeader().BatchNumber
+	}
+	return true
+}
+
+type repairLog struct {
+	changes []RepairChange
+}
+
+func repairChange(record string, batchIndex, entryIndex int) RepairChange {
+	return RepairChange{Record: record, BatchIndex: batchIndex, EntryIndex: entryIndex}
+}
+
+func (c RepairChange) at(record string) RepairChange {
+	c.Record = record
+	return c
+}
+
+// repairRecord is a record of an entry with a copy of its fields before the repair.
+type repairRecord struct {
+	name   string
+	record interface{} // pointer to the record
+	before interface{}
+}
+
+func snapshot(name string, record interface{}) repairRecord {
+	return repairRecord{name: name, record: record, before: reflect.ValueOf(record).Elem().Interface()}
+}
+
+// repairBatch fixes the entries of b and rebuilds its control record with build().
+func (r *repairLog) repairBatch(index int, b Batcher, batchNumber int, renumber bool, opts *RepairOpts) error {
+	builder, ok := b.(interface{ build() error })
+	if !ok {
+		return b.Error("Batch", fmt.Errorf("%T can't be repaired", b))
+	}
+	header := *b.GetHeader()
+
+	var records [][]repairRecord
+	for _, entry := range b.GetEntries() {
+		records = append(records, entryRecords(entry))
+		if !opts.SkipCheckDigits {
+			entry.CheckDigit = repairCheckDigit(entry.RDFIIdentification, entry.CheckDigit)
+		}
+		if !opts.SkipAddendaIndicators {
+			if entry.addendaCount() > 0 {
+				entry.AddendaRecordIndicator = 1
+			} else {
+				entry.AddendaRecordIndicator = 0
+			}
+		}
+	}
+	for _, entry := range b.GetADVEntries() {
+		records = append(records, advEntryRecords(entry))
+		if !opts.SkipCheckDigits {
+			entry.CheckDigit = repairCheckDigit(entry.RDFIIdentification, entry.CheckDigit)
+		}
+	}
+	if renumber {
+		b.GetHeader().BatchNumber = batchNumber
+	}
+
+	at := repairChange("BatchHeader", index, -1)
+	if b.GetHeader().StandardEntryClassCode == ADV {
+		control := b.GetADVControl()
+		if err := builder.build(); err != nil {
+			return err
+		}
+		if control != nil {
+			b.GetADVControl().ID = control.ID
+		}
+		r.diff(at, header, *b.GetHeader(), "")
+		r.diffEntries(index, false, records, func(i int) string { return "" })
+		if control != nil {
+			r.diff(at.at("ADVBatchControl"), *control, *b.GetADVControl(), "totals are calculated from the entries of the batch")
+		}
+		return nil
+	}
+
+	control := b.GetControl()
+	if err := builder.build(); err != nil {
+		return err
+	}
+	if control != nil {
+		b.GetControl().ID = control.ID
+		b.GetControl().MessageAuthenticationCode = control.MessageAuthenticationCode
+	}
+	entries := b.GetEntries()
+	r.diff(at, header, *b.GetHeader(), "")
+	r.diffEntries(index, false, records, func(i int) string { return entries[i].TraceNumber })
+	if control != nil {
+		r.diff(at.at("BatchControl"), *control, *b.GetControl(), "totals are calculated from the entries of the batch")
+	}
+	return nil
+}
+
+// repairIATBatch fixes the entries of b and rebuilds its control record with build().
+func (r *repairLog) repairIATBatch(index int, b *IATBatch, batchNumber int, renumber bool, opts *RepairOpts) error {
+	header := *b.GetHeader()
+
+	var records [][]repairRecord
+	for _, entry := range b.Entries {
+		records = append(records, iatEntryRecords(entry))
+		if !opts.SkipCheckDigits {
+			entry.CheckDigit = repairCheckDigit(entry.RDFIIdentification, entry.CheckDigit)
+		}
+	}
+	if renumber {
+		b.GetHeader().BatchNumber = batchNumber
+	}
+
+	control := b.GetControl()
+	if err := b.build(); err != nil {
+		return err
+	}
+	if control != nil {
+		b.GetControl().ID = control.ID
+		b.GetControl().MessageAuthenticationCode = control.MessageAuthenticationCode
+	}
+
+	at := repairChange("IATBatchHeader", index, -1)
+	at.IAT = true
+	r.diff(at, header, *b.GetHeader(), "")
+	r.diffEntries(index, true, records, func(i int) string { return b.Entries[i].TraceNumber })
+	if control != nil {
+		r.diff(at.at("BatchControl"), *control, *b.GetControl(), "totals are calculated from the entries of the batch")
+	}
+	return nil
+}
+
+// repairCheckDigit returns the check digit of rdfi, or current when rdfi isn't an 8 digit routing number.
+func repairCheckDigit(rdfi, current string) string {
+	if len(rdfi) != 8 {
+		return current
+	}
+	if check := CalculateCheckDigit(rdfi); check >= 0 {
+		if n, err := strconv.Atoi(current); err == nil && n == check {
+			return current // keep the formatting of an unchanged digit
+		}
+		return strconv.Itoa(check)
+	}
+	return current
+}
+
+func (r *repairLog) diffEntries(batchIndex int, iat bool, records [][]repairRecord, traceNumber func(int) string) {
+	for i := range records {
+		at := repairChange("", batchIndex, i)
+		at.IAT = iat
+		at.TraceNumber = traceNumber(i)
+		for _, rec := range records[i] {
+			r.diff(at.at(rec.name), rec.before, reflect.ValueOf(rec.record).Elem().Interface(), "")
+		}
+	}
+}
+
+// diff records a change for every exported string or number field which differs between before and after,
+// which are values of the same record type. reason is used for fields without an entry in repairReasons.
+func (r *repairLog) diff(at RepairChange, before, after interface{}, reason string) {
+	old, current := reflect.ValueOf(before), reflect.ValueOf(after)
+	for i := 0; i < old.NumField(); i++ {
+		field := old.Type().Field(i)
+		if field.PkgPath != "" || field.Name == "ID" {
+			continue
+		}
+		switch field.Type.Kind() {
+		case reflect.String, reflect.Int:
+		default:
+			continue
+		}
+		a, b := fmt.Sprint(old.Field(i).Interface()), fmt.Sprint(current.Field(i).Interface())
+		if a == b {
+			continue
+		}
+		change := at
+		change.FieldName = field.Name
+		change.Old, change.New = a, b
+		change.Reason = reason
+		if why, exists := repairReasons[field.Name]; exists {
+			change.Reason = why
+		}
+		r.changes = append(r.changes, change)
+	}
+}
+
+// entryRecords returns the entry and its addenda records.
+func entryRecords(ed *EntryDetail) []repairRecord {
+	out := []repairRecord{snapshot("EntryDetail", ed)}
+	if ed.Addenda02 != nil {
+		out = append(out, snapshot("Addenda02", ed.Addenda02))
+	}
+	for _, a := range ed.Addenda05 {
+		if a != nil {
+			out = append(out, snapshot("Addenda05", a))
+		}
+	}
+	if ed.Addenda98 != nil {
+		out = append(out, snapshot("Addenda98", ed.Addenda98))
+	}
+	if ed.Addenda98Refused != nil {
+		out = append(out, snapshot("Addenda98Refused", ed.Addenda98Refused))
+	}
+	if ed.Addenda99 != nil {
+		out = append(out, snapshot("Addenda99", ed.Addenda99))
+	}
+	if ed.Addenda99Dishonored != nil {
+		out = append(out, snapshot("Addenda99Dishonored", ed.Addenda99Dishonored))
+	}
+	if ed.Addenda99Contested != nil {
+		out = append(out, snapshot("Addenda99Contested", ed.Addenda99Contested))
+	}
+	return out
+}
+
+// advEntryRecords returns the ADV entry and its addenda record.
+func advEntryRecords(ed *ADVEntryDetail) []repairRecord {
+	out := []repairRecord{snapshot("ADVEntryDetail", ed)}
+	if ed.Addenda99 != nil {
+		out = append(out, snapshot("Addenda99", ed.Addenda99))
+	}
+	return out
+}
+
+// iatEntryRecords returns the IAT entry and its addenda records.
+func iatEntryRecords(ed *IATEntryDetail) []repairRecord {
+	out := []repairRecord{snapshot("IATEntryDetail", ed)}
+	if ed.Addenda10 != nil {
+		out = append(out, snapshot("Addenda10", ed.Addenda10))
+	}
+	if ed.Addenda11 != nil {
+		out = append(out, snapshot("Addenda11", ed.Addenda11))
+	}
+	if ed.Addenda12 != nil {
+		out = append(out, snapshot("Addenda12", ed.Addenda12))
+	}
+	if ed.Addenda13 != nil {
+		out = append(out, snapshot("Addenda13", ed.Addenda13))
+	}
+	if ed.Addenda14 != nil {
+		out = append(out, snapshot("Addenda14", ed.Addenda14))
+	}
+	if ed.Addenda15 != nil {
+		out = append(out, snapshot("Addenda15", ed.Addenda15))
+	}
+	if ed.Addenda16 != nil {
+		out = append(out, snapshot("Addenda16", ed.Addenda16))
+	}
+	for _, a := range ed.Addenda17 {
+		if a != nil {
+			out = append(out, snapshot("Addenda17", a))
+		}
+	}
+	for _, a := range ed.Addenda18 {
+		if a != nil {
+			out = append(out, snapshot("Addenda18", a))
+		}
+	}
+	if ed.Addenda98 != nil {
+		out = append(out, snapshot("Addenda98", ed.Addenda98))
+	}
+	if ed.Addenda99 != nil {
+		out = append(out, snapshot("Addenda99", ed.Addenda99))
+	}
+	return out
+}
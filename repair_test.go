@@ -0,0 +1,97 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile__Repair(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "flattenBatchesMultipleBatchHeaders.ach"))
+	require.NoError(t, err)
+
+	file.Batches[0].GetEntries()[1].AddendaRecordIndicator = 0
+	file.Batches[1].GetEntries()[0].CheckDigit = "9"
+	file.Batches[2].GetHeader().BatchNumber = 1
+	file.Batches[3].GetControl().EntryHash = 1
+	file.Control.TotalCreditEntryDollarAmountInFile = 5
+	file.Control.BlockCount = 0
+	require.Error(t, file.Validate())
+
+	changes, err := file.Repair(nil)
+	require.NoError(t, err)
+
+	var got []string
+	for _, change := range changes {
+		got = append(got, change.String())
+	}
+	require.Equal(t, []string{
+		`batch 0 entry 1 (trace number 121042880000002) EntryDetail AddendaRecordIndicator changed from "0" to "1": indicator must match the addenda records of the entry`,
+		`batch 1 entry 0 (trace number 121042880000004) EntryDetail CheckDigit changed from "9" to "4": check digit is calculated from the RDFI routing number`,
+		`batch 2 BatchHeader BatchNumber changed from "1" to "3": batch numbers must be in ascending order`,
+		`batch 3 BatchControl EntryHash changed from "1" to "69414030": totals are calculated from the entries of the batch`,
+		`FileControl BlockCount changed from "0" to "4": block count includes the lines of 9s padding the last block to 10 records`,
+		`FileControl TotalCreditEntryDollarAmountInFile changed from "5" to "1200000": totals are calculated from the batches of the file`,
+	}, got)
+
+	require.NoError(t, file.Validate())
+
+	// a repaired file has nothing left to change
+	changes, err = file.Repair(nil)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestFile__RepairOpts(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	file.Batches[0].GetEntries()[0].CheckDigit = "1"
+
+	changes, err := file.Repair(&RepairOpts{SkipCheckDigits: true})
+	require.ErrorContains(t, err, "does not match calculated check digit")
+	require.Empty(t, changes)
+
+	changes, err = file.Repair(nil)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, "EntryDetail", changes[0].Record)
+	require.Equal(t, "CheckDigit", changes[0].FieldName)
+}
+
+func TestFile__RepairIAT(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "flattenIATBatchesOneBatchHeader.ach"))
+	require.NoError(t, err)
+
+	file.IATBatches[1].Entries[0].CheckDigit = "0"
+	file.IATBatches[1].GetControl().TotalDebitEntryDollarAmount = 1
+	require.Error(t, file.Validate())
+
+	changes, err := file.Repair(nil)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.True(t, changes[0].IAT)
+	require.Equal(t, "IATEntryDetail", changes[0].Record)
+	require.Equal(t, "CheckDigit", changes[0].FieldName)
+	require.Equal(t, "BatchControl", changes[1].Record)
+	require.Equal(t, "TotalDebitEntryDollarAmount", changes[1].FieldName)
+	require.NoError(t, file.Validate())
+}
@@ -0,0 +1,399 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/base"
+)
+
+// ReturnEntry identifies a forward entry which an RDFI is returning along with the reason for the return.
+type ReturnEntry struct {
+	// TraceNumber is the trace number of the forward EntryDetail or IATEntryDetail being returned.
+	TraceNumber string `json:"traceNumber"`
+
+	// ReturnCode is the Nacha return reason code (e.g. R01) and must exist in the return code table
+	// unless ValidateOpts.CustomReturnCodes is set on the forward File.
+	ReturnCode string `json:"returnCode"`
+
+	// DateOfDeath is required for R14 and R15 returns. Format: YYMMDD (Y=Year, M=Month, D=Day)
+	DateOfDeath string `json:"dateOfDeath,omitempty"`
+
+	// AddendaInformation is optional information included on the Addenda99 record.
+	AddendaInformation string `json:"addendaInformation,omitempty"`
+}
+
+// CreateReturns builds a Nacha compliant return file from the forward entries of f which match
+// the trace numbers in returns. The forward File is not modified.
+//
+// Each returned entry is placed in a batch which copies the original Company/Batch Header with
+// the returning RDFI as the ODFIIdentification. Entries are given their return transaction codes,
+// new trace numbers and an Addenda99 record referencing the original entry. The ImmediateOrigin and
+// ImmediateDestination of the FileHeader are swapped so the file can be sent back to the originator.
+//
+// IAT entries keep their Addenda10-18 records and carry the original payment amount on their Addenda99.
+func (f *File) CreateReturns(returns []ReturnEntry) (*File, error) {
+	if len(returns) == 0 {
+		return nil, ErrFileNoReturnEntries
+	}
+	requested := make(map[string]ReturnEntry, len(returns))
+	for i := range returns {
+		ret := returns[i]
+		if err := f.validateReturnEntry(ret); err != nil {
+			return nil, err
+		}
+		if _, exists := requested[ret.TraceNumber]; exists {
+			return nil, fmt.Errorf("trace number %s: %w", ret.TraceNumber, ErrFileReturnEntryDuplicate)
+		}
+		requested[ret.TraceNumber] = ret
+	}
+
+	out := NewFile()
+	out.ID = base.ID()
+	out.Header = f.returnFileHeader(time.Now())
+	out.SetValidation(f.validateOpts)
+
+	found := make(map[string]bool)
+
+	err := f.createReturnBatches(out, "", func(bh *BatchHeader, entry *EntryDetail) (*EntryDetail, error) {
+		ret, exists := requested[entry.TraceNumber]
+		if !exists {
+			return nil, nil
+		}
+		if entry.Category != CategoryForward {
+			return nil, fmt.Errorf("trace number %s: %w", entry.TraceNumber, ErrFileReturnEntryCategory)
+		}
+		found[entry.TraceNumber] = true
+		return createReturnEntryDetail(bh, entry, ret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, iatBatch := range f.IATBatches {
+		bh := iatBatch.GetHeader()
+		if bh == nil {
+			continue
+		}
+		var order []string
+		grouped := make(map[string][]*IATEntryDetail)
+		for _, entry := range iatBatch.GetEntries() {
+			ret, exists := requested[entry.TraceNumber]
+			if !exists {
+				continue
+			}
+			if entry.Category != CategoryForward {
+				return nil, fmt.Errorf("trace number %s: %w", entry.TraceNumber, ErrFileReturnEntryCategory)
+			}
+			found[entry.TraceNumber] = true
+
+			rdfi := entry.RDFIIdentificationField()
+			if _, exists := grouped[rdfi]; !exists {
+				order = append(order, rdfi)
+			}
+			grouped[rdfi] = append(grouped[rdfi], createReturnIATEntryDetail(bh, entry, ret))
+		}
+		for _, rdfi := range order {
+			b := NewIATBatch(returnIATBatchHeader(bh, rdfi))
+			if iatBatch.Control != nil {
+				b.Control.CompanyIdentification = iatBatch.Control.CompanyIdentification
+			}
+			b.SetValidation(f.validateOpts)
+			for _, entry := range grouped[rdfi] {
+				entry.SetValidation(f.validateOpts)
+				entry.Addenda99.SetValidation(f.validateOpts)
+				b.AddEntry(entry)
+			}
+			if err := b.build(); err != nil {
+				return nil, b.Error("Return", err)
+			}
+			for _, entry := range b.Entries {
+				entry.Addenda99.TraceNumber = entry.TraceNumber
+			}
+			if err := b.Validate(); err != nil {
+				return nil, err
+			}
+			out.AddIATBatch(b)
+		}
+	}
+
+	for i := range returns {
+		if !found[returns[i].TraceNumber] {
+			return nil, fmt.Errorf("trace number %s: %w", returns[i].TraceNumber, ErrFileReturnEntryNotFound)
+		}
+	}
+
+	if err := out.Create(); err != nil {
+		return nil, err
+	}
+	if err := out.Validate(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// createReturnBatches copies the BatchHeader of each batch in f into out for entries which fn creates.
+// Entries are grouped into batches originated by the RDFI of the entry they were created from and
+// fn returns a nil EntryDetail for entries which are skipped. When sec is non-empty it replaces the
+// StandardEntryClassCode of each copied BatchHeader.
+func (f *File) createReturnBatches(out *File, sec string, fn func(bh *BatchHeader, entry *EntryDetail) (*EntryDetail, error)) error {
+	for _, batch := range f.Batches {
+		bh := batch.GetHeader()
+		if bh == nil || bh.StandardEntryClassCode == ADV {
+			continue
+		}
+		var order []string
+		grouped := make(map[string][]*EntryDetail)
+		for _, entry := range batch.GetEntries() {
+			ed, err := fn(bh, entry)
+			if err != nil {
+				return err
+			}
+			if ed == nil {
+				continue
+			}
+			rdfi := entry.RDFIIdentificationField()
+			if _, exists := grouped[rdfi]; !exists {
+				order = append(order, rdfi)
+			}
+			grouped[rdfi] = append(grouped[rdfi], ed)
+		}
+		for _, rdfi := range order {
+			rbh := returnBatchHeader(bh, rdfi)
+			if sec != "" {
+				rbh.StandardEntryClassCode = sec
+			}
+			b, err := NewBatch(rbh)
+			if err != nil {
+				return err
+			}
+			b.SetValidation(f.validateOpts)
+			for _, entry := range grouped[rdfi] {
+				entry.SetValidation(f.validateOpts)
+				entry.Addenda99.SetValidation(f.validateOpts)
+				entry.Addenda99Dishonored.SetValidation(f.validateOpts)
+				entry.Addenda99Contested.SetValidation(f.validateOpts)
+				b.AddEntry(entry)
+			}
+			if err := b.Create(); err != nil {
+				return err
+			}
+			out.AddBatch(b)
+		}
+	}
+	return nil
+}
+
+func (f *File) validateReturnEntry(ret ReturnEntry) error {
+	if ret.TraceNumber == "" {
+		return fieldError("TraceNumber", ErrFieldRequired, ret.TraceNumber)
+	}
+	code := strings.ToUpper(ret.ReturnCode)
+	if IsDishonoredReturnCode(code) || IsContestedReturnCode(code) {
+		return fieldError("ReturnCode", ErrAddenda99ReturnCode, ret.ReturnCode)
+	}
+	if f.validateOpts == nil || !f.validateOpts.CustomReturnCodes {
+		if LookupReturnCode(code) == nil {
+			return fieldError("ReturnCode", ErrAddenda99ReturnCode, ret.ReturnCode)
+		}
+	}
+	switch code {
+	case "R14", "R15":
+		// The date of death must be supplied on entries returned for reason of death
+		if ret.DateOfDeath == "" {
+			return fieldError("DateOfDeath", ErrFieldRequired, ret.DateOfDeath)
+		}
+	}
+	if ret.DateOfDeath != "" {
+		if _, err := time.Parse("060102", ret.DateOfDeath); err != nil {
+			return fieldError("DateOfDeath", err, ret.DateOfDeath)
+		}
+	}
+	return nil
+}
+
+// returnFileHeader creates a FileHeader which routes a return file back to the originator of f.
+func (f *File) returnFileHeader(now time.Time) FileHeader {
+	fh := NewFileHeader()
+	fh.ID = base.ID()
+	fh.ImmediateOrigin = f.Header.ImmediateDestination
+	fh.ImmediateOriginName = f.Header.ImmediateDestinationName
+	fh.ImmediateDestination = f.Header.ImmediateOrigin
+	fh.ImmediateDestinationName = f.Header.ImmediateOriginName
+	fh.FileCreationDate = now.Format("060102")
+	fh.FileCreationTime = now.Format("1504")
+	fh.FileIDModifier = "A"
+	fh.SetValidation(f.validateOpts)
+	return fh
+}
+
+// returnBatchHeader copies the original BatchHeader for a return batch originated by rdfi.
+func returnBatchHeader(original *BatchHeader, rdfi string) *BatchHeader {
+	bh := NewBatchHeader()
+	bh.ID = base.ID()
+	bh.ServiceClassCode = original.ServiceClassCode
+	bh.CompanyName = original.CompanyName
+	bh.CompanyDiscretionaryData = original.CompanyDiscretionaryData
+	bh.CompanyIdentification = original.CompanyIdentification
+	bh.StandardEntryClassCode = original.StandardEntryClassCode
+	bh.CompanyEntryDescription = original.CompanyEntryDescription
+	bh.CompanyDescriptiveDate = original.CompanyDescriptiveDate
+	bh.EffectiveEntryDate = original.EffectiveEntryDate
+	bh.OriginatorStatusCode = original.OriginatorStatusCode
+	// SettlementDate is inserted by the ACH Operator
+	bh.ODFIIdentification = rdfi
+	return bh
+}
+
+// returnIATBatchHeader copies the original IATBatchHeader for a return batch originated by rdfi.
+func returnIATBatchHeader(original *IATBatchHeader, rdfi string) *IATBatchHeader {
+	bh := *original
+	bh.ID = base.ID()
+	bh.SettlementDate = ""
+	bh.BatchNumber = 0
+	bh.ODFIIdentification = rdfi
+	return &bh
+}
+
+func createReturnEntryDetail(bh *BatchHeader, entry *EntryDetail, ret ReturnEntry) *EntryDetail {
+	odfi := bh.ODFIIdentificationField()
+
+	ed := NewEntryDetail()
+	ed.ID = base.ID()
+	ed.TransactionCode = returnTransactionCode(entry.TransactionCode)
+	ed.RDFIIdentification = odfi
+	ed.CheckDigit = strconv.Itoa(CalculateCheckDigit(odfi))
+	ed.DFIAccountNumber = entry.DFIAccountNumber
+	ed.Amount = entry.Amount
+	ed.IdentificationNumber = entry.IdentificationNumber
+	ed.IndividualName = entry.IndividualName
+	ed.DiscretionaryData = entry.DiscretionaryData
+	ed.AddendaRecordIndicator = 1
+	ed.Category = CategoryReturn
+
+	addenda99 := createReturnAddenda99(entry.TraceNumber, entry.RDFIIdentificationField(), ret)
+	addenda99.AddendaInformation = ret.AddendaInformation
+	ed.Addenda99 = addenda99
+
+	return ed
+}
+
+func createReturnIATEntryDetail(bh *IATBatchHeader, entry *IATEntryDetail, ret ReturnEntry) *IATEntryDetail {
+	odfi := bh.ODFIIdentificationField()
+
+	ed := NewIATEntryDetail()
+	ed.ID = base.ID()
+	ed.TransactionCode = returnTransactionCode(entry.TransactionCode)
+	ed.RDFIIdentification = odfi
+	ed.CheckDigit = strconv.Itoa(CalculateCheckDigit(odfi))
+	ed.AddendaRecords = entry.AddendaRecords
+	ed.Amount = entry.Amount
+	ed.DFIAccountNumber = entry.DFIAccountNumber
+	ed.OFACScreeningIndicator = entry.OFACScreeningIndicator
+	ed.SecondaryOFACScreeningIndicator = entry.SecondaryOFACScreeningIndicator
+	ed.Category = CategoryReturn
+
+	// Copy the original addenda records so building the return batch doesn't modify the forward entry
+	copyIATAddenda(ed, entry)
+
+	addenda99 := createReturnAddenda99(entry.TraceNumber, entry.RDFIIdentificationField(), ret)
+	addenda99.IATPaymentAmount(strconv.Itoa(entry.Amount))
+	addenda99.IATAddendaInformation(ret.AddendaInformation)
+	ed.Addenda99 = addenda99
+
+	return ed
+}
+
+func createReturnAddenda99(originalTrace, originalDFI string, ret ReturnEntry) *Addenda99 {
+	addenda99 := NewAddenda99()
+	addenda99.ID = base.ID()
+	addenda99.ReturnCode = strings.ToUpper(ret.ReturnCode)
+	addenda99.OriginalTrace = originalTrace
+	addenda99.OriginalDFI = originalDFI
+	addenda99.DateOfDeath = ret.DateOfDeath
+	return addenda99
+}
+
+// copyIATAddenda copies the Addenda10 through Addenda18 records of src onto dst.
+func copyIATAddenda(dst, src *IATEntryDetail) {
+	if src.Addenda10 != nil {
+		a := *src.Addenda10
+		dst.Addenda10 = &a
+	}
+	if src.Addenda11 != nil {
+		a := *src.Addenda11
+		dst.Addenda11 = &a
+	}
+	if src.Addenda12 != nil {
+		a := *src.Addenda12
+		dst.Addenda12 = &a
+	}
+	if src.Addenda13 != nil {
+		a := *src.Addenda13
+		dst.Addenda13 = &a
+	}
+	if src.Addenda14 != nil {
+		a := *src.Addenda14
+		dst.Addenda14 = &a
+	}
+	if src.Addenda15 != nil {
+		a := *src.Addenda15
+		dst.Addenda15 = &a
+	}
+	if src.Addenda16 != nil {
+		a := *src.Addenda16
+		dst.Addenda16 = &a
+	}
+	for _, addenda17 := range src.Addenda17 {
+		a := *addenda17
+		dst.AddAddenda17(&a)
+	}
+	for _, addenda18 := range src.Addenda18 {
+		a := *addenda18
+		dst.AddAddenda18(&a)
+	}
+}
+
+// returnTransactionCode converts a forward TransactionCode into its automated return equivalent.
+// Credits (e.g. 22, 23, 24) are returned with the credit return code (21) and debits (e.g. 27, 28, 29)
+// with the debit return code (26) of the same account type.
+func returnTransactionCode(code int) int {
+	switch code {
+	case CheckingCredit, CheckingPrenoteCredit, CheckingZeroDollarRemittanceCredit:
+		return CheckingReturnNOCCredit
+	case CheckingDebit, CheckingPrenoteDebit, CheckingZeroDollarRemittanceDebit:
+		return CheckingReturnNOCDebit
+	case SavingsCredit, SavingsPrenoteCredit, SavingsZeroDollarRemittanceCredit:
+		return SavingsReturnNOCCredit
+	case SavingsDebit, SavingsPrenoteDebit, SavingsZeroDollarRemittanceDebit:
+		return SavingsReturnNOCDebit
+	case GLCredit, GLPrenoteCredit, GLZeroDollarRemittanceCredit:
+		return GLReturnNOCCredit
+	case GLDebit, GLPrenoteDebit, GLZeroDollarRemittanceDebit:
+		return GLReturnNOCDebit
+	case LoanCredit, LoanPrenoteCredit, LoanZeroDollarRemittanceCredit:
+		return LoanReturnNOCCredit
+	case LoanDebit:
+		return LoanReturnNOCDebit
+	}
+	return code
+}
@@ -0,0 +1,167 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile__CreateReturns(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	returns, err := file.CreateReturns([]ReturnEntry{
+		{TraceNumber: "121042880000001", ReturnCode: "R01"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, file.Header.ImmediateOrigin, returns.Header.ImmediateDestination)
+	require.Equal(t, file.Header.ImmediateDestination, returns.Header.ImmediateOrigin)
+	require.Len(t, returns.Batches, 1)
+	require.Len(t, returns.ReturnEntries, 1)
+
+	bh := returns.Batches[0].GetHeader()
+	require.Equal(t, PPD, bh.StandardEntryClassCode)
+	require.Equal(t, "23138010", bh.ODFIIdentification)
+	require.Equal(t, CategoryReturn, returns.Batches[0].Category())
+
+	entries := returns.Batches[0].GetEntries()
+	require.Len(t, entries, 1)
+	ed := entries[0]
+	require.Equal(t, CheckingReturnNOCDebit, ed.TransactionCode)
+	require.Equal(t, "12104288", ed.RDFIIdentification)
+	require.Equal(t, 100000000, ed.Amount)
+	require.Equal(t, "231380100000001", ed.TraceNumber)
+
+	require.NotNil(t, ed.Addenda99)
+	require.Equal(t, "R01", ed.Addenda99.ReturnCode)
+	require.Equal(t, "121042880000001", ed.Addenda99.OriginalTrace)
+	require.Equal(t, "23138010", ed.Addenda99.OriginalDFI)
+	require.Equal(t, ed.TraceNumber, ed.Addenda99.TraceNumber)
+
+	// the forward file is left alone
+	require.Equal(t, CategoryForward, file.Batches[0].GetEntries()[0].Category)
+	require.Nil(t, file.Batches[0].GetEntries()[0].Addenda99)
+
+	// write and read the return file back
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(returns))
+	parsed, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+	require.Len(t, parsed.ReturnEntries, 1)
+}
+
+func TestFile__CreateReturnsIAT(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "iat-debit.ach"))
+	require.NoError(t, err)
+
+	returns, err := file.CreateReturns([]ReturnEntry{
+		{TraceNumber: "231380100000001", ReturnCode: "R03", AddendaInformation: "ACCOUNT CLOSED"},
+	})
+	require.NoError(t, err)
+	require.Len(t, returns.IATBatches, 1)
+
+	iatBatch := returns.IATBatches[0]
+	require.Equal(t, "12104288", iatBatch.Header.ODFIIdentification)
+	require.Equal(t, CategoryReturn, iatBatch.Category())
+
+	ed := iatBatch.Entries[0]
+	require.Equal(t, CheckingReturnNOCDebit, ed.TransactionCode)
+	require.Equal(t, "23138010", ed.RDFIIdentification)
+	require.NotNil(t, ed.Addenda10)
+	require.NotNil(t, ed.Addenda16)
+	require.Len(t, ed.Addenda17, 1)
+	require.Len(t, ed.Addenda18, 1)
+	require.Equal(t, 100000, ed.Addenda99.IATPaymentAmountField())
+	require.Equal(t, "R03", ed.Addenda99.ReturnCode)
+	require.Equal(t, "231380100000001", ed.Addenda99.OriginalTrace)
+
+	// the forward entry keeps its own addenda records
+	require.NotSame(t, file.IATBatches[0].Entries[0].Addenda10, ed.Addenda10)
+	require.Equal(t, "0000001", file.IATBatches[0].Entries[0].Addenda10.EntryDetailSequenceNumberField())
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(returns))
+	_, err = NewReader(&buf).Read()
+	require.NoError(t, err)
+}
+
+func TestFile__CreateReturnsMultipleEntries(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "20110805A.ach"))
+	require.NoError(t, err)
+
+	returns, err := file.CreateReturns([]ReturnEntry{
+		{TraceNumber: "042000010000024", ReturnCode: "R02"},
+		{TraceNumber: "042000010000025", ReturnCode: "R03"},
+	})
+	require.NoError(t, err)
+	require.Len(t, returns.Batches, 1)
+
+	entries := returns.Batches[0].GetEntries()
+	require.Len(t, entries, 2)
+	require.Equal(t, "PAYTON MCCOY          ", entries[0].IndividualName)
+	require.Equal(t, "R02", entries[0].Addenda99.ReturnCode)
+	require.Equal(t, "R03", entries[1].Addenda99.ReturnCode)
+	require.Equal(t, "021200020000001", entries[0].TraceNumber)
+	require.Equal(t, "021200020000002", entries[1].TraceNumber)
+}
+
+func TestFile__CreateReturnsErrors(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	_, err = file.CreateReturns(nil)
+	require.ErrorIs(t, err, ErrFileNoReturnEntries)
+
+	_, err = file.CreateReturns([]ReturnEntry{{TraceNumber: "999999999999999", ReturnCode: "R01"}})
+	require.ErrorIs(t, err, ErrFileReturnEntryNotFound)
+
+	_, err = file.CreateReturns([]ReturnEntry{
+		{TraceNumber: "121042880000001", ReturnCode: "R01"},
+		{TraceNumber: "121042880000001", ReturnCode: "R02"},
+	})
+	require.ErrorIs(t, err, ErrFileReturnEntryDuplicate)
+
+	_, err = file.CreateReturns([]ReturnEntry{{TraceNumber: "121042880000001", ReturnCode: "R99"}})
+	require.ErrorIs(t, err, ErrAddenda99ReturnCode)
+
+	_, err = file.CreateReturns([]ReturnEntry{{TraceNumber: "121042880000001", ReturnCode: "R61"}})
+	require.ErrorIs(t, err, ErrAddenda99ReturnCode)
+
+	_, err = file.CreateReturns([]ReturnEntry{{TraceNumber: "121042880000001", ReturnCode: "R14"}})
+	var fe *FieldError
+	require.True(t, errors.As(err, &fe))
+	require.Equal(t, "DateOfDeath", fe.FieldName)
+
+	_, err = file.CreateReturns([]ReturnEntry{{TraceNumber: "121042880000001", ReturnCode: "R14", DateOfDeath: "190601"}})
+	require.NoError(t, err)
+}
+
+func TestReturnTransactionCode(t *testing.T) {
+	require.Equal(t, CheckingReturnNOCCredit, returnTransactionCode(CheckingCredit))
+	require.Equal(t, CheckingReturnNOCDebit, returnTransactionCode(CheckingPrenoteDebit))
+	require.Equal(t, SavingsReturnNOCCredit, returnTransactionCode(SavingsZeroDollarRemittanceCredit))
+	require.Equal(t, GLReturnNOCDebit, returnTransactionCode(GLDebit))
+	require.Equal(t, LoanReturnNOCDebit, returnTransactionCode(LoanDebit))
+	require.Equal(t, CheckingReturnNOCDebit, returnTransactionCode(CheckingReturnNOCDebit))
+}
@@ -20,6 +20,8 @@ package ach
 import (
 	"fmt"
 	"time"
+
+	"github.com/moov-io/base"
 )
 
 // Reversal will transform a File into a Nacha compliant reversal which can be transmitted to undo fund movement.
@@ -52,25 +54,10 @@ func (f *File) Reversal(effectiveEntryDate time.Time) error {
 		// In EntryDetail records we need to update the TransactionCode fields to undo fund movement.
 		entries := f.Batches[i].GetEntries()
 		for j := range entries {
-			switch entries[j].TransactionCode {
-			case
-				CheckingCredit, CheckingReturnNOCCredit, CheckingPrenoteCredit, CheckingZeroDollarRemittanceCredit,
-				GLCredit, GLPrenoteCredit, GLReturnNOCCredit, GLZeroDollarRemittanceCredit,
-				LoanCredit, LoanPrenoteCredit, LoanReturnNOCCredit, LoanZeroDollarRemittanceCredit,
-				SavingsCredit, SavingsPrenoteCredit, SavingsReturnNOCCredit, SavingsZeroDollarRemittanceCredit:
-				// Credit -> Debit
-				hasDebits = true
-				entries[j].TransactionCode += 5
-
-			case
-				CheckingDebit, CheckingPrenoteDebit, CheckingReturnNOCDebit, CheckingZeroDollarRemittanceDebit,
-				GLDebit, GLPrenoteDebit, GLReturnNOCDebit, GLZeroDollarRemittanceDebit,
-				LoanDebit, LoanReturnNOCDebit,
-				SavingsDebit, SavingsPrenoteDebit, SavingsReturnNOCDebit, SavingsZeroDollarRemittanceDebit:
-				// Debit -> Credit
-				hasCredits = true
-				entries[j].TransactionCode -= 5
-			}
+			code, credit, debit := reversalTransactionCode(entries[j].TransactionCode)
+			entries[j].TransactionCode = code
+			hasCredits = hasCredits || credit
+			hasDebits = hasDebits || debit
 		}
 
 		// Re-calculate control record
@@ -106,3 +93,240 @@ func (f *File) Reversal(effectiveEntryDate time.Time) error {
 	}
 	return f.Create()
 }
+
+// ReversalSelection chooses the entries of a File which are reversed by CreateReversal.
+// An entry is selected when it matches any of the populated fields.
+type ReversalSelection struct {
+	// TraceNumbers selects the entries with these trace numbers.
+	TraceNumbers []string `json:"traceNumbers,omitempty"`
+
+	// BatchID selects every entry of the batch with this ID.
+	BatchID string `json:"batchID,omitempty"`
+
+	// Entry selects each EntryDetail it returns true for.
+	Entry func(*EntryDetail) bool `json:"-"`
+
+	// IATEntry selects each IATEntryDetail it returns true for.
+	IATEntry func(*IATEntryDetail) bool `json:"-"`
+}
+
+func (sel ReversalSelection) empty() bool {
+	return len(sel.TraceNumbers) == 0 && sel.BatchID == "" && sel.Entry == nil && sel.IATEntry == nil
+}
+
+// CreateReversal builds a file reversing the forward entries of f chosen by sel. The original File is not modified.
+//
+// Each reversing entry keeps the Standard Entry Class Code, Company Identification, amount and trace number of
+// the original entry with its transaction code switched between credit and debit. Reversing batches have
+// "REVERSAL" as their Company Entry Description and effectiveEntryDate as their Effective Entry Date, which must
+// be within five banking days of the Effective Entry Date of the original batch. ADV batches are not reversed.
+func (f *File) CreateReversal(sel ReversalSelection, effectiveEntryDate time.Time) (*File, error) {
+	if sel.empty() {
+		return nil, ErrFileNoReversalEntries
+	}
+	traces := make(map[string]bool, len(sel.TraceNumbers))
+	for _, trace := range sel.TraceNumbers {
+		traces[trace] = true
+	}
+	found := make(map[string]bool)
+	batchFound := false
+
+	now := time.Now()
+	out := NewFile()
+	out.ID = base.ID()
+	out.Header = f.Header
+	out.Header.ID = base.ID()
+	out.Header.FileCreationDate = now.Format("060102")
+	out.Header.FileCreationTime = now.Format("1504")
+	out.SetValidation(f.validateOpts)
+
+	for _, b := range f.Batches {
+		if b.GetHeader().StandardEntryClassCode == ADV {
+			continue
+		}
+		wholeBatch := sel.BatchID != "" && b.ID() == sel.BatchID
+		batchFound = batchFound || wholeBatch
+
+		var entries []*EntryDetail
+		hasCredits, hasDebits := false, false
+		for _, entry := range b.GetEntries() {
+			if !isForwardEntry(entry.Category) {
+				continue
+			}
+			if !wholeBatch && !traces[entry.TraceNumber] && (sel.Entry == nil || !sel.Entry(entry)) {
+				continue
+			}
+			found[entry.TraceNumber] = true
+
+			ed := copyEntryDetail(entry)
+			ed.ID = base.ID()
+			code, credit, debit := reversalTransactionCode(entry.TransactionCode)
+			ed.TransactionCode = code
+			hasCredits = hasCredits || credit
+			hasDebits = hasDebits || debit
+			entries = append(entries, ed)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		original := b.GetHeader()
+		if err := checkReversalWindow(original.EffectiveEntryDate, effectiveEntryDate); err != nil {
+			return nil, fmt.Errorf("batch %d: %w", original.BatchNumber, err)
+		}
+		bh := *original
+		bh.ID = base.ID()
+		bh.CompanyEntryDescription = "REVERSAL"
+		bh.EffectiveEntryDate = effectiveEntryDate.Format("060102")
+		bh.ServiceClassCode = reversalServiceClassCode(original.ServiceClassCode, hasCredits, hasDebits)
+
+		batch, err := NewBatch(&bh)
+		if err != nil {
+			return nil, err
+		}
+		batch.SetID(base.ID())
+		batch.SetValidation(f.validateOpts)
+		for _, ed := range entries {
+			batch.AddEntry(ed)
+		}
+		if err := batch.Create(); err != nil {
+			return nil, err
+		}
+		out.AddBatch(batch)
+	}
+
+	for _, b := range f.IATBatches {
+		wholeBatch := sel.BatchID != "" && b.ID == sel.BatchID
+		batchFound = batchFound || wholeBatch
+
+		var entries []*IATEntryDetail
+		hasCredits, hasDebits := false, false
+		for _, entry := range b.Entries {
+			if !isForwardEntry(entry.Category) {
+				continue
+			}
+			if !wholeBatch && !traces[entry.TraceNumber] && (sel.IATEntry == nil || !sel.IATEntry(entry)) {
+				continue
+			}
+			found[entry.TraceNumber] = true
+
+			ed := copyReversalIATEntryDetail(entry)
+			code, credit, debit := reversalTransactionCode(entry.TransactionCode)
+			ed.TransactionCode = code
+			hasCredits = hasCredits || credit
+			hasDebits = hasDebits || debit
+			entries = append(entries, ed)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		original := b.GetHeader()
+		if err := checkReversalWindow(original.EffectiveEntryDate, effectiveEntryDate); err != nil {
+			return nil, fmt.Errorf("IAT batch %d: %w", original.BatchNumber, err)
+		}
+		bh := *original
+		bh.ID = base.ID()
+		bh.CompanyEntryDescription = "REVERSAL"
+		bh.EffectiveEntryDate = effectiveEntryDate.Format("060102")
+		bh.ServiceClassCode = reversalServiceClassCode(original.ServiceClassCode, hasCredits, hasDebits)
+
+		batch := NewIATBatch(&bh)
+		batch.ID = base.ID()
+		batch.SetValidation(f.validateOpts)
+		for _, ed := range entries {
+			batch.AddEntry(ed)
+		}
+		if err := batch.Create(); err != nil {
+			return nil, err
+		}
+		out.AddIATBatch(batch)
+	}
+
+	if sel.BatchID != "" && !batchFound {
+		return nil, fmt.Errorf("batch %s: %w", sel.BatchID, ErrFileReversalEntryNotFound)
+	}
+	for _, trace := range sel.TraceNumbers {
+		if !found[trace] {
+			return nil, fmt.Errorf("trace number %s: %w", trace, ErrFileReversalEntryNotFound)
+		}
+	}
+	if len(out.Batches) == 0 && len(out.IATBatches) == 0 {
+		return nil, ErrFileNoReversalEntries
+	}
+	if err := out.Create(); err != nil {
+		return nil, err
+	}
+	if err := out.Validate(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// checkReversalWindow ensures a reversing entry settles within five banking days after the
+// original Effective Entry Date (YYMMDD) and not before it.
+func checkReversalWindow(originalEffectiveEntryDate string, effectiveEntryDate time.Time) error {
+	original, err := time.Parse("060102", originalEffectiveEntryDate)
+	if err != nil {
+		return fieldError("EffectiveEntryDate", ErrValidDay, originalEffectiveEntryDate)
+	}
+	y, m, d := effectiveEntryDate.Date()
+	reversal := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	deadline := NewCalendar().AddBankingDays(original, 5)
+	if reversal.Before(original) || reversal.After(deadline) {
+		return fmt.Errorf("effective entry date %s is not between %s and %s: %w",
+			reversal.Format("060102"), originalEffectiveEntryDate, deadline.Format("060102"), ErrFileReversalWindow)
+	}
+	return nil
+}
+
+// isForwardEntry reports if an entry category is a forward entry which can be reversed.
+func isForwardEntry(category string) bool {
+	return category == "" || category == CategoryForward
+}
+
+// reversalTransactionCode returns the TransactionCode which undoes the fund movement of code
+// and if the reversing entry is a credit or debit. Unknown codes are returned unchanged.
+func reversalTransactionCode(code int) (reversed int, credit bool, debit bool) {
+	switch code {
+	case
+		CheckingCredit, CheckingReturnNOCCredit, CheckingPrenoteCredit, CheckingZeroDollarRemittanceCredit,
+		GLCredit, GLPrenoteCredit, GLReturnNOCCredit, GLZeroDollarRemittanceCredit,
+		LoanCredit, LoanPrenoteCredit, LoanReturnNOCCredit, LoanZeroDollarRemittanceCredit,
+		SavingsCredit, SavingsPrenoteCredit, SavingsReturnNOCCredit, SavingsZeroDollarRemittanceCredit:
+		// Credit -> Debit
+		return code + 5, false, true
+
+	case
+		CheckingDebit, CheckingPrenoteDebit, CheckingReturnNOCDebit, CheckingZeroDollarRemittanceDebit,
+		GLDebit, GLPrenoteDebit, GLReturnNOCDebit, GLZeroDollarRemittanceDebit,
+		LoanDebit, LoanReturnNOCDebit,
+		SavingsDebit, SavingsPrenoteDebit, SavingsReturnNOCDebit, SavingsZeroDollarRemittanceDebit:
+		// Debit -> Credit
+		return code - 5, true, false
+	}
+	return code, false, false
+}
+
+// reversalServiceClassCode returns the ServiceClassCode of a batch holding the reversing entries.
+func reversalServiceClassCode(original int, hasCredits, hasDebits bool) int {
+	switch {
+	case hasCredits && hasDebits:
+		return MixedDebitsAndCredits
+	case hasCredits:
+		return CreditsOnly
+	case hasDebits:
+		return DebitsOnly
+	}
+	return original
+}
+
+// copyReversalIATEntryDetail copies entry and its addenda records so the original entry isn't modified.
+func copyReversalIATEntryDetail(entry *IATEntryDetail) *IATEntryDetail {
+	ed := *entry
+	ed.ID = base.ID()
+	ed.Addenda17, ed.Addenda18 = nil, nil
+	copyIATAddenda(&ed, entry)
+	return &ed
+}
@@ -18,6 +18,7 @@
 package ach
 
 import (
+	"bytes"
 	"path/filepath"
 	"testing"
 	"time"
@@ -56,3 +57,104 @@ func TestFileDebitReversal(t *testing.T) {
 	require.Len(t, entries, 1)
 	require.Equal(t, CheckingCredit, entries[0].TransactionCode)
 }
+
+func TestFile__CreateReversal(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	effectiveEntryDate := time.Date(2019, time.July, 26, 0, 0, 0, 0, time.UTC)
+	reversal, err := file.CreateReversal(ReversalSelection{TraceNumbers: []string{"121042880000002"}}, effectiveEntryDate)
+	require.NoError(t, err)
+	require.Len(t, reversal.Batches, 1)
+
+	bh := reversal.Batches[0].GetHeader()
+	require.Equal(t, "REVERSAL", bh.CompanyEntryDescription)
+	require.Equal(t, "190726", bh.EffectiveEntryDate)
+	require.Equal(t, DebitsOnly, bh.ServiceClassCode)
+
+	entries := reversal.Batches[0].GetEntries()
+	require.Len(t, entries, 1)
+	require.Equal(t, CheckingDebit, entries[0].TransactionCode)
+	require.Equal(t, 100000000, entries[0].Amount)
+	require.Equal(t, "121042880000002", entries[0].TraceNumber)
+	require.Equal(t, 100000000, reversal.Control.TotalDebitEntryDollarAmountInFile)
+	require.Equal(t, 0, reversal.Control.TotalCreditEntryDollarAmountInFile)
+
+	// the original file is untouched
+	require.Equal(t, "REG.SALARY", file.Batches[0].GetHeader().CompanyEntryDescription)
+	require.Equal(t, CheckingCredit, file.Batches[0].GetEntries()[1].TransactionCode)
+	require.Len(t, file.Batches[0].GetEntries(), 3)
+
+	// select the whole batch
+	file.Batches[0].SetID("batch-1")
+	reversal, err = file.CreateReversal(ReversalSelection{BatchID: "batch-1"}, effectiveEntryDate)
+	require.NoError(t, err)
+	require.Len(t, reversal.Batches[0].GetEntries(), 3)
+	require.Equal(t, MixedDebitsAndCredits, reversal.Batches[0].GetHeader().ServiceClassCode)
+
+	// select debits with a predicate
+	reversal, err = file.CreateReversal(ReversalSelection{
+		Entry: func(ed *EntryDetail) bool { return ed.TransactionCode == CheckingDebit },
+	}, effectiveEntryDate)
+	require.NoError(t, err)
+	entries = reversal.Batches[0].GetEntries()
+	require.Len(t, entries, 1)
+	require.Equal(t, CheckingCredit, entries[0].TransactionCode)
+	require.Equal(t, CreditsOnly, reversal.Batches[0].GetHeader().ServiceClassCode)
+}
+
+func TestFile__CreateReversalIAT(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "iat-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	effectiveEntryDate := time.Date(2019, time.August, 9, 0, 0, 0, 0, time.UTC)
+	reversal, err := file.CreateReversal(ReversalSelection{
+		IATEntry: func(ed *IATEntryDetail) bool { return ed.TransactionCode == CheckingDebit },
+	}, effectiveEntryDate)
+	require.NoError(t, err)
+	require.Len(t, reversal.IATBatches, 1)
+
+	bh := reversal.IATBatches[0].GetHeader()
+	require.Equal(t, "REVERSAL", bh.CompanyEntryDescription)
+	require.Equal(t, "190809", bh.EffectiveEntryDate)
+	require.Equal(t, CreditsOnly, bh.ServiceClassCode)
+
+	entries := reversal.IATBatches[0].Entries
+	require.Len(t, entries, 1)
+	require.Equal(t, CheckingCredit, entries[0].TransactionCode)
+	require.NotNil(t, entries[0].Addenda10)
+	require.NotSame(t, file.IATBatches[0].Entries[0].Addenda10, entries[0].Addenda10)
+	require.Equal(t, CheckingDebit, file.IATBatches[0].Entries[0].TransactionCode)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(reversal))
+	_, err = NewReader(&buf).Read()
+	require.NoError(t, err)
+}
+
+func TestFile__CreateReversalErrors(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	effectiveEntryDate := time.Date(2019, time.July, 26, 0, 0, 0, 0, time.UTC)
+
+	_, err = file.CreateReversal(ReversalSelection{}, effectiveEntryDate)
+	require.ErrorIs(t, err, ErrFileNoReversalEntries)
+
+	_, err = file.CreateReversal(ReversalSelection{Entry: func(*EntryDetail) bool { return false }}, effectiveEntryDate)
+	require.ErrorIs(t, err, ErrFileNoReversalEntries)
+
+	_, err = file.CreateReversal(ReversalSelection{TraceNumbers: []string{"999999999999999"}}, effectiveEntryDate)
+	require.ErrorIs(t, err, ErrFileReversalEntryNotFound)
+
+	_, err = file.CreateReversal(ReversalSelection{BatchID: "missing"}, effectiveEntryDate)
+	require.ErrorIs(t, err, ErrFileReversalEntryNotFound)
+
+	// five banking days after Friday 2019-07-19 is Friday 2019-07-26
+	selection := ReversalSelection{TraceNumbers: []string{"121042880000001"}}
+	_, err = file.CreateReversal(selection, time.Date(2019, time.July, 29, 0, 0, 0, 0, time.UTC))
+	require.ErrorIs(t, err, ErrFileReversalWindow)
+
+	_, err = file.CreateReversal(selection, time.Date(2019, time.July, 18, 0, 0, 0, 0, time.UTC))
+	require.ErrorIs(t, err, ErrFileReversalWindow)
+}
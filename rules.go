@@ -0,0 +1,218 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"sync"
+)
+
+// rules are the custom validation rules added with RegisterRule, RegisterEntryRule and RegisterFileRule.
+var rules = &ruleRegistry{
+	batch: make(map[string][]batchRule),
+	entry: make(map[string][]entryRule),
+}
+
+type ruleRegistry struct {
+	mu    sync.RWMutex
+	next  int
+	batch map[string][]batchRule
+	entry map[string][]entryRule
+	file  []fileRule
+}
+
+// batchRule, entryRule and fileRule are registered rules along with the id their unregister function removes.
+type batchRule struct {
+	id    int
+	check func(Batcher) error
+}
+
+type entryRule struct {
+	id    int
+	check func(*EntryDetail) error
+}
+
+type fileRule struct {
+	id    int
+	check func(*File) error
+}
+
+// RegisterRule adds a custom validation rule for batches with the Standard Entry Class Code sec.
+// An empty sec adds the rule to batches of every Standard Entry Class Code. The returned function
+// removes the rule.
+//
+// Rules run after the Nacha and Standard Entry Class Code checks of each batch in Batch.Validate,
+// File.ValidateWith and File.ValidateAllWith. Errors from a rule are returned as a *BatchError, so
+// rules can use the Error method of the Batcher to name the field with the problem.
+//
+// IAT batches are not a Batcher, use RegisterFileRule to check them.
+func RegisterRule(sec string, rule func(Batcher) error) (unregister func()) {
+	if rule == nil {
+		return func() {}
+	}
+	rules.mu.Lock()
+	defer rules.mu.Unlock()
+
+	rules.next++
+	id := rules.next
+	rules.batch[sec] = append(rules.batch[sec], batchRule{id: id, check: rule})
+
+	return func() {
+		rules.mu.Lock()
+		defer rules.mu.Unlock()
+
+		registered := rules.batch[sec]
+		for i := range registered {
+			if registered[i].id == id {
+				rules.batch[sec] = append(registered[:i:i], registered[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// RegisterEntryRule adds a custom validation rule for each EntryDetail in batches with the Standard Entry
+// Class Code sec. An empty sec adds the rule to batches of every Standard Entry Class Code. The returned
+// function removes the rule.
+//
+// Entry rules run with the rules added by RegisterRule. Returning a *FieldError names the field with the problem.
+func RegisterEntryRule(sec string, rule func(*EntryDetail) error) (unregister func()) {
+	if rule == nil {
+		return func() {}
+	}
+	rules.mu.Lock()
+	defer rules.mu.Unlock()
+
+	rules.next++
+	id := rules.next
+	rules.entry[sec] = append(rules.entry[sec], entryRule{id: id, check: rule})
+
+	return func() {
+		rules.mu.Lock()
+		defer rules.mu.Unlock()
+
+		registered := rules.entry[sec]
+		for i := range registered {
+			if registered[i].id == id {
+				rules.entry[sec] = append(registered[:i:i], registered[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// RegisterFileRule adds a custom validation rule for every File. File rules run after the Nacha
+// checks in File.ValidateWith and File.ValidateAllWith. The returned function removes the rule.
+func RegisterFileRule(rule func(*File) error) (unregister func()) {
+	if rule == nil {
+		return func() {}
+	}
+	rules.mu.Lock()
+	defer rules.mu.Unlock()
+
+	rules.next++
+	id := rules.next
+	rules.file = append(rules.file, fileRule{id: id, check: rule})
+
+	return func() {
+		rules.mu.Lock()
+		defer rules.mu.Unlock()
+
+		for i := range rules.file {
+			if rules.file[i].id == id {
+				rules.file = append(rules.file[:i:i], rules.file[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// customChecks returns the registered rules for batch as checks.
+func (batch *Batch) customChecks() []func() error {
+	sec := batch.Header.StandardEntryClassCode
+
+	rules.mu.RLock()
+	batchRules := append(append([]batchRule{}, rules.batch[""]...), rules.batch[sec]...)
+	entryRules := append(append([]entryRule{}, rules.entry[""]...), rules.entry[sec]...)
+	rules.mu.RUnlock()
+
+	var checks []func() error
+	if batch.streaming {
+		// batch rules need every entry, which ValidateStream doesn't keep
+		batchRules = nil
+	}
+	for i := range batchRules {
+		rule := batchRules[i].check
+		checks = append(checks, func() error {
+			return batch.ruleError(rule(batch))
+		})
+	}
+	if len(entryRules) > 0 {
+		checks = append(checks, func() error {
+			for _, entry := range batch.Entries {
+				for _, rule := range entryRules {
+					if err := rule.check(entry); err != nil {
+						return batch.ruleError(err)
+					}
+				}
+			}
+			return nil
+		})
+	}
+	return checks
+}
+
+// verifyRules returns the first error from the custom rules of batch. The Validate method of each
+// batch type calls it once the batch passes its other checks.
+func (batch *Batch) verifyRules() error {
+	for _, check := range batch.customChecks() {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ruleError wraps an error from a custom rule as a *BatchError.
+func (batch *Batch) ruleError(err error) error {
+	if err == nil {
+		return nil
+	}
+	field := errorFieldName(err)
+	if field == "" {
+		field = "Rule"
+	}
+	return batch.Error(field, err)
+}
+
+// validateRules returns the first error from the rules added with RegisterFileRule.
+func (f *File) validateRules() error {
+	for _, rule := range fileRules() {
+		if err := rule.check(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileRules returns the rules added with RegisterFileRule.
+func fileRules() []fileRule {
+	rules.mu.RLock()
+	defer rules.mu.RUnlock()
+
+	return append([]fileRule{}, rules.file...)
+}
@@ -0,0 +1,188 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterRule(t *testing.T) {
+	errBanned := errors.New("is a banned description")
+	t.Cleanup(RegisterRule(CCD, func(b Batcher) error {
+		if desc := b.GetHeader().CompanyEntryDescription; desc == "BANNED" {
+			return b.Error("CompanyEntryDescription", errBanned, desc)
+		}
+		return nil
+	}))
+
+	batch := mockBatchCCD(t)
+	require.NoError(t, batch.Validate())
+
+	batch.GetHeader().CompanyEntryDescription = "BANNED"
+	err := batch.Validate()
+	require.ErrorIs(t, err, errBanned)
+
+	var be *BatchError
+	require.True(t, errors.As(err, &be))
+	require.Equal(t, "CompanyEntryDescription", be.FieldName)
+
+	// other SEC codes are unaffected
+	ppd := NewBatchPPD(mockBatchPPDHeader())
+	ppd.GetHeader().CompanyEntryDescription = "BANNED"
+	ppd.AddEntry(mockPPDEntryDetail())
+	require.NoError(t, ppd.Create())
+
+	// rules for every SEC code
+	t.Cleanup(RegisterRule("", func(b Batcher) error {
+		if b.GetHeader().CompanyEntryDescription == "BANNED" {
+			return errBanned
+		}
+		return nil
+	}))
+	err = ppd.Validate()
+	require.ErrorIs(t, err, errBanned)
+	require.True(t, errors.As(err, &be))
+	require.Equal(t, "Rule", be.FieldName)
+}
+
+func TestRegisterEntryRule(t *testing.T) {
+	t.Cleanup(RegisterEntryRule(CCD, func(ed *EntryDetail) error {
+		if len(ed.Addenda05) == 0 {
+			return fieldError("Addenda05", ErrFieldRequired)
+		}
+		return nil
+	}))
+
+	batch := mockBatchCCD(t)
+	require.NoError(t, batch.Validate())
+
+	batch.GetEntries()[0].Addenda05 = nil
+	batch.GetEntries()[0].AddendaRecordIndicator = 0
+	err := batch.Create()
+	require.ErrorIs(t, err, ErrFieldRequired)
+
+	var be *BatchError
+	require.True(t, errors.As(err, &be))
+	require.Equal(t, "Addenda05", be.FieldName)
+}
+
+func TestRegisterFileRule(t *testing.T) {
+	errOrigin := errors.New("is not our bank")
+	t.Cleanup(RegisterFileRule(func(f *File) error {
+		if f.Header.ImmediateOrigin != "121042882" {
+			return fieldError("ImmediateOrigin", errOrigin, f.Header.ImmediateOrigin)
+		}
+		return nil
+	}))
+	t.Cleanup(RegisterRule(PPD, func(b Batcher) error {
+		if len(b.GetEntries()) > 1 {
+			return b.Error("entries", errors.New("only one entry per batch"))
+		}
+		return nil
+	}))
+
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	require.NoError(t, file.Validate())
+
+	file.Header.ImmediateOrigin = "231380104"
+	require.ErrorIs(t, file.Validate(), errOrigin)
+
+	entry := copyEntryDetail(file.Batches[0].GetEntries()[0])
+	entry.SetTraceNumber(file.Batches[0].GetHeader().ODFIIdentification, 2)
+	file.Batches[0].AddEntry(entry)
+	require.Error(t, file.Batches[0].Create())
+
+	// ValidateAll reports every rule, after the file totals which don't include the new entry
+	report := file.ValidateAll()
+	first, last := report.Findings[0], report.Findings[len(report.Findings)-1]
+	require.Equal(t, "Batch", first.Record)
+	require.Equal(t, "entries", first.FieldName)
+	require.Equal(t, "File", last.Record)
+	require.Equal(t, "ImmediateOrigin", last.FieldName)
+}
+
+func TestRegisterRule__AfterSECChecks(t *testing.T) {
+	errRule := errors.New("custom rule")
+	t.Cleanup(RegisterRule(PPD, func(b Batcher) error {
+		return errRule
+	}))
+	t.Cleanup(RegisterRule(PPD, func(b Batcher) error {
+		return b.Error("CompanyName", errRule)
+	}))
+
+	batch := NewBatchPPD(mockBatchPPDHeader())
+	entry := mockPPDEntryDetail()
+	entry.AddendaRecordIndicator = 1
+	entry.AddAddenda05(mockAddenda05())
+	entry.AddAddenda05(mockAddenda05())
+	batch.AddEntry(entry)
+
+	// PPD allows one Addenda05, which is checked before custom rules
+	err := batch.Create()
+	require.Error(t, err)
+	require.NotErrorIs(t, err, errRule)
+	require.ErrorContains(t, err, "AddendaCount")
+
+	entry.Addenda05 = entry.Addenda05[:1]
+	require.ErrorIs(t, batch.Create(), errRule)
+
+	// ValidateAll reports every custom rule
+	file := NewFile()
+	file.SetHeader(mockFileHeader())
+	file.AddBatch(batch)
+	require.NoError(t, file.Create())
+
+	report := file.ValidateAll()
+	require.Len(t, report.Findings, 2)
+	require.Equal(t, "Rule", report.Findings[0].FieldName)
+	require.Equal(t, "CompanyName", report.Findings[1].FieldName)
+}
+
+func TestRegisterRule__Unregister(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	errRule := errors.New("custom rule")
+	unregister := RegisterRule("", func(b Batcher) error {
+		return errRule
+	})
+	unregisterEntry := RegisterEntryRule("", func(ed *EntryDetail) error {
+		return errRule
+	})
+	unregisterFile := RegisterFileRule(func(f *File) error {
+		return errRule
+	})
+	require.ErrorIs(t, file.Batches[0].Validate(), errRule)
+
+	unregister()
+	require.ErrorIs(t, file.Batches[0].Validate(), errRule)
+	unregisterEntry()
+	require.NoError(t, file.Batches[0].Validate())
+	require.ErrorIs(t, file.Validate(), errRule)
+	unregisterFile()
+	require.NoError(t, file.Validate())
+
+	// removing a rule twice is a no-op
+	unregister()
+	require.NoError(t, file.Validate())
+}
@@ -0,0 +1,216 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SameDayEntryLimit is the largest amount (in cents) of an entry eligible for Same Day ACH, $1,000,000.
+const SameDayEntryLimit = 100000000
+
+// SameDayWindow is a FedACH processing window for Same Day ACH.
+type SameDayWindow struct {
+	// Deadline is the time (HHMM, Eastern) files must be submitted by.
+	Deadline string `json:"deadline"`
+
+	// Settlement is the time (HHMM, Eastern) entries in the window settle.
+	Settlement string `json:"settlement"`
+}
+
+// SameDayWindows are the Same Day ACH processing windows of the ACH Operators, in order.
+//
+// https://www.frbservices.org/resources/resource-centers/same-day-ach/fedach-processing-schedule.html
+var SameDayWindows = []SameDayWindow{
+	{Deadline: "1030", Settlement: "1300"},
+	{Deadline: "1445", Settlement: "1700"},
+	{Deadline: "1645", Settlement: "1800"},
+}
+
+// SameDayBatch is the classification of a batch by ClassifySameDay.
+type SameDayBatch struct {
+	// BatchNumber of the batch header
+	BatchNumber int `json:"batchNumber"`
+
+	// SameDay is true when the batch settles the day it's submitted.
+	SameDay bool `json:"sameDay"`
+
+	// Window is the index of SameDayWindows the batch settles in. It is -1 for next-day batches.
+	Window int `json:"window"`
+
+	// SettlementDate is the day the batch settles.
+	SettlementDate time.Time `json:"settlementDate"`
+
+	// Reason describes why a batch is next-day.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ClassifySameDay reports if each batch of f is settled same-day or next-day when the file is
+// submitted to the ACH Operator at submission.
+//
+// A batch is same-day when it's submitted before the last Same Day window on a banking day,
+// its Effective Entry Date is the submission date (or earlier) and all of its entries are eligible.
+// ADV batches are not classified.
+func (f *File) ClassifySameDay(submission time.Time) []SameDayBatch {
+	cal := NewCalendar()
+	submission = submission.In(easternTime())
+	today := truncateDate(submission)
+	window := sameDayWindowIndex(submission)
+
+	var out []SameDayBatch
+	classify := func(batchNumber int, effectiveEntryDate string, eligible error) {
+		result := SameDayBatch{BatchNumber: batchNumber, Window: -1}
+
+		effective, err := time.Parse("060102", effectiveEntryDate)
+		if err != nil {
+			effective = today // invalid dates are settled at the earliest opportunity
+		}
+		switch {
+		case eligible != nil:
+			result.Reason = eligible.Error()
+		case effective.After(today):
+			result.Reason = "effective entry date is in the future"
+		case !cal.IsBankingDay(today):
+			result.Reason = "submitted on a non-banking day"
+		case window < 0:
+			result.Reason = "submitted after the last Same Day window"
+		default:
+			result.SameDay = true
+			result.Window = window
+			result.SettlementDate = today
+		}
+		if !result.SameDay {
+			if effective.After(today) {
+				result.SettlementDate = cal.SettlementDate(effective)
+			} else {
+				result.SettlementDate = cal.NextBankingDay(today)
+			}
+		}
+		out = append(out, result)
+	}
+
+	for _, b := range f.Batches {
+		bh := b.GetHeader()
+		if bh.StandardEntryClassCode == ADV {
+			continue
+		}
+		classify(bh.BatchNumber, bh.EffectiveEntryDate, sameDayEntriesEligible(b.GetEntries()))
+	}
+	for _, b := range f.IATBatches {
+		bh := b.GetHeader()
+		classify(bh.BatchNumber, bh.EffectiveEntryDate, ErrBatchSameDayIAT)
+	}
+	return out
+}
+
+// validateSameDay checks every batch of f follows the Same Day ACH rules. The first error is returned.
+func (f *File) validateSameDay() error {
+	if len(f.IATBatches) > 0 {
+		return f.IATBatches[0].Error("StandardEntryClassCode", ErrBatchSameDayIAT, IAT)
+	}
+
+	cal := NewCalendar()
+	created, err := time.Parse("060102", f.Header.FileCreationDate)
+	if err != nil {
+		return fieldError("FileCreationDate", ErrValidDay, f.Header.FileCreationDate)
+	}
+	expected := cal.SettlementDate(created)
+
+	for _, b := range f.Batches {
+		bh := b.GetHeader()
+		if bh.StandardEntryClassCode == ADV {
+			continue
+		}
+		batchError := func(field string, err error, value interface{}) error {
+			return &BatchError{
+				BatchNumber: bh.BatchNumber,
+				BatchType:   bh.StandardEntryClassCode,
+				FieldName:   field,
+				FieldValue:  value,
+				Err:         err,
+			}
+		}
+
+		if err := sameDayEntriesEligible(b.GetEntries()); err != nil {
+			return batchError("Amount", err, nil)
+		}
+		if err := checkSameDayDescriptiveDate(bh.CompanyDescriptiveDate); err != nil {
+			return batchError("CompanyDescriptiveDate", err, bh.CompanyDescriptiveDate)
+		}
+
+		effective, err := time.Parse("060102", bh.EffectiveEntryDate)
+		if err != nil || !effective.Equal(expected) {
+			return batchError("EffectiveEntryDate", ErrBatchSameDayEffectiveEntryDate, bh.EffectiveEntryDate)
+		}
+		if settlement := strings.TrimSpace(bh.SettlementDate); settlement != "" {
+			if settlement != fmt.Sprintf("%03d", effective.YearDay()) {
+				return batchError("SettlementDate", ErrBatchSameDaySettlementDate, bh.SettlementDate)
+			}
+		}
+	}
+	return nil
+}
+
+// sameDayEntriesEligible returns an error if any entry is over the SameDayEntryLimit.
+func sameDayEntriesEligible(entries []*EntryDetail) error {
+	for _, entry := range entries {
+		if entry.Amount > SameDayEntryLimit {
+			return fmt.Errorf("trace number %s: %w", entry.TraceNumber, ErrBatchSameDayAmount)
+		}
+	}
+	return nil
+}
+
+// checkSameDayDescriptiveDate verifies a Company Descriptive Date using the "SDHHMM" convention
+// has the settlement time of a Same Day window.
+func checkSameDayDescriptiveDate(value string) error {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "SD") {
+		return nil
+	}
+	for _, window := range SameDayWindows {
+		if value[2:] == window.Settlement {
+			return nil
+		}
+	}
+	return ErrBatchSameDayDescriptiveDate
+}
+
+// sameDayWindowIndex returns the index of the first Same Day window whose deadline submission
+// (in Eastern time) is before, or -1 if every deadline has passed.
+func sameDayWindowIndex(submission time.Time) int {
+	hhmm := submission.Format("1504")
+	for i, window := range SameDayWindows {
+		if hhmm < window.Deadline {
+			return i
+		}
+	}
+	return -1
+}
+
+// easternTime returns the time zone of the ACH Operators. A fixed offset from UTC is used if
+// the time zone database is unavailable.
+func easternTime() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.FixedZone("EST", -5*60*60)
+	}
+	return loc
+}
@@ -0,0 +1,110 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile__ValidateSameDay(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	opts := &ValidateOpts{SameDay: true}
+	require.NoError(t, file.ValidateWith(nil))
+
+	// the effective entry date is the next day
+	err = file.ValidateWith(opts)
+	require.ErrorIs(t, err, ErrBatchSameDayEffectiveEntryDate)
+
+	bh := file.Batches[0].GetHeader()
+	bh.EffectiveEntryDate = "190624"
+	require.NoError(t, file.ValidateWith(opts))
+
+	bh.CompanyDescriptiveDate = "SD1300"
+	require.NoError(t, file.ValidateWith(opts))
+	bh.CompanyDescriptiveDate = "SD1200"
+	require.ErrorIs(t, file.ValidateWith(opts), ErrBatchSameDayDescriptiveDate)
+	bh.CompanyDescriptiveDate = ""
+
+	bh.SettlementDate = "175"
+	require.NoError(t, file.ValidateWith(opts))
+	bh.SettlementDate = "176"
+	require.ErrorIs(t, file.ValidateWith(opts), ErrBatchSameDaySettlementDate)
+	bh.SettlementDate = ""
+
+	entry := file.Batches[0].GetEntries()[0]
+	entry.Amount = SameDayEntryLimit + 1
+	require.NoError(t, file.Batches[0].Create())
+	require.NoError(t, file.Create())
+	require.ErrorIs(t, file.ValidateWith(opts), ErrBatchSameDayAmount)
+}
+
+func TestFile__ValidateSameDayIAT(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "iat-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	err = file.ValidateWith(&ValidateOpts{SameDay: true})
+	require.ErrorIs(t, err, ErrBatchSameDayIAT)
+}
+
+func TestFile__ClassifySameDay(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	file.Batches[0].GetHeader().EffectiveEntryDate = "190624"
+
+	eastern := easternTime()
+	submitted := func(hour, min int) time.Time {
+		return time.Date(2019, time.June, 24, hour, min, 0, 0, eastern)
+	}
+
+	batches := file.ClassifySameDay(submitted(9, 0))
+	require.Len(t, batches, 1)
+	require.True(t, batches[0].SameDay)
+	require.Equal(t, 0, batches[0].Window)
+	require.Equal(t, calendarDate(2019, time.June, 24), batches[0].SettlementDate)
+
+	batches = file.ClassifySameDay(submitted(15, 0))
+	require.True(t, batches[0].SameDay)
+	require.Equal(t, 2, batches[0].Window)
+
+	// after the last window
+	batches = file.ClassifySameDay(submitted(17, 0))
+	require.False(t, batches[0].SameDay)
+	require.Equal(t, -1, batches[0].Window)
+	require.Equal(t, calendarDate(2019, time.June, 25), batches[0].SettlementDate)
+	require.NotEmpty(t, batches[0].Reason)
+
+	// future effective entry date
+	file.Batches[0].GetHeader().EffectiveEntryDate = "190626"
+	batches = file.ClassifySameDay(submitted(9, 0))
+	require.False(t, batches[0].SameDay)
+	require.Equal(t, calendarDate(2019, time.June, 26), batches[0].SettlementDate)
+
+	// IAT entries are never same-day
+	iat, err := ReadFile(filepath.Join("test", "testdata", "iat-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+	batches = iat.ClassifySameDay(time.Date(2019, time.August, 8, 9, 0, 0, 0, eastern))
+	require.Len(t, batches, 1)
+	require.False(t, batches[0].SameDay)
+	require.Equal(t, ErrBatchSameDayIAT.Error(), batches[0].Reason)
+}
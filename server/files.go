@@ -423,6 +423,9 @@ type validateFileRequest struct {
 
 type validateFileResponse struct {
 	Err error `json:"error"`
+
+	// Warnings are the problems downgraded with ValidateOpts.Severities in a valid file
+	Warnings []ach.ValidationFinding `json:"warnings,omitempty"`
 }
 
 func (v validateFileResponse) error() error { return v.Err }
@@ -447,9 +450,18 @@ func validateFileEndpoint(s Service, logger log.Logger) endpoint.Endpoint {
 			}
 		}
 		if err != nil { // wrap err with context
-			err = fmt.Errorf("%v: %v", errInvalidFile, err)
+			return validateFileResponse{Err: fmt.Errorf("%v: %v", errInvalidFile, err)}, nil
+		}
+
+		resp := validateFileResponse{}
+		if req.opts != nil && len(req.opts.Severities) > 0 {
+			report, err := s.ValidateFileReport(req.ID, req.opts)
+			if err != nil {
+				return validateFileResponse{Err: err}, err
+			}
+			resp.Warnings = report.Warnings()
 		}
-		return validateFileResponse{err}, nil
+		return resp, nil
 	}
 }
 
@@ -474,6 +486,53 @@ func decodeValidateFileRequest(_ context.Context, r *http.Request) (interface{},
 	return req, nil
 }
 
+type validateStreamRequest struct {
+	body      io.Reader
+	requestID string
+
+	opts *ach.ValidateOpts
+}
+
+func validateStreamEndpoint(logger log.Logger) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(validateStreamRequest)
+		if !ok {
+			return validateFileResponse{Err: ErrFoundABug}, ErrFoundABug
+		}
+
+		err := ach.ValidateStream(req.body, req.opts)
+		if logger != nil {
+			logger := logger.With(log.Fields{
+				"files":     log.String("validateStream"),
+				"requestID": log.String(req.requestID),
+			})
+			if err != nil {
+				logger.Error().LogError(err)
+			} else {
+				logger.Info().Log("validate stream")
+			}
+		}
+		if err != nil { // wrap err with context
+			return validateFileResponse{Err: fmt.Errorf("%v: %v", errInvalidFile, err)}, nil
+		}
+		return validateFileResponse{}, nil
+	}
+}
+
+// decodeValidateStreamRequest reads ValidateOpts from the query parameters only, so the
+// Nacha formatted file in the body can be validated as it's read.
+func decodeValidateStreamRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	opts, err := queryValidateOpts(r, &ach.ValidateOpts{}, "")
+	if err != nil {
+		return nil, err
+	}
+	return validateStreamRequest{
+		body:      r.Body,
+		requestID: moovhttp.GetRequestID(r),
+		opts:      opts,
+	}, nil
+}
+
 type balanceFileRequest struct {
 	fileID    string
 	offset    *ach.Offset
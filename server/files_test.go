@@ -304,6 +304,18 @@ func TestFiles__decodeCreateFileRequest__validateOpts(t *testing.T) {
 				AllowInvalidAmounts: true,
 			},
 		},
+		{
+			query: "?sameDay=true",
+			expect: ach.ValidateOpts{
+				SameDay: true,
+			},
+		},
+		{
+			query: "?preserveUnknownRecords=true",
+			expect: ach.ValidateOpts{
+				PreserveUnknownRecords: true,
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -590,6 +602,148 @@ func TestFiles__ValidateOpts(t *testing.T) {
 	}
 }
 
+func TestFiles__ValidateOptsSeverities(t *testing.T) {
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+
+	fd, err := os.Open(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	require.NoError(t, err)
+	defer fd.Close()
+
+	bs, _ := io.ReadAll(fd)
+	file, _ := ach.FileFromJSON(bs)
+	file.Header.ImmediateOrigin = "123456789" // invalid routing number
+	repo.StoreFile(file)
+
+	router := mux.NewRouter()
+	router.Methods("POST").Path("/files/{id}/validate").Handler(
+		httptransport.NewServer(validateFileEndpoint(svc, logger), decodeValidateFileRequest, encodeResponse),
+	)
+
+	// the invalid origin is a warning
+	w := httptest.NewRecorder()
+	body := strings.NewReader(`{"requireABAOrigin": true, "severities": {"ImmediateOrigin": "warning"}}`)
+	req := httptest.NewRequest("POST", fmt.Sprintf("/files/%s/validate", file.ID), body)
+	router.ServeHTTP(w, req)
+	w.Flush()
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp struct {
+		Error    *string `json:"error"`
+		Warnings []struct {
+			Record    string `json:"record"`
+			FieldName string `json:"fieldName"`
+			Severity  string `json:"severity"`
+		} `json:"warnings"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Nil(t, resp.Error)
+	require.Len(t, resp.Warnings, 1)
+	require.Equal(t, "FileHeader", resp.Warnings[0].Record)
+	require.Equal(t, "ImmediateOrigin", resp.Warnings[0].FieldName)
+	require.Equal(t, "warning", resp.Warnings[0].Severity)
+
+	// without the severity it's an error
+	w = httptest.NewRecorder()
+	body = strings.NewReader(`{"requireABAOrigin": true}`)
+	req = httptest.NewRequest("POST", fmt.Sprintf("/files/%s/validate", file.ID), body)
+	router.ServeHTTP(w, req)
+	w.Flush()
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.NotContains(t, w.Body.String(), "arnings")
+}
+
+func TestFiles__ValidateStream(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("..", "test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.Methods("POST").Path("/validate").Handler(
+		httptransport.NewServer(validateStreamEndpoint(log.NewNopLogger()), decodeValidateStreamRequest, encodeResponse),
+	)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/validate", bytes.NewReader(bs))
+	router.ServeHTTP(w, req)
+	w.Flush()
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// drop the FileControl record
+	var lines []string
+	for _, line := range strings.Split(string(bs), "\n") {
+		if !strings.HasPrefix(line, "9") {
+			lines = append(lines, line)
+		}
+	}
+	body := strings.Join(lines, "\n")
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/validate", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+	w.Flush()
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "invalid ACH file")
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/validate?allowMissingFileControl=true", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+	w.Flush()
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func TestFiles__ValidateProfile(t *testing.T) {
+	logger := log.NewNopLogger()
+	repo := NewRepositoryInMemory(testTTLDuration, logger)
+	svc := NewService(repo)
+
+	err := ach.RegisterValidationProfile(ach.ValidationProfile{
+		Name:         "server-test-partner",
+		ValidateOpts: &ach.ValidateOpts{RequireABAOrigin: true},
+	})
+	require.NoError(t, err)
+
+	fd, err := os.Open(filepath.Join("..", "test", "testdata", "ppd-valid.json"))
+	require.NoError(t, err)
+	defer fd.Close()
+
+	bs, _ := io.ReadAll(fd)
+	file, _ := ach.FileFromJSON(bs)
+	file.Header.ImmediateOrigin = "123456789" // invalid routing number
+	repo.StoreFile(file)
+
+	router := mux.NewRouter()
+	router.Methods("POST").Path("/files/{id}/validate").Handler(
+		httptransport.NewServer(validateFileEndpoint(svc, logger), decodeValidateFileRequest, encodeResponse,
+			httptransport.ServerErrorEncoder(encodeError)),
+	)
+
+	validate := func(query, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", fmt.Sprintf("/files/%s/validate%s", file.ID, query), strings.NewReader(body))
+		router.ServeHTTP(w, req)
+		w.Flush()
+		return w
+	}
+
+	// the profile requires a routing number origin
+	w := validate("?profile=server-test-partner", "")
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	require.Contains(t, w.Body.String(), "ImmediateOrigin")
+
+	w = validate("", `{"profile": "server-test-partner"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	// options are layered over the profile
+	w = validate("?profile=server-test-partner&bypassOrigin=true", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = validate("?profile=missing", "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "validation profile not found")
+	require.Contains(t, w.Body.String(), `"code":"ACH-FILE-VALIDATION-PROFILE-NOT-FOUND"`)
+}
+
 func TestFilesErr__balanceFileEndpoint(t *testing.T) {
 	repo := NewRepositoryInMemory(testTTLDuration, nil)
 	svc := NewService(repo)
@@ -1309,8 +1463,10 @@ func TestFiles__CreateFileEndpointErr(t *testing.T) {
 	}
 
 	var resp struct {
-		ID  string `json:"id"`
-		Err string `json:"error"`
+		ID       string `json:"id"`
+		Err      string `json:"error"`
+		Code     string `json:"code"`
+		Category string `json:"category"`
 	}
 	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
 		t.Fatal(err)
@@ -1318,6 +1474,8 @@ func TestFiles__CreateFileEndpointErr(t *testing.T) {
 	if resp.ID == "" || resp.Err == "" {
 		t.Errorf("resp.ID=%q resp.Err=%q", resp.ID, resp.Err)
 	}
+	require.Equal(t, "ACH-FILE-RECORD-LENGTH", resp.Code)
+	require.Equal(t, "file", resp.Category)
 }
 
 func TestFiles__CreateFileEndpointJSONErr(t *testing.T) {
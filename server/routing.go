@@ -28,6 +28,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/moov-io/ach"
 	"github.com/moov-io/base"
 	moovhttp "github.com/moov-io/base/http"
 	"github.com/moov-io/base/log"
@@ -46,6 +47,8 @@ var (
 	ErrFoundABug  = fmt.Errorf("snuck into encodeError with err == nil, %s", bugReportHelp)
 
 	errInvalidFile = errors.New("invalid ACH file")
+
+	errInvalidValidateOpts = errors.New("invalid validation options")
 )
 
 // contextKey is a unique (and compariable) type we use
@@ -157,6 +160,12 @@ func MakeHTTPHandler(s Service, repo Repository, kitlog gokitlog.Logger) http.Ha
 		encodeResponse,
 		options...,
 	))
+	r.Methods("POST").Path("/validate").Handler(httptransport.NewServer(
+		validateStreamEndpoint(logger),
+		decodeValidateStreamRequest,
+		encodeResponse,
+		options...,
+	))
 	r.Methods("DELETE").Path("/files/{id}").Handler(httptransport.NewServer(
 		deleteFileEndpoint(s, logger),
 		decodeDeleteFileRequest,
@@ -245,8 +254,11 @@ func marshalStructWithError(in interface{}, w http.ResponseWriter) error {
 		if value == nil {
 			continue
 		}
+		if strings.Contains(v.Type().Field(i).Tag.Get("json"), ",omitempty") && v.Field(i).IsZero() {
+			continue
+		}
 		if err, ok := value.(error); ok {
-			out["error"] = err.Error()
+			addError(out, err)
 		} else {
 			out[name] = value
 		}
@@ -300,14 +312,23 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(codeFrom(err))
-	err = json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": err.Error(),
-	})
+	out := make(map[string]interface{})
+	addError(out, err)
+	err = json.NewEncoder(w).Encode(out)
 	if err != nil {
 		w.Write([]byte(fmt.Sprintf("problem rendering json: %v", err)))
 	}
 }
 
+// addError sets the message of err in out, along with its stable code and category when it has one.
+func addError(out map[string]interface{}, err error) {
+	out["error"] = err.Error()
+	if code := ach.CodeOf(err); code != "" {
+		out["code"] = code
+		out["category"] = code.Category()
+	}
+}
+
 func codeFrom(err error) int {
 	if err == nil {
 		return http.StatusOK
@@ -326,6 +347,11 @@ func codeFrom(err error) int {
 		strings.Contains(errString, "ach.RecordWrongLengthErr"),
 		strings.Contains(errString, "FieldName"): // FileFromJSON
 		return http.StatusBadRequest
+	case
+		errors.Is(err, errInvalidValidateOpts),
+		errors.Is(err, ach.ErrValidationProfileNotFound),
+		errors.Is(err, ach.ErrValidationProfileCycle):
+		return http.StatusBadRequest
 	}
 
 	switch err {
@@ -49,6 +49,8 @@ type Service interface {
 	GetFileContents(id string, opts *ach.WriteOpts) (io.Reader, error)
 	// ValidateFile
 	ValidateFile(id string, opts *ach.ValidateOpts) error
+	// ValidateFileReport checks every record of a file and returns every problem found
+	ValidateFileReport(id string, opts *ach.ValidateOpts) (*ach.ValidationReport, error)
 	// BalanceFile will apply a given offset record to the file
 	BalanceFile(fileID string, off *ach.Offset) (*ach.File, error)
 	// SegmentFileID segments an ach file
@@ -162,6 +164,14 @@ func (s *service) ValidateFile(id string, opts *ach.ValidateOpts) error {
 	return f.ValidateWith(opts)
 }
 
+func (s *service) ValidateFileReport(id string, opts *ach.ValidateOpts) (*ach.ValidationReport, error) {
+	f, err := s.GetFile(id)
+	if err != nil {
+		return nil, fmt.Errorf("problem reading file %s: %v", id, err)
+	}
+	return f.ValidateAllWith(opts), nil
+}
+
 func (s *service) CreateBatch(fileID string, batch ach.Batcher) (string, error) {
 	if batch == nil {
 		return "", errors.New("no batch provided")
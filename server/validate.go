@@ -24,6 +24,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/moov-io/ach"
 )
@@ -48,13 +49,54 @@ const (
 	unequalAddendaCounts             = "unequalAddendaCounts"
 	preserveSpaces                   = "preserveSpaces"
 	allowInvalidAmounts              = "allowInvalidAmounts"
+	sameDay                          = "sameDay"
+	preserveUnknownRecords           = "preserveUnknownRecords"
+
+	// profile is the name of a validation profile the other options are merged onto
+	profile = "profile"
 )
 
 // readValidateOpts parses ValidateOpts from the URL query parameters and from the request body.
 // A copy of the request body is returned. Callers are responsible for closing the body.
 //
-// Query parameters override the JSON body
+// Query parameters override the JSON body, which is an error when it can't be parsed. A validation profile
+// named by the "profile" query parameter or JSON field is merged with the other options.
 func readValidateOpts(request *http.Request) (io.Reader, *ach.ValidateOpts, error) {
+	var buf bytes.Buffer
+	bs, _ := io.ReadAll(io.TeeReader(request.Body, &buf))
+
+	opts := &ach.ValidateOpts{}
+	var named struct {
+		Profile string `json:"profile"`
+	}
+	if isJSONBody(request, bs) {
+		if err := json.Unmarshal(bs, opts); err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", errInvalidValidateOpts, err)
+		}
+		if err := json.Unmarshal(bs, &named); err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", errInvalidValidateOpts, err)
+		}
+	}
+
+	opts, err := queryValidateOpts(request, opts, named.Profile)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &buf, opts, nil
+}
+
+// isJSONBody returns true if the request body is JSON, rather than a Nacha formatted file or empty.
+func isJSONBody(request *http.Request, bs []byte) bool {
+	bs = bytes.TrimSpace(bs)
+	if len(bs) == 0 {
+		return false
+	}
+	return bytes.HasPrefix(bs, []byte("{")) || strings.Contains(strings.ToLower(request.Header.Get("Content-Type")), "application/json")
+}
+
+// queryValidateOpts sets the options of the URL query parameters on opts and merges opts onto
+// the validation profile named by the "profile" query parameter, or profileName when it's missing.
+func queryValidateOpts(request *http.Request, opts *ach.ValidateOpts, profileName string) (*ach.ValidateOpts, error) {
 	validationNames := []string{
 		skipAll,
 		requireABAOrigin,
@@ -75,14 +117,10 @@ func readValidateOpts(request *http.Request) (io.Reader, *ach.ValidateOpts, erro
 		unequalAddendaCounts,
 		preserveSpaces,
 		allowInvalidAmounts,
+		sameDay,
+		preserveUnknownRecords,
 	}
 
-	var buf bytes.Buffer
-	bs, _ := io.ReadAll(io.TeeReader(request.Body, &buf))
-
-	opts := &ach.ValidateOpts{}
-	json.Unmarshal(bs, opts)
-
 	for _, name := range validationNames {
 		q := request.URL.Query()
 		if q == nil {
@@ -95,7 +133,7 @@ func readValidateOpts(request *http.Request) (io.Reader, *ach.ValidateOpts, erro
 
 		yes, err := strconv.ParseBool(input)
 		if err != nil {
-			return nil, nil, fmt.Errorf("%s is an invalid boolean: %v", name, err)
+			return nil, fmt.Errorf("%s is an invalid boolean: %v", name, err)
 		}
 		switch name {
 		case skipAll:
@@ -130,8 +168,22 @@ func readValidateOpts(request *http.Request) (io.Reader, *ach.ValidateOpts, erro
 			opts.PreserveSpaces = yes
 		case allowInvalidAmounts:
 			opts.AllowInvalidAmounts = yes
+		case sameDay:
+			opts.SameDay = yes
+		case preserveUnknownRecords:
+			opts.PreserveUnknownRecords = yes
 		}
 	}
 
-	return &buf, opts, nil
+	if q := request.URL.Query(); q != nil && q.Get(profile) != "" {
+		profileName = q.Get(profile)
+	}
+	if profileName != "" {
+		profileOpts, err := ach.ValidationProfileOpts(profileName)
+		if err != nil {
+			return nil, err
+		}
+		opts = profileOpts.Merge(opts)
+	}
+	return opts, nil
 }
@@ -42,3 +42,29 @@ func TestReadValidateOpts(t *testing.T) {
 	require.True(t, opts.AllowUnorderedBatchNumbers)
 	require.True(t, opts.AllowInvalidCheckDigit)
 }
+
+func TestReadValidateOpts__InvalidJSON(t *testing.T) {
+	req, err := http.NewRequest("POST", "/files/f1/validate", strings.NewReader(`{"requireABAOrigin": "yes"}`))
+	require.NoError(t, err)
+
+	_, _, err = readValidateOpts(req)
+	require.ErrorIs(t, err, errInvalidValidateOpts)
+	require.Equal(t, http.StatusBadRequest, codeFrom(err))
+
+	req, err = http.NewRequest("POST", "/files/f1/validate", strings.NewReader(`{"bypassOrigin`))
+	require.NoError(t, err)
+	_, _, err = readValidateOpts(req)
+	require.ErrorIs(t, err, errInvalidValidateOpts)
+
+	// Nacha formatted and empty bodies aren't JSON
+	req, err = http.NewRequest("POST", "/files/create", strings.NewReader("101 031300012 2313801041812180000A094101Federal Reserve Bank   My Bank Name           12345678"))
+	require.NoError(t, err)
+	_, _, err = readValidateOpts(req)
+	require.NoError(t, err)
+
+	req, err = http.NewRequest("POST", "/files/f1/validate", strings.NewReader(""))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	_, _, err = readValidateOpts(req)
+	require.NoError(t, err)
+}
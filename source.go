@@ -0,0 +1,83 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import "errors"
+
+// source is the position of a record in the input a Reader read it from. It isn't written to
+// ACH or JSON files, so records copied or decoded from JSON have no source.
+type source struct {
+	line   int
+	offset int64
+}
+
+// SourceLine returns the line number (starting at 1) the record was read from, or 0 when the
+// record wasn't read by a Reader. Records of a file with every record on one line share a line.
+func (s source) SourceLine() int {
+	return s.line
+}
+
+// SourceOffset returns the byte offset of the record in the input it was read from.
+// It's only meaningful when SourceLine is not 0.
+func (s source) SourceOffset() int64 {
+	return s.offset
+}
+
+// atLine returns err with line, the line of the record it was found in, when it's a *FieldError or
+// *BatchError without a line. The error is copied as records may return shared errors.
+func atLine(err error, line int) error {
+	if line == 0 {
+		return err
+	}
+	switch e := err.(type) {
+	case *FieldError:
+		if e.Line == 0 {
+			copied := *e
+			copied.Line = line
+			return &copied
+		}
+	case *BatchError:
+		if e.Line == 0 {
+			copied := *e
+			copied.Line = line
+			return &copied
+		}
+	}
+	return err
+}
+
+// errorSourceLine returns the line of the record err was found in, or 0 when it isn't known.
+func errorSourceLine(err error) int {
+	var be *BatchError
+	if errors.As(err, &be) {
+		return be.Line
+	}
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		return fe.Line
+	}
+	return 0
+}
+
+// errorLine returns the line of the record err was found in, or line when err doesn't have one.
+func errorLine(err error, line int) int {
+	if fe, ok := err.(*FieldError); ok && fe.Line > 0 {
+		return fe.Line
+	}
+	return line
+}
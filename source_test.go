@@ -0,0 +1,82 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader__SourceLines(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "two-micro-deposits.ach"))
+	require.NoError(t, err)
+
+	require.Equal(t, 1, file.Header.SourceLine())
+	require.Equal(t, int64(0), file.Header.SourceOffset())
+
+	bh := file.Batches[0].GetHeader()
+	require.Equal(t, 2, bh.SourceLine())
+	require.Equal(t, int64(95), bh.SourceOffset())
+
+	ed := file.Batches[0].GetEntries()[0]
+	require.Equal(t, 3, ed.SourceLine())
+	require.Equal(t, int64(190), ed.SourceOffset())
+	require.Equal(t, 4, ed.Addenda05[0].SourceLine())
+	require.Equal(t, int64(285), ed.Addenda05[0].SourceOffset())
+
+	bc := file.Batches[0].GetControl()
+	require.Equal(t, 9, bc.SourceLine())
+	require.Equal(t, 18, file.Control.SourceLine())
+	require.Equal(t, int64(17*95), file.Control.SourceOffset())
+
+	// sources aren't part of the JSON
+	bs, err := json.Marshal(file)
+	require.NoError(t, err)
+	require.NotContains(t, strings.ToLower(string(bs)), "source")
+
+	// records which weren't read have no source
+	require.Zero(t, NewEntryDetail().SourceLine())
+}
+
+func TestReader__SourceLinesFixedWidth(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit-fixedLength.ach"))
+	require.NoError(t, err)
+
+	// records without line endings are read one at a time
+	ed := file.Batches[0].GetEntries()[0]
+	require.Equal(t, 3, ed.SourceLine())
+	require.Equal(t, int64(2*RecordLength), ed.SourceOffset())
+	require.Equal(t, int64(4*RecordLength), file.Control.SourceOffset())
+}
+
+func TestReader__SourceLinesCRLF(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	file, err := NewReader(strings.NewReader(strings.ReplaceAll(string(bs), "\n", "\r\n"))).Read()
+	require.NoError(t, err)
+
+	ed := file.Batches[0].GetEntries()[0]
+	require.Equal(t, 3, ed.SourceLine())
+	require.Equal(t, int64(75+2+94+2), ed.SourceOffset()) // the FileHeader is short
+}
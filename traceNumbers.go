@@ -0,0 +1,187 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// maxTraceSequence is the largest sequence number which fits in the last 7 digits of a trace number.
+const maxTraceSequence = 9999999
+
+// TraceNumberAllocator hands out unique trace numbers for each ODFI.
+//
+// Trace numbers are 15 digits: the first 8 digits of the ODFI's routing number followed by a
+// 7 digit sequence number. Implementations must be safe for concurrent use.
+type TraceNumberAllocator interface {
+	// Next returns the next unused trace number for odfi, an 8 or 9 digit routing number.
+	Next(odfi string) (string, error)
+}
+
+// MemoryTraceNumberAllocator is a TraceNumberAllocator which keeps the last sequence number of each ODFI in memory.
+type MemoryTraceNumberAllocator struct {
+	mu   sync.Mutex
+	last map[string]int
+}
+
+// NewMemoryTraceNumberAllocator returns a MemoryTraceNumberAllocator starting every ODFI at sequence number 1.
+func NewMemoryTraceNumberAllocator() *MemoryTraceNumberAllocator {
+	return &MemoryTraceNumberAllocator{
+		last: make(map[string]int),
+	}
+}
+
+// Next returns the next unused trace number for odfi.
+func (a *MemoryTraceNumberAllocator) Next(odfi string) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return nextTraceNumber(a.last, odfi)
+}
+
+// SetLast sets the last sequence number given out for odfi, so the next trace number is last + 1.
+// Once every sequence number has been given out, SetLast(odfi, 0) starts the sequence over at 1.
+func (a *MemoryTraceNumberAllocator) SetLast(odfi string, last int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.last[aba8(odfi)] = last
+}
+
+// FileTraceNumberAllocator is a TraceNumberAllocator which saves the last sequence number of each ODFI
+// to a JSON file after every allocation, so trace numbers stay unique across restarts.
+//
+// The file should only be used by one FileTraceNumberAllocator at a time.
+type FileTraceNumberAllocator struct {
+	mu   sync.Mutex
+	path string
+	last map[string]int
+}
+
+// NewFileTraceNumberAllocator returns a FileTraceNumberAllocator which reads and saves its state at path.
+// A missing file is created on the first allocation.
+func NewFileTraceNumberAllocator(path string) (*FileTraceNumberAllocator, error) {
+	a := &FileTraceNumberAllocator{
+		path: path,
+		last: make(map[string]int),
+	}
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return a, nil
+		}
+		return nil, fmt.Errorf("reading trace numbers: %w", err)
+	}
+	if len(bs) > 0 {
+		if err := json.Unmarshal(bs, &a.last); err != nil {
+			return nil, fmt.Errorf("reading trace numbers from %s: %w", path, err)
+		}
+	}
+	return a, nil
+}
+
+// Next returns the next unused trace number for odfi. The trace number is saved before it's returned.
+func (a *FileTraceNumberAllocator) Next(odfi string) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	trace, err := nextTraceNumber(a.last, odfi)
+	if err != nil {
+		return "", err
+	}
+	if err := a.save(); err != nil {
+		// give the sequence number back as the allocation didn't persist
+		a.last[aba8(odfi)]--
+		return "", err
+	}
+	return trace, nil
+}
+
+// SetLast sets the last sequence number given out for odfi, so the next trace number is last + 1, and saves it.
+// Once every sequence number has been given out, SetLast(odfi, 0) starts the sequence over at 1.
+func (a *FileTraceNumberAllocator) SetLast(odfi string, last int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	prefix := aba8(odfi)
+	previous, ok := a.last[prefix]
+	a.last[prefix] = last
+	if err := a.save(); err != nil {
+		if ok {
+			a.last[prefix] = previous
+		} else {
+			delete(a.last, prefix)
+		}
+		return err
+	}
+	return nil
+}
+
+// save writes the state to a temporary file and renames it over path, so a crash doesn't leave a partial file.
+func (a *FileTraceNumberAllocator) save() error {
+	bs, err := json.Marshal(a.last)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(a.path), filepath.Base(a.path)+".*")
+	if err != nil {
+		return fmt.Errorf("saving trace numbers: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(bs); err != nil {
+		tmp.Close()
+		return fmt.Errorf("saving trace numbers: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("saving trace numbers: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("saving trace numbers: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), a.path); err != nil {
+		return fmt.Errorf("saving trace numbers: %w", err)
+	}
+	return nil
+}
+
+// nextTraceNumber increments the sequence number of odfi in last and formats the trace number.
+func nextTraceNumber(last map[string]int, odfi string) (string, error) {
+	prefix := aba8(odfi)
+	if _, err := strconv.Atoi(prefix); err != nil || len(prefix) != 8 {
+		return "", fieldError("ODFIIdentification", ErrTraceNumberODFI, odfi)
+	}
+	if last[prefix] >= maxTraceSequence {
+		return "", fieldError("TraceNumber", ErrTraceNumbersExhausted, prefix)
+	}
+	last[prefix]++
+	return fmt.Sprintf("%s%07d", prefix, last[prefix]), nil
+}
+
+// setAllocatedTraceNumber sets trace on ed and the addenda records which carry its trace number.
+func setAllocatedTraceNumber(ed *EntryDetail, trace string) {
+	seq, _ := strconv.Atoi(trace[8:])
+	ed.SetTraceNumber(trace[:8], seq)
+}
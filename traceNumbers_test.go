@@ -0,0 +1,193 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryTraceNumberAllocator(t *testing.T) {
+	alloc := NewMemoryTraceNumberAllocator()
+
+	trace, err := alloc.Next("121042882")
+	require.NoError(t, err)
+	require.Equal(t, "121042880000001", trace)
+
+	trace, err = alloc.Next("12104288")
+	require.NoError(t, err)
+	require.Equal(t, "121042880000002", trace)
+
+	// each ODFI has its own sequence
+	trace, err = alloc.Next("231380104")
+	require.NoError(t, err)
+	require.Equal(t, "231380100000001", trace)
+
+	alloc.SetLast("231380104", 9999998)
+	trace, err = alloc.Next("231380104")
+	require.NoError(t, err)
+	require.Equal(t, "231380109999999", trace)
+
+	_, err = alloc.Next("231380104")
+	require.ErrorIs(t, err, ErrTraceNumbersExhausted)
+
+	_, err = alloc.Next("1234")
+	require.ErrorIs(t, err, ErrTraceNumberODFI)
+
+	// concurrent allocations are unique
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			trace, err := alloc.Next("121042882")
+			require.NoError(t, err)
+			mu.Lock()
+			seen[trace] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	require.Len(t, seen, 50)
+}
+
+func TestFileTraceNumberAllocator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+
+	alloc, err := NewFileTraceNumberAllocator(path)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = alloc.Next("121042882")
+		require.NoError(t, err)
+	}
+
+	// a restarted allocator continues the sequence
+	alloc, err = NewFileTraceNumberAllocator(path)
+	require.NoError(t, err)
+	trace, err := alloc.Next("121042882")
+	require.NoError(t, err)
+	require.Equal(t, "121042880000004", trace)
+
+	// the sequence rolls over with SetLast
+	require.NoError(t, alloc.SetLast("121042882", maxTraceSequence))
+	_, err = alloc.Next("121042882")
+	require.ErrorIs(t, err, ErrTraceNumbersExhausted)
+	require.NoError(t, alloc.SetLast("121042882", 0))
+	alloc, err = NewFileTraceNumberAllocator(path)
+	require.NoError(t, err)
+	trace, err = alloc.Next("121042882")
+	require.NoError(t, err)
+	require.Equal(t, "121042880000001", trace)
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+	_, err = NewFileTraceNumberAllocator(path)
+	require.Error(t, err)
+}
+
+func TestBatch__WithTraceNumbers(t *testing.T) {
+	alloc := NewMemoryTraceNumberAllocator()
+	alloc.SetLast("12104288", 41)
+
+	bh := mockBatchPPDHeader()
+	bh.ServiceClassCode = MixedDebitsAndCredits
+	batch := NewBatchPPD(bh)
+	batch.WithTraceNumbers(alloc)
+	for i := 0; i < 2; i++ {
+		entry := mockPPDEntryDetail()
+		entry.TraceNumber = ""
+		batch.AddEntry(entry)
+	}
+	batch.WithOffset(&Offset{
+		RoutingNumber: "121042882",
+		AccountNumber: "123456789",
+		AccountType:   OffsetChecking,
+		Description:   "test offset",
+	})
+	require.NoError(t, batch.Create())
+
+	entries := batch.GetEntries()
+	require.Len(t, entries, 3)
+	require.Equal(t, "121042880000042", entries[0].TraceNumber)
+	require.Equal(t, "121042880000043", entries[1].TraceNumber)
+	require.Equal(t, offsetIndividualName, entries[2].IndividualName)
+	require.Equal(t, "121042880000044", entries[2].TraceNumber)
+}
+
+func TestBatcher__TraceNumberSetter(t *testing.T) {
+	bh := mockBatchPPDHeader()
+	batch, err := NewBatch(bh)
+	require.NoError(t, err)
+
+	setter, ok := batch.(TraceNumberSetter)
+	require.True(t, ok)
+
+	alloc := NewMemoryTraceNumberAllocator()
+	alloc.SetLast("12104288", 9)
+	setter.WithTraceNumbers(alloc)
+
+	entry := mockPPDEntryDetail()
+	entry.TraceNumber = ""
+	batch.AddEntry(entry)
+	require.NoError(t, batch.Create())
+	require.Equal(t, "121042880000010", batch.GetEntries()[0].TraceNumber)
+}
+
+func TestMergeFiles__TraceNumbers(t *testing.T) {
+	file1, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	file2, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	alloc := NewMemoryTraceNumberAllocator()
+	alloc.SetLast("12104288", 100)
+
+	merged, err := MergeFilesWith([]*File{file1, file2}, Conditions{TraceNumbers: alloc})
+	require.NoError(t, err)
+	require.Len(t, merged, 1)
+
+	var traces []string
+	for _, b := range merged[0].Batches {
+		for _, entry := range b.GetEntries() {
+			traces = append(traces, entry.TraceNumber)
+		}
+	}
+	require.ElementsMatch(t, []string{"121042880000101", "121042880000102"}, traces)
+
+	// the incoming files are untouched
+	require.Equal(t, "121042880000001", file1.Batches[0].GetEntries()[0].TraceNumber)
+	require.Equal(t, "121042880000001", file2.Batches[0].GetEntries()[0].TraceNumber)
+}
+
+func TestBuilder__TraceNumbers(t *testing.T) {
+	alloc := NewMemoryTraceNumberAllocator()
+	alloc.SetLast("12104288", 7)
+
+	file, err := NewBuilder("121042882", "231380104").
+		TraceNumbers(alloc).
+		Batch(PPD, Company{Name: "Acme Corp", Identification: "121042882", EntryDescription: "PAYROLL"}).
+		Credit("231380104", "12345678", 100000, "Jane Doe").
+		Build()
+	require.NoError(t, err)
+	require.Equal(t, "121042880000008", file.Batches[0].GetEntries()[0].TraceNumber)
+}
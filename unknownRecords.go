@@ -0,0 +1,106 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"sort"
+	"strings"
+)
+
+// UnknownRecord is a line between the FileHeader and FileControl which isn't a Nacha record,
+// such as a bank proprietary record.
+type UnknownRecord struct {
+	// Line is the record as it was read, without its line ending
+	Line string `json:"line"`
+
+	// Position is the number of Nacha records (not counting block padding) written before the line
+	Position int `json:"position"`
+}
+
+// isUnknownRecord returns true when line doesn't start with the type code of a Nacha record.
+func isUnknownRecord(line string) bool {
+	if line == "" {
+		return false
+	}
+	return !strings.ContainsRune("156789", rune(line[0]))
+}
+
+// preservingUnknownRecords returns true when lines which aren't Nacha records are kept on the File.
+func (r *Reader) preservingUnknownRecords() bool {
+	return r.File.validateOpts != nil && r.File.validateOpts.PreserveUnknownRecords
+}
+
+// preservingLine returns true when line is an unknown record being kept, which is read up to its
+// line ending rather than split into 94 character records.
+func (r *Reader) preservingLine(line string) bool {
+	return r.preservingUnknownRecords() && isUnknownRecord(line)
+}
+
+// preserveUnknownRecord keeps line on the File if it's an unknown record being preserved.
+// Lines before the first record are leading lines and lines after the FileControl are trailing lines.
+func (r *Reader) preserveUnknownRecord(line string) bool {
+	if !r.preservingLine(line) {
+		return false
+	}
+	switch {
+	case r.records == 0:
+		r.File.LeadingLines = append(r.File.LeadingLines, line)
+	case r.File.Control != (FileControl{}) || r.File.ADVControl != (ADVFileControl{}):
+		r.File.TrailingLines = append(r.File.TrailingLines, line)
+	default:
+		r.File.UnknownRecords = append(r.File.UnknownRecords, UnknownRecord{
+			Line:     line,
+			Position: r.records,
+		})
+	}
+	return true
+}
+
+// writeRawLines writes lines followed by the line ending. They don't count towards block padding.
+func (w *Writer) writeRawLines(lines []string) error {
+	for _, line := range lines {
+		if _, err := w.w.WriteString(line); err != nil {
+			return err
+		}
+		if _, err := w.w.WriteString(w.LineEnding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeUnknownRecords writes the unknown records left whose Position is at most position.
+func (w *Writer) writeUnknownRecords(position int) error {
+	for len(w.unknownRecords) > 0 && w.unknownRecords[0].Position <= position {
+		if err := w.writeRawLines([]string{w.unknownRecords[0].Line}); err != nil {
+			return err
+		}
+		w.unknownRecords = w.unknownRecords[1:]
+	}
+	return nil
+}
+
+// sortedUnknownRecords returns a copy of records ordered by their Position.
+func sortedUnknownRecords(records []UnknownRecord) []UnknownRecord {
+	out := make([]UnknownRecord, len(records))
+	copy(out, records)
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Position < out[j].Position
+	})
+	return out
+}
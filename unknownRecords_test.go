@@ -0,0 +1,106 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base"
+	"github.com/stretchr/testify/require"
+)
+
+func unknownRecordsFile(t *testing.T) string {
+	t.Helper()
+
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+	// envelope lines longer than a record are kept whole
+	envelope := "$$ADD ID=ACHFILE01 BID='NWFACH0001' " + strings.Repeat("X", 80)
+	proprietary := "0BANK PROPRIETARY RECORD" + strings.Repeat(" ", 70)
+
+	var out []string
+	out = append(out, envelope)
+	out = append(out, lines[:3]...) // FileHeader, BatchHeader, EntryDetail
+	out = append(out, proprietary)
+	out = append(out, lines[3:]...)
+	out = append(out, "$$END")
+	return strings.Join(out, "\n") + "\n"
+}
+
+func TestReader__PreserveUnknownRecords(t *testing.T) {
+	input := unknownRecordsFile(t)
+
+	r := NewReader(strings.NewReader(input))
+	r.SetValidation(&ValidateOpts{PreserveUnknownRecords: true})
+	file, err := r.Read()
+	require.NoError(t, err)
+
+	require.Len(t, file.LeadingLines, 1)
+	require.Len(t, file.LeadingLines[0], 116)
+	require.Equal(t, []string{"$$END"}, file.TrailingLines)
+	require.Len(t, file.UnknownRecords, 1)
+	require.Equal(t, 3, file.UnknownRecords[0].Position)
+	require.True(t, strings.HasPrefix(file.UnknownRecords[0].Line, "0BANK PROPRIETARY"))
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(&file))
+	require.Equal(t, input, buf.String())
+
+	// the lines survive a trip through JSON
+	bs, err := json.Marshal(&file)
+	require.NoError(t, err)
+	fromJSON, err := FileFromJSON(bs)
+	require.NoError(t, err)
+	require.Equal(t, file.LeadingLines, fromJSON.LeadingLines)
+	require.Equal(t, file.UnknownRecords, fromJSON.UnknownRecords)
+	require.Equal(t, file.TrailingLines, fromJSON.TrailingLines)
+}
+
+func TestReader__UnknownRecordsRejected(t *testing.T) {
+	r := NewReader(strings.NewReader(unknownRecordsFile(t)))
+	_, err := r.Read()
+	require.Error(t, err)
+	require.True(t, base.Has(err, NewErrUnknownRecordType("$")))
+}
+
+func TestWriter__UnknownRecordsOrder(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	file.UnknownRecords = []UnknownRecord{
+		{Line: "0LAST", Position: 100},
+		{Line: "0SECOND", Position: 1},
+		{Line: "0FIRST", Position: 0},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+
+	lines := strings.Split(buf.String(), "\n")
+	require.Equal(t, "0FIRST", lines[0])
+	require.Equal(t, "0SECOND", lines[2])
+	require.Equal(t, "0LAST", lines[7])
+	require.Equal(t, paddingLine, lines[8])
+}
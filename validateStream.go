@@ -0,0 +1,498 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"io"
+)
+
+// ValidateStream reads an ACH file from r and checks it like Reader.Read followed by File.ValidateWith,
+// but one record at a time so memory use doesn't grow with the number of entries.
+//
+// Records are parsed and validated as they're read. Each entry is checked with the rules of its batch's
+// Standard Entry Class Code, trace numbers must ascend within a batch and batch numbers within the file,
+// and the totals and entry hash of each batch and file control record are compared with the entries read.
+// The first error found is returned, so a file with several problems may report a different one than
+// File.ValidateWith does.
+//
+// Rules added with RegisterRule or RegisterFileRule, ValidateOpts.SameDay and ValidateOpts.Severities
+// need a whole File and are not checked.
+func ValidateStream(r io.Reader, opts *ValidateOpts) error {
+	if opts == nil {
+		opts = &ValidateOpts{}
+	}
+	if opts.SkipAll {
+		return nil
+	}
+
+	v := &streamValidator{
+		iter: NewEventIterator(r),
+		opts: opts,
+	}
+	v.iter.SetValidation(opts)
+	v.iter.reuseEntries = true
+
+	for {
+		event, err := v.iter.Next()
+		if err != nil {
+			return err
+		}
+		if event == nil {
+			return v.finish()
+		}
+		if err := v.check(event); err != nil {
+			return err
+		}
+	}
+}
+
+// streamTotals are the counts and sums a batch or file control record is compared with.
+type streamTotals struct {
+	entries      int
+	entryAddenda int
+	hash         int
+	credit       int
+	debit        int
+}
+
+func (t *streamTotals) add(other streamTotals) {
+	t.entries += other.entries
+	t.entryAddenda += other.entryAddenda
+	t.hash += other.hash
+	t.credit += other.credit
+	t.debit += other.debit
+}
+
+// streamBatch is implemented by the batches NewBatch returns through the Batch they embed.
+type streamBatch interface {
+	Batcher
+	streamEntry(entry *EntryDetail) streamTotals
+	streamADVEntry(entry *ADVEntryDetail) streamTotals
+	isHeaderControlEquality() error
+}
+
+// streamValidator keeps the state ValidateStream needs between records. Only the current
+// batch header and entry are held.
+type streamValidator struct {
+	iter *EventIterator
+	opts *ValidateOpts
+
+	header  bool
+	control bool
+
+	// batch or iatBatch holds the header and current entry of the batch being read
+	batch     streamBatch
+	iatBatch  *IATBatch
+	totals    streamTotals
+	lastTrace string
+	category  string
+
+	// batches and iatBatches are the number of batches read, file sums the control records of every batch
+	batches         int
+	iatBatches      int
+	lastBatchNumber int
+	file            streamTotals
+
+	converters
+}
+
+// check validates the record of event against what has been read before it.
+func (v *streamValidator) check(event *Event) error {
+	if event.Type != FileHeaderEvent && !v.header && !v.opts.AllowMissingFileHeader {
+		// There must be a File Header before the other records
+		return ErrFileHeader
+	}
+
+	switch event.Type {
+	case FileHeaderEvent:
+		if v.header {
+			// There can only be one File Header per File
+			return v.iter.reader.parseError(ErrFileHeader)
+		}
+		v.header = true
+
+	case BatchHeaderEvent, IATBatchHeaderEvent:
+		return v.startBatch(event)
+
+	case EntryEvent:
+		return v.checkEntry(event.Entry)
+
+	case IATEntryEvent:
+		return v.checkIATEntry(event.IATEntry)
+
+	case ADVEntryEvent:
+		return v.checkADVEntry(event.ADVEntry)
+
+	case BatchControlEvent:
+		if v.iatBatch != nil {
+			return v.endIATBatch(event.BatchControl)
+		}
+		return v.endBatch(event.BatchControl, nil)
+
+	case ADVBatchControlEvent:
+		return v.endBatch(nil, event.ADVBatchControl)
+
+	case FileControlEvent, ADVFileControlEvent:
+		if v.control {
+			// Can be only one file control per file
+			return v.iter.reader.parseError(ErrFileControl)
+		}
+		v.control = true
+		if err := v.endOpenBatch(); err != nil {
+			return err
+		}
+		return v.checkFileControl(event)
+	}
+	return nil
+}
+
+// startBatch begins tallying the batch of a batch header.
+func (v *streamValidator) startBatch(event *Event) error {
+	if v.batch != nil || v.iatBatch != nil {
+		if v.totals.entries == 0 {
+			return v.iter.reader.parseError(ErrFileConsecutiveBatchHeaders)
+		}
+		if err := v.endOpenBatch(); err != nil {
+			return err
+		}
+	}
+	v.totals = streamTotals{}
+	v.lastTrace, v.category = "", ""
+
+	if event.IATBatchHeader != nil {
+		iatBatch := NewIATBatch(event.IATBatchHeader)
+		iatBatch.SetValidation(v.opts)
+		v.iatBatch = &iatBatch
+		return nil
+	}
+
+	bh := event.BatchHeader
+	b, err := NewBatch(bh)
+	if err != nil {
+		return v.iter.reader.parseError(err)
+	}
+	batch, ok := b.(streamBatch)
+	if !ok {
+		return b.Error("Batch", fmt.Errorf("%T can't be streamed", b))
+	}
+	batch.SetValidation(v.opts)
+	v.batch = batch
+
+	if bh.StandardEntryClassCode != ADV && !v.opts.AllowUnorderedBatchNumbers && !v.opts.CustomTraceNumbers {
+		if bh.BatchNumber <= v.lastBatchNumber {
+			return NewErrFileBatchNumberAscending(v.lastBatchNumber, bh.BatchNumber)
+		}
+	}
+	v.lastBatchNumber = bh.BatchNumber
+	return nil
+}
+
+// checkEntry validates entry as the only entry of its batch and checks it follows the previous entry.
+func (v *streamValidator) checkEntry(entry *EntryDetail) error {
+	if v.totals.entries > 0 {
+		if !v.opts.CustomTraceNumbers && entry.TraceNumber <= v.lastTrace {
+			return v.batch.Error("TraceNumber", NewErrBatchAscending(v.lastTrace, entry.TraceNumber))
+		}
+		if entry.Category != CategoryNOC && entry.Category != v.category {
+			return v.batch.Error("Category", NewErrBatchCategory(entry.Category, v.category))
+		}
+	} else {
+		v.category = entry.Category
+	}
+	v.lastTrace = entry.TraceNumber
+
+	totals := v.batch.streamEntry(entry)
+	if err := v.batch.Validate(); err != nil {
+		return err
+	}
+	v.totals.add(totals)
+	return nil
+}
+
+// checkADVEntry validates entry as the only entry of its batch and checks it follows the previous entry.
+func (v *streamValidator) checkADVEntry(entry *ADVEntryDetail) error {
+	if v.totals.entries > 0 {
+		if entry.Category != v.category {
+			return v.batch.Error("Category", NewErrBatchCategory(entry.Category, v.category))
+		}
+	} else {
+		v.category = entry.Category
+	}
+
+	totals := v.batch.streamADVEntry(entry)
+	if err := v.batch.Validate(); err != nil {
+		return err
+	}
+	v.totals.add(totals)
+	return nil
+}
+
+// checkIATEntry validates entry as the only entry of its batch and checks it follows the previous entry.
+func (v *streamValidator) checkIATEntry(entry *IATEntryDetail) error {
+	if v.totals.entries > 0 {
+		if !v.opts.CustomTraceNumbers && entry.TraceNumber <= v.lastTrace {
+			return v.iatBatch.Error("TraceNumber", NewErrBatchAscending(v.lastTrace, entry.TraceNumber))
+		}
+		if entry.Category != CategoryNOC && entry.Category != v.category {
+			return v.iatBatch.Error("Category", NewErrBatchCategory(entry.Category, v.category))
+		}
+	} else {
+		v.category = entry.Category
+	}
+	v.lastTrace = entry.TraceNumber
+
+	totals := v.iatBatch.streamEntry(entry)
+	if err := v.iatBatch.Validate(); err != nil {
+		return err
+	}
+	v.totals.add(totals)
+	return nil
+}
+
+// endBatch compares the batch control record (bc or advControl) with the entries read.
+func (v *streamValidator) endBatch(bc *BatchControl, advControl *ADVBatchControl) error {
+	batch := v.batch
+	v.batch = nil
+	if batch == nil {
+		return v.iter.reader.parseError(ErrFileBatchControlOutsideBatch)
+	}
+	if v.totals.entries == 0 {
+		return batch.Error("entries", ErrBatchNoEntries)
+	}
+
+	var control streamTotals
+	if advControl != nil {
+		batch.SetADVControl(advControl)
+		control = streamTotals{
+			entryAddenda: advControl.EntryAddendaCount,
+			hash:         advControl.EntryHash,
+			credit:       advControl.TotalCreditEntryDollarAmount,
+			debit:        advControl.TotalDebitEntryDollarAmount,
+		}
+	} else {
+		batch.SetControl(bc)
+		control = streamTotals{
+			entryAddenda: bc.EntryAddendaCount,
+			hash:         bc.EntryHash,
+			credit:       bc.TotalCreditEntryDollarAmount,
+			debit:        bc.TotalDebitEntryDollarAmount,
+		}
+	}
+	if err := batch.isHeaderControlEquality(); err != nil {
+		return err
+	}
+	if err := v.checkBatchTotals(batch.Error, control); err != nil {
+		return err
+	}
+	v.batches++
+	v.file.add(control)
+	return nil
+}
+
+// endIATBatch compares the IAT batch control record with the entries read.
+func (v *streamValidator) endIATBatch(bc *BatchControl) error {
+	iatBatch := v.iatBatch
+	v.iatBatch = nil
+	if v.totals.entries == 0 {
+		return iatBatch.Error("entries", ErrBatchNoEntries)
+	}
+
+	iatBatch.SetControl(bc)
+	control := streamTotals{
+		entryAddenda: bc.EntryAddendaCount,
+		hash:         bc.EntryHash,
+		credit:       bc.TotalCreditEntryDollarAmount,
+		debit:        bc.TotalDebitEntryDollarAmount,
+	}
+	if err := iatBatch.isHeaderControlEquality(); err != nil {
+		return err
+	}
+	if err := v.checkBatchTotals(iatBatch.Error, control); err != nil {
+		return err
+	}
+	v.iatBatches++
+	v.file.add(control)
+	return nil
+}
+
+// endOpenBatch checks a batch without a control record against the empty control record Reader leaves on it.
+func (v *streamValidator) endOpenBatch() error {
+	switch {
+	case v.iatBatch != nil:
+		return v.endIATBatch(NewBatchControl())
+	case v.batch != nil && v.batch.GetHeader().StandardEntryClassCode == ADV:
+		return v.endBatch(nil, NewADVBatchControl())
+	case v.batch != nil:
+		return v.endBatch(NewBatchControl(), nil)
+	}
+	return nil
+}
+
+// checkBatchTotals compares the totals of the entries in the current batch with its control record.
+func (v *streamValidator) checkBatchTotals(batchError func(string, error, ...interface{}) error, control streamTotals) error {
+	if v.totals.entryAddenda != control.entryAddenda && !v.opts.UnequalAddendaCounts {
+		return batchError("EntryAddendaCount",
+			NewErrBatchCalculatedControlEquality(v.totals.entryAddenda, control.entryAddenda))
+	}
+	if v.totals.debit != control.debit {
+		return batchError("TotalDebitEntryDollarAmount",
+			NewErrBatchCalculatedControlEquality(v.totals.debit, control.debit))
+	}
+	if v.totals.credit != control.credit {
+		return batchError("TotalCreditEntryDollarAmount",
+			NewErrBatchCalculatedControlEquality(v.totals.credit, control.credit))
+	}
+	if hash := v.leastSignificantDigits(v.totals.hash, 10); hash != control.hash {
+		return batchError("EntryHash", NewErrBatchCalculatedControlEquality(hash, control.hash))
+	}
+	return nil
+}
+
+// checkFileControl compares the file control record of event with the batch control records read.
+func (v *streamValidator) checkFileControl(event *Event) error {
+	var batchCount int
+	var control streamTotals
+	if fc := event.FileControl; fc != nil {
+		batchCount = fc.BatchCount
+		control = streamTotals{
+			entryAddenda: fc.EntryAddendaCount,
+			hash:         fc.EntryHash,
+			credit:       fc.TotalCreditEntryDollarAmountInFile,
+			debit:        fc.TotalDebitEntryDollarAmountInFile,
+		}
+	} else {
+		fc := event.ADVFileControl
+		batchCount = fc.BatchCount
+		control = streamTotals{
+			entryAddenda: fc.EntryAddendaCount,
+			hash:         fc.EntryHash,
+			credit:       fc.TotalCreditEntryDollarAmountInFile,
+			debit:        fc.TotalDebitEntryDollarAmountInFile,
+		}
+	}
+
+	// The value of the Batch Count Field is equal to the number of Company/Batch/Header Records in the file.
+	if batchCount != v.batches+v.iatBatches {
+		return NewErrFileCalculatedControlEquality("BatchCount", v.batches, batchCount)
+	}
+	if v.file.entryAddenda != control.entryAddenda && !v.opts.UnequalAddendaCounts {
+		return NewErrFileCalculatedControlEquality("EntryAddendaCount", v.file.entryAddenda, control.entryAddenda)
+	}
+	if v.file.debit != control.debit {
+		return NewErrFileCalculatedControlEquality("TotalDebitEntryDollarAmountInFile", v.file.debit, control.debit)
+	}
+	if v.file.credit != control.credit {
+		return NewErrFileCalculatedControlEquality("TotalCreditEntryDollarAmountInFile", v.file.credit, control.credit)
+	}
+	if hash := v.leastSignificantDigits(v.file.hash, 10); hash != control.hash {
+		return NewErrFileCalculatedControlEquality("EntryHash", hash, control.hash)
+	}
+	return nil
+}
+
+// finish checks the file had a header and control record once the input is exhausted.
+func (v *streamValidator) finish() error {
+	if !v.header && !v.opts.AllowMissingFileHeader {
+		return ErrFileHeader
+	}
+	if !v.control && !v.opts.AllowMissingFileControl {
+		return ErrFileControl
+	}
+	return v.endOpenBatch()
+}
+
+// streamEntry makes entry the only entry of batch and sets its control record to match,
+// so Validate only checks the rules of a single entry. The totals of entry are returned.
+func (batch *Batch) streamEntry(entry *EntryDetail) streamTotals {
+	batch.streaming = true
+	batch.Entries = append(batch.Entries[:0], entry)
+
+	credit, debit := entryAmounts(entry)
+	totals := streamTotals{
+		entries:      1,
+		entryAddenda: 1 + entry.addendaCount(),
+		hash:         batch.calculateEntryHash(),
+		credit:       credit,
+		debit:        debit,
+	}
+	batch.Control = &BatchControl{
+		ServiceClassCode:             batch.Header.ServiceClassCode,
+		EntryAddendaCount:            totals.entryAddenda,
+		EntryHash:                    totals.hash,
+		TotalDebitEntryDollarAmount:  totals.debit,
+		TotalCreditEntryDollarAmount: totals.credit,
+		CompanyIdentification:        batch.Header.CompanyIdentification,
+		ODFIIdentification:           batch.Header.ODFIIdentification,
+		BatchNumber:                  batch.Header.BatchNumber,
+	}
+	return totals
+}
+
+// streamADVEntry makes entry the only entry of an ADV batch and sets its control record to match.
+func (batch *Batch) streamADVEntry(entry *ADVEntryDetail) streamTotals {
+	batch.streaming = true
+	batch.ADVEntries = append(batch.ADVEntries[:0], entry)
+
+	credit, debit := batch.calculateADVBatchAmounts()
+	totals := streamTotals{
+		entries:      1,
+		entryAddenda: 1,
+		hash:         batch.calculateEntryHash(),
+		credit:       credit,
+		debit:        debit,
+	}
+	if entry.Addenda99 != nil {
+		totals.entryAddenda++
+	}
+	batch.ADVControl = &ADVBatchControl{
+		ServiceClassCode:             batch.Header.ServiceClassCode,
+		EntryAddendaCount:            totals.entryAddenda,
+		EntryHash:                    totals.hash,
+		TotalDebitEntryDollarAmount:  totals.debit,
+		TotalCreditEntryDollarAmount: totals.credit,
+		ODFIIdentification:           batch.Header.ODFIIdentification,
+		BatchNumber:                  batch.Header.BatchNumber,
+	}
+	return totals
+}
+
+// streamEntry makes entry the only entry of iatBatch and sets its control record to match.
+func (iatBatch *IATBatch) streamEntry(entry *IATEntryDetail) streamTotals {
+	iatBatch.Entries = append(iatBatch.Entries[:0], entry)
+
+	entryAddenda, _ := iatBatch.isBatchEntryCount()
+	credit, debit := iatBatch.calculateBatchAmounts()
+	totals := streamTotals{
+		entries:      1,
+		entryAddenda: entryAddenda,
+		hash:         iatBatch.calculateEntryHash(),
+		credit:       credit,
+		debit:        debit,
+	}
+	iatBatch.Control = &BatchControl{
+		ServiceClassCode:             iatBatch.Header.ServiceClassCode,
+		EntryAddendaCount:            totals.entryAddenda,
+		EntryHash:                    totals.hash,
+		TotalDebitEntryDollarAmount:  totals.debit,
+		TotalCreditEntryDollarAmount: totals.credit,
+		ODFIIdentification:           iatBatch.Header.ODFIIdentification,
+		BatchNumber:                  iatBatch.Header.BatchNumber,
+	}
+	return totals
+}
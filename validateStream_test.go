@@ -0,0 +1,295 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// streamedFile reads path and lets modify change it before writing it out without validation.
+func streamedFile(t *testing.T, path string, modify func(f *File)) (*File, *bytes.Buffer) {
+	t.Helper()
+
+	fd, err := os.Open(filepath.Join("test", "testdata", path))
+	require.NoError(t, err)
+	defer fd.Close()
+
+	file, err := NewReader(fd).Read()
+	require.NoError(t, err)
+	if modify != nil {
+		modify(&file)
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.BypassValidation = true
+	require.NoError(t, w.Write(&file))
+	return &file, &buf
+}
+
+func TestValidateStream(t *testing.T) {
+	paths := []string{
+		"ppd-debit.ach",
+		"ppd-debit-fixedLength.ach",
+		"ppd-mixedDebitCredit.ach",
+		"long-line.ach",
+		"short-line.ach",
+		"web-debit.ach",
+		"return-WEB.ach",
+		"cor-example.ach",
+		"two-micro-deposits.ach",
+		"20180716-IAT-A17-A18.ach",
+		"iat-addenda99.ach",
+		"flattenADVBatchesOneBatchHeader.ach",
+	}
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			fd, err := os.Open(filepath.Join("test", "testdata", path))
+			require.NoError(t, err)
+			defer fd.Close()
+
+			require.NoError(t, ValidateStream(fd, nil))
+		})
+	}
+}
+
+func TestValidateStream__SameErrors(t *testing.T) {
+	cases := map[string]struct {
+		path   string
+		modify func(f *File)
+		// validate returns the expected error when it's not from File.Validate
+		validate func(f *File) error
+	}{
+		"batch debit total": {
+			path: "ppd-debit.ach",
+			modify: func(f *File) {
+				f.Batches[0].GetControl().TotalDebitEntryDollarAmount++
+			},
+		},
+		"batch entry hash": {
+			path: "ppd-mixedDebitCredit.ach",
+			modify: func(f *File) {
+				f.Batches[0].GetControl().EntryHash++
+			},
+		},
+		"batch entry addenda count": {
+			path: "ppd-mixedDebitCredit.ach",
+			modify: func(f *File) {
+				f.Batches[0].GetControl().EntryAddendaCount++
+			},
+		},
+		"trace numbers": {
+			path: "ppd-mixedDebitCredit.ach",
+			modify: func(f *File) {
+				entries := f.Batches[0].GetEntries()
+				entries[0], entries[1] = entries[1], entries[0]
+			},
+		},
+		"entry rules": {
+			path: "ppd-debit.ach",
+			modify: func(f *File) {
+				entry := f.Batches[0].GetEntries()[0]
+				entry.TransactionCode = CheckingCredit
+				f.Batches[0].GetControl().TotalDebitEntryDollarAmount = 0
+				f.Batches[0].GetControl().TotalCreditEntryDollarAmount = entry.Amount
+				f.Control.TotalDebitEntryDollarAmountInFile = 0
+				f.Control.TotalCreditEntryDollarAmountInFile = entry.Amount
+			},
+		},
+		"batch numbers": {
+			path: "web-debit.ach",
+			modify: func(f *File) {
+				f.Batches[1].GetHeader().BatchNumber = 1
+				f.Batches[1].GetControl().BatchNumber = 1
+			},
+		},
+		"file batch count": {
+			path: "web-debit.ach",
+			modify: func(f *File) {
+				f.Control.BatchCount++
+			},
+		},
+		"file credit total": {
+			path: "ppd-mixedDebitCredit.ach",
+			modify: func(f *File) {
+				f.Control.TotalCreditEntryDollarAmountInFile--
+			},
+		},
+		"file entry hash": {
+			path: "web-debit.ach",
+			modify: func(f *File) {
+				f.Control.EntryHash++
+			},
+		},
+		"IAT batch credit total": {
+			path: "20180716-IAT-A17-A18.ach",
+			modify: func(f *File) {
+				f.IATBatches[1].GetControl().TotalCreditEntryDollarAmount++
+			},
+			validate: func(f *File) error {
+				return f.IATBatches[1].Validate()
+			},
+		},
+		"ADV batch entry hash": {
+			path: "flattenADVBatchesOneBatchHeader.ach",
+			modify: func(f *File) {
+				f.Batches[2].GetADVControl().EntryHash++
+			},
+			validate: func(f *File) error {
+				return f.Batches[2].Validate()
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			file, buf := streamedFile(t, tc.path, tc.modify)
+
+			expected := file.Validate()
+			if tc.validate != nil {
+				// File.Validate leaves IAT and ADV batches to Reader
+				expected = tc.validate(file)
+			}
+			require.Error(t, expected)
+
+			err := ValidateStream(buf, nil)
+			require.Error(t, err)
+			require.Equal(t, expected.Error(), err.Error())
+		})
+	}
+}
+
+func TestValidateStream__Opts(t *testing.T) {
+	_, buf := streamedFile(t, "ppd-mixedDebitCredit.ach", func(f *File) {
+		entries := f.Batches[0].GetEntries()
+		entries[0], entries[1] = entries[1], entries[0]
+		f.Batches[0].GetControl().EntryAddendaCount++
+		f.Control.EntryAddendaCount++
+	})
+	input := buf.String()
+
+	err := ValidateStream(strings.NewReader(input), nil)
+	var batchErr *BatchError
+	require.True(t, errors.As(err, &batchErr))
+	require.Equal(t, "TraceNumber", batchErr.FieldName)
+
+	err = ValidateStream(strings.NewReader(input), &ValidateOpts{CustomTraceNumbers: true})
+	require.True(t, errors.As(err, &batchErr))
+	require.Equal(t, "EntryAddendaCount", batchErr.FieldName)
+
+	err = ValidateStream(strings.NewReader(input), &ValidateOpts{CustomTraceNumbers: true, UnequalAddendaCounts: true})
+	require.NoError(t, err)
+
+	require.NoError(t, ValidateStream(strings.NewReader("garbage"), &ValidateOpts{SkipAll: true}))
+}
+
+func TestValidateStream__Structure(t *testing.T) {
+	_, buf := streamedFile(t, "ppd-debit.ach", nil)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+	// no file header
+	err := ValidateStream(strings.NewReader(strings.Join(lines[1:], "\n")), nil)
+	require.ErrorIs(t, err, ErrFileHeader)
+
+	err = ValidateStream(strings.NewReader(strings.Join(lines[1:], "\n")), &ValidateOpts{AllowMissingFileHeader: true})
+	require.NoError(t, err)
+
+	// no file control
+	err = ValidateStream(strings.NewReader(strings.Join(lines[:4], "\n")), nil)
+	require.ErrorIs(t, err, ErrFileControl)
+
+	// duplicate file header
+	err = ValidateStream(strings.NewReader(strings.Join(append(lines[:1:1], lines...), "\n")), nil)
+	require.ErrorIs(t, err, ErrFileHeader)
+
+	// batch header without entries
+	err = ValidateStream(strings.NewReader(strings.Join([]string{lines[0], lines[1], lines[1], lines[2], lines[3], lines[4]}, "\n")), nil)
+	require.ErrorIs(t, err, ErrFileConsecutiveBatchHeaders)
+
+	// entries without a batch control record
+	err = ValidateStream(strings.NewReader(strings.Join([]string{lines[0], lines[1], lines[2], lines[4]}, "\n")), nil)
+	var batchErr *BatchError
+	require.True(t, errors.As(err, &batchErr))
+}
+
+func TestValidateStream__Writer(t *testing.T) {
+	file := streamTestFile(t)
+	bh := *file.Batches[0].GetHeader()
+	template := *file.Batches[0].GetEntries()[0]
+
+	r, pw := io.Pipe()
+	go func() {
+		w := NewWriter(pw)
+		err := w.BeginFile(file.Header)
+		if err == nil {
+			err = w.BeginBatch(&bh)
+		}
+		for i := 1; err == nil && i <= 10000; i++ {
+			entry := template
+			entry.SetTraceNumber(bh.ODFIIdentification, i)
+			err = w.WriteEntry(&entry)
+		}
+		if err == nil {
+			err = w.EndBatch()
+		}
+		if err == nil {
+			err = w.EndFile()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	require.NoError(t, ValidateStream(r, nil))
+}
+
+func TestValidateStream__FixedWidthLarge(t *testing.T) {
+	bs := fixedWidthFile(t, 1000)
+	require.Greater(t, len(bs), 64*1024)
+	require.NoError(t, ValidateStream(bytes.NewReader(bs), nil))
+
+	// change the amount of the last entry so it no longer matches the batch control
+	amount := 1001*RecordLength + 38
+	bs[amount] = '0' + (bs[amount]-'0'+1)%10
+	err := ValidateStream(bytes.NewReader(bs), nil)
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+}
+
+func TestValidateStream__Windows1252(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	// put a windows-1252 broken bar (¦) in the IndividualName of the entry
+	entry := bytes.Index(bs, []byte("\n6")) + 1
+	bs[entry+58] = 0xA6
+
+	file, err := NewReader(bytes.NewReader(bs)).Read()
+	require.NoError(t, err)
+	require.NoError(t, file.Validate())
+	require.NoError(t, ValidateStream(bytes.NewReader(bs), nil))
+
+	events := readEvents(t, NewEventIterator(bytes.NewReader(bs)))
+	require.Equal(t, file.Batches[0].GetEntries()[0].IndividualName, events[2].Entry.IndividualName)
+	require.Contains(t, events[2].Entry.IndividualName, "¦")
+}
@@ -0,0 +1,176 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationProfile is a named set of ValidateOpts, such as the rules agreed with a bank partner.
+// Profiles can extend other profiles to build on their ValidateOpts.
+type ValidationProfile struct {
+	// Name is how the profile is referenced, e.g. "lenient-inbound"
+	Name string `json:"name"`
+
+	// Description is an optional summary of the profile
+	Description string `json:"description,omitempty"`
+
+	// Extends are the names of profiles whose ValidateOpts are merged, in order, before ValidateOpts.
+	// Boolean options enabled by any profile stay enabled and later Severities override earlier ones.
+	Extends []string `json:"extends,omitempty"`
+
+	// ValidateOpts are the options of this profile
+	ValidateOpts *ValidateOpts `json:"validateOpts,omitempty"`
+}
+
+var validationProfiles = struct {
+	mu       sync.RWMutex
+	profiles map[string]ValidationProfile
+}{
+	profiles: make(map[string]ValidationProfile),
+}
+
+// RegisterValidationProfile adds profile, replacing any profile with the same name.
+// The profiles it extends don't need to be registered until the profile is used.
+func RegisterValidationProfile(profile ValidationProfile) error {
+	if profile.Name == "" {
+		return fieldError("Name", ErrFieldRequired)
+	}
+
+	validationProfiles.mu.Lock()
+	defer validationProfiles.mu.Unlock()
+
+	validationProfiles.profiles[profile.Name] = profile
+	return nil
+}
+
+// ValidationProfiles returns the names of every registered profile in order.
+func ValidationProfiles() []string {
+	validationProfiles.mu.RLock()
+	defer validationProfiles.mu.RUnlock()
+
+	out := make([]string, 0, len(validationProfiles.profiles))
+	for name := range validationProfiles.profiles {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ValidationProfileOpts returns the ValidateOpts of the registered profile name, merged with
+// the ValidateOpts of every profile it extends.
+func ValidationProfileOpts(name string) (*ValidateOpts, error) {
+	validationProfiles.mu.RLock()
+	defer validationProfiles.mu.RUnlock()
+
+	return resolveValidationProfile(name, nil)
+}
+
+func resolveValidationProfile(name string, seen []string) (*ValidateOpts, error) {
+	for _, s := range seen {
+		if s == name {
+			return nil, fmt.Errorf("validation profile %s: %w", name, ErrValidationProfileCycle)
+		}
+	}
+	profile, exists := validationProfiles.profiles[name]
+	if !exists {
+		return nil, fmt.Errorf("validation profile %s: %w", name, ErrValidationProfileNotFound)
+	}
+
+	out := &ValidateOpts{}
+	for _, parent := range profile.Extends {
+		opts, err := resolveValidationProfile(parent, append(seen, name))
+		if err != nil {
+			return nil, err
+		}
+		out = out.merge(opts)
+	}
+	if profile.ValidateOpts != nil {
+		out = out.merge(profile.ValidateOpts)
+	}
+	return out, nil
+}
+
+// ReadValidationProfiles parses YAML or JSON profiles keyed by their name, such as:
+//
+//	fedach:
+//	  validateOpts:
+//	    requireABAOrigin: true
+//	lenient-inbound:
+//	  extends: ["fedach"]
+//	  validateOpts:
+//	    customTraceNumbers: true
+//	    severities:
+//	      IndividualName: warning
+//
+// ValidateOpts use the same field names as their JSON form. Profiles are returned in order of their name.
+func ReadValidationProfiles(r io.Reader) ([]ValidationProfile, error) {
+	// Decode the YAML (which JSON is a subset of) and re-encode it as JSON so the json tags of ValidateOpts are used.
+	var raw interface{}
+	if err := yaml.NewDecoder(r).Decode(&raw); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading validation profiles: %w", err)
+	}
+	bs, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("reading validation profiles: %w", err)
+	}
+	var byName map[string]ValidationProfile
+	if err := json.Unmarshal(bs, &byName); err != nil {
+		return nil, fmt.Errorf("reading validation profiles: %w", err)
+	}
+
+	out := make([]ValidationProfile, 0, len(byName))
+	for name, profile := range byName {
+		profile.Name = name
+		out = append(out, profile)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+// LoadValidationProfiles reads the YAML or JSON profiles in path and registers each of them.
+func LoadValidationProfiles(path string) error {
+	fd, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("loading validation profiles: %w", err)
+	}
+	defer fd.Close()
+
+	profiles, err := ReadValidationProfiles(fd)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", path, err)
+	}
+	for _, profile := range profiles {
+		if err := RegisterValidationProfile(profile); err != nil {
+			return fmt.Errorf("loading %s: %w", path, err)
+		}
+	}
+	return nil
+}
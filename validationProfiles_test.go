@@ -0,0 +1,171 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// withoutValidationProfiles removes the profiles registered by a test once it's done.
+func withoutValidationProfiles(t *testing.T) {
+	t.Helper()
+
+	validationProfiles.mu.Lock()
+	saved := validationProfiles.profiles
+	validationProfiles.profiles = make(map[string]ValidationProfile)
+	validationProfiles.mu.Unlock()
+
+	t.Cleanup(func() {
+		validationProfiles.mu.Lock()
+		defer validationProfiles.mu.Unlock()
+
+		validationProfiles.profiles = saved
+	})
+}
+
+const testValidationProfiles = `
+strict-nacha:
+  description: Nacha rules with a routing number origin
+  validateOpts:
+    requireABAOrigin: true
+    severities:
+      IndividualName: error
+
+fedach:
+  extends: ["strict-nacha"]
+  validateOpts:
+    bypassDestinationValidation: true
+
+lenient-inbound:
+  extends: ["fedach"]
+  validateOpts:
+    customTraceNumbers: true
+    severities:
+      IndividualName: warning
+`
+
+func TestValidationProfiles(t *testing.T) {
+	withoutValidationProfiles(t)
+
+	profiles, err := ReadValidationProfiles(strings.NewReader(testValidationProfiles))
+	require.NoError(t, err)
+	require.Len(t, profiles, 3)
+	require.Equal(t, "fedach", profiles[0].Name)
+	require.Equal(t, []string{"strict-nacha"}, profiles[0].Extends)
+	require.True(t, profiles[0].ValidateOpts.BypassDestinationValidation)
+	require.Equal(t, "Nacha rules with a routing number origin", profiles[2].Description)
+
+	for _, profile := range profiles {
+		require.NoError(t, RegisterValidationProfile(profile))
+	}
+	require.Equal(t, []string{"fedach", "lenient-inbound", "strict-nacha"}, ValidationProfiles())
+
+	opts, err := ValidationProfileOpts("lenient-inbound")
+	require.NoError(t, err)
+	require.True(t, opts.RequireABAOrigin)
+	require.True(t, opts.BypassDestinationValidation)
+	require.True(t, opts.CustomTraceNumbers)
+	require.False(t, opts.AllowZeroBatches)
+	require.Equal(t, SeverityWarning, opts.Severities["IndividualName"])
+
+	opts, err = ValidationProfileOpts("strict-nacha")
+	require.NoError(t, err)
+	require.True(t, opts.RequireABAOrigin)
+	require.False(t, opts.BypassDestinationValidation)
+	require.Equal(t, SeverityError, opts.Severities["IndividualName"])
+
+	// profiles aren't modified when they're resolved
+	opts.SkipAll = true
+	opts, err = ValidationProfileOpts("strict-nacha")
+	require.NoError(t, err)
+	require.False(t, opts.SkipAll)
+
+	_, err = ValidationProfileOpts("missing")
+	require.ErrorIs(t, err, ErrValidationProfileNotFound)
+
+	require.Error(t, RegisterValidationProfile(ValidationProfile{}))
+}
+
+func TestValidationProfiles__Errors(t *testing.T) {
+	withoutValidationProfiles(t)
+
+	require.NoError(t, RegisterValidationProfile(ValidationProfile{Name: "a", Extends: []string{"b"}}))
+	require.NoError(t, RegisterValidationProfile(ValidationProfile{Name: "b", Extends: []string{"a"}}))
+	require.NoError(t, RegisterValidationProfile(ValidationProfile{Name: "c", Extends: []string{"missing"}}))
+
+	_, err := ValidationProfileOpts("a")
+	require.ErrorIs(t, err, ErrValidationProfileCycle)
+
+	_, err = ValidationProfileOpts("c")
+	require.ErrorIs(t, err, ErrValidationProfileNotFound)
+
+	_, err = ReadValidationProfiles(strings.NewReader(`fedach: [`))
+	require.Error(t, err)
+
+	profiles, err := ReadValidationProfiles(strings.NewReader(""))
+	require.NoError(t, err)
+	require.Empty(t, profiles)
+}
+
+func TestLoadValidationProfiles(t *testing.T) {
+	withoutValidationProfiles(t)
+
+	// JSON works as well
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	err := os.WriteFile(path, []byte(`{"partner": {"validateOpts": {"customReturnCodes": true}}}`), 0600)
+	require.NoError(t, err)
+	require.NoError(t, LoadValidationProfiles(path))
+
+	opts, err := ValidationProfileOpts("partner")
+	require.NoError(t, err)
+	require.True(t, opts.CustomReturnCodes)
+
+	require.Error(t, LoadValidationProfiles(filepath.Join(t.TempDir(), "missing.yaml")))
+}
+
+func TestMergeDir__ValidationProfile(t *testing.T) {
+	withoutValidationProfiles(t)
+	require.NoError(t, RegisterValidationProfile(ValidationProfile{
+		Name:         "partner",
+		ValidateOpts: &ValidateOpts{RequireABAOrigin: true},
+	}))
+
+	dir := filepath.Join("test", "testdata")
+	merged, err := MergeDir(dir, Conditions{}, &MergeDirOptions{
+		AcceptFile: func(path string) FileAcceptance {
+			if filepath.Base(path) == "ppd-debit.ach" {
+				return AcceptFile
+			}
+			return SkipFile
+		},
+		ValidationProfile: "partner",
+	})
+	require.NoError(t, err)
+	require.Len(t, merged, 1)
+	require.True(t, merged[0].GetValidation().RequireABAOrigin)
+
+	_, err = MergeDir(dir, Conditions{}, &MergeDirOptions{
+		ValidationProfile: "missing",
+	})
+	require.ErrorIs(t, err, ErrValidationProfileNotFound)
+}
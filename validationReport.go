@@ -0,0 +1,495 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/moov-io/base"
+)
+
+// Severity is how serious a ValidationFinding is.
+type Severity string
+
+const (
+	// SeverityError findings make a File invalid.
+	SeverityError Severity = "error"
+
+	// SeverityWarning findings are reported but don't make a File invalid.
+	SeverityWarning Severity = "warning"
+)
+
+// severity returns the Severity of a problem with field in record.
+func (v *ValidateOpts) severity(record, field string) Severity {
+	if v == nil || len(v.Severities) == 0 {
+		return SeverityError
+	}
+	severity, exists := v.Severities[record+"."+field]
+	if !exists {
+		severity = v.Severities[field]
+	}
+	if severity == SeverityWarning {
+		return SeverityWarning
+	}
+	return SeverityError
+}
+
+// ValidationFinding is one problem found in a File by ValidateAll.
+type ValidationFinding struct {
+	// Record is the type of record the problem was found in, e.g. "FileHeader", "EntryDetail" or "Addenda05".
+	// Problems with the totals or ordering of a whole batch or file use "Batch" or "File".
+	Record string
+
+	// Line is the line the record was read from, see SourceLine. Problems with a whole batch use
+	// the line of its header. It is 0 when the line isn't known.
+	Line int
+
+	// BatchIndex is the index of the batch in File.Batches, or File.IATBatches when IAT is set.
+	// It is -1 for file level records.
+	BatchIndex int
+
+	// IAT is set when BatchIndex refers to File.IATBatches.
+	IAT bool
+
+	// EntryIndex is the index of the entry in its batch. It is -1 for records which aren't part of an entry.
+	EntryIndex int
+
+	// TraceNumber of the entry, if any.
+	TraceNumber string
+
+	// FieldName is the name of the field with the problem, if known.
+	FieldName string
+
+	// Err is the validation error, usually a *FieldError or *BatchError.
+	Err error
+
+	// Severity of the problem.
+	Severity Severity
+}
+
+func (f ValidationFinding) Error() string {
+	if f.Line > 0 {
+		return fmt.Sprintf("line %d %s", f.Line, f.message())
+	}
+	return f.message()
+}
+
+func (f ValidationFinding) message() string {
+	switch {
+	case f.EntryIndex >= 0 && f.TraceNumber != "":
+		return fmt.Sprintf("batch %d entry %d (trace number %s) %s: %v", f.BatchIndex, f.EntryIndex, f.TraceNumber, f.Record, f.Err)
+	case f.EntryIndex >= 0:
+		return fmt.Sprintf("batch %d entry %d %s: %v", f.BatchIndex, f.EntryIndex, f.Record, f.Err)
+	case f.BatchIndex >= 0:
+		return fmt.Sprintf("batch %d %s: %v", f.BatchIndex, f.Record, f.Err)
+	}
+	return fmt.Sprintf("%s: %v", f.Record, f.Err)
+}
+
+// Unwrap returns the validation error, so errors.Is and errors.As can be used on findings.
+func (f ValidationFinding) Unwrap() error {
+	return f.Err
+}
+
+// MarshalJSON writes the finding with its error message, as errors don't encode to JSON.
+func (f ValidationFinding) MarshalJSON() ([]byte, error) {
+	var message string
+	if f.Err != nil {
+		message = f.Err.Error()
+	}
+	return json.Marshal(struct {
+		Record      string        `json:"record"`
+		BatchIndex  int           `json:"batchIndex"`
+		IAT         bool          `json:"iat,omitempty"`
+		EntryIndex  int           `json:"entryIndex"`
+		TraceNumber string        `json:"traceNumber,omitempty"`
+		FieldName   string        `json:"fieldName,omitempty"`
+		Line        int           `json:"line,omitempty"`
+		Error       string        `json:"error"`
+		Code        ErrorCode     `json:"code,omitempty"`
+		Category    ErrorCategory `json:"category,omitempty"`
+		Severity    Severity      `json:"severity"`
+	}{
+		Record:      f.Record,
+		BatchIndex:  f.BatchIndex,
+		IAT:         f.IAT,
+		EntryIndex:  f.EntryIndex,
+		TraceNumber: f.TraceNumber,
+		FieldName:   f.FieldName,
+		Line:        f.Line,
+		Error:       message,
+		Code:        CodeOf(f.Err),
+		Category:    CodeOf(f.Err).Category(),
+		Severity:    f.Severity,
+	})
+}
+
+// ValidationReport is every problem found in a File by ValidateAll, in the order of the records.
+type ValidationReport struct {
+	Findings []ValidationFinding `json:"findings"`
+
+	opts *ValidateOpts
+}
+
+// Warnings returns the findings with SeverityWarning.
+func (r *ValidationReport) Warnings() []ValidationFinding {
+	if r == nil {
+		return nil
+	}
+	var out []ValidationFinding
+	for _, finding := range r.Findings {
+		if finding.Severity == SeverityWarning {
+			out = append(out, finding)
+		}
+	}
+	return out
+}
+
+// Valid returns true when no findings have SeverityError.
+func (r *ValidationReport) Valid() bool {
+	return r.Err() == nil
+}
+
+// Err returns the findings with SeverityError as a base.ErrorList, or nil when there are none.
+func (r *ValidationReport) Err() error {
+	if r == nil {
+		return nil
+	}
+	var el base.ErrorList
+	for _, finding := range r.Findings {
+		if finding.Severity == SeverityError {
+			el.Add(finding)
+		}
+	}
+	if el.Empty() {
+		return nil
+	}
+	return el
+}
+
+func (r *ValidationReport) add(finding ValidationFinding, err error) {
+	if err == nil {
+		return
+	}
+	finding.Err = atLine(err, finding.Line)
+	finding.FieldName = errorFieldName(err)
+	finding.Severity = r.opts.severity(finding.Record, finding.FieldName)
+	r.Findings = append(r.Findings, finding)
+}
+
+// recordValidator is implemented by the records of a File.
+type recordValidator interface {
+	Validate() error
+	validateAll() []error
+}
+
+// addRecord adds the problem Validate finds in record. Records stop at the first problem they find, so
+// when it isn't an error the problems validateAll finds in the other fields are added up to the first error.
+func (r *ValidationReport) addRecord(finding ValidationFinding, record recordValidator) {
+	err := record.Validate()
+	if err == nil {
+		return
+	}
+	r.add(finding, err)
+	if r.Findings[len(r.Findings)-1].Severity == SeverityError {
+		return
+	}
+	reported := errorFieldName(err)
+	for _, err := range record.validateAll() {
+		if field := errorFieldName(err); field == "" || field == reported {
+			continue
+		}
+		r.add(finding, err)
+		if r.Findings[len(r.Findings)-1].Severity == SeverityError {
+			return
+		}
+	}
+}
+
+// has returns true if err was already reported in the batch. Batch validation stops at the first
+// problem it finds, which ValidateAll has usually reported from a record or the batch checks.
+// Findings match when they have the same cause, field and line.
+func (r *ValidationReport) has(batchIndex int, iat bool, err error) bool {
+	cause := rootError(err)
+	field, line := errorFieldName(err), errorSourceLine(err)
+	for _, finding := range r.Findings {
+		if finding.BatchIndex != batchIndex || finding.IAT != iat || finding.FieldName != field {
+			continue
+		}
+		if errorSourceLine(finding.Err) == line && errors.Is(finding.Err, cause) {
+			return true
+		}
+	}
+	return false
+}
+
+// rootError returns the error at the end of err's chain of wrapped errors.
+func rootError(err error) error {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
+
+// batchChecker is implemented by the batches which share the checks of Batch.
+type batchChecker interface {
+	hasControl() bool
+	checks() []func() error
+	customChecks() []func() error
+}
+
+// errorFieldName returns the name of the field err is about, if it has one.
+func errorFieldName(err error) string {
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		return fe.FieldName
+	}
+	var be *BatchError
+	if errors.As(err, &be) {
+		return be.FieldName
+	}
+	var ce ErrFileCalculatedControlEquality
+	if errors.As(err, &ce) {
+		return ce.Field
+	}
+	return ""
+}
+
+// ValidateAll checks every record of the File with the ValidateOpts stored on the File and
+// returns every problem found.
+func (f *File) ValidateAll() *ValidationReport {
+	return f.ValidateAllWith(f.validateOpts)
+}
+
+// ValidateAllWith checks every record of the File with opts and returns every problem found,
+// unlike ValidateWith which returns the first error encountered.
+//
+// Each record is checked on its own, then the totals and ordering of each batch and finally the
+// totals of the File. At most one error is reported for each record, after any warnings. Rules specific to the
+// Standard Entry Class Code of a batch are reported once its records and totals are valid.
+//
+// Findings have the Severity configured in opts.Severities, or SeverityError.
+func (f *File) ValidateAllWith(opts *ValidateOpts) *ValidationReport {
+	if opts == nil {
+		opts = &ValidateOpts{}
+	}
+	report := &ValidationReport{opts: opts}
+	if opts.SkipAll {
+		return report
+	}
+
+	file := ValidationFinding{BatchIndex: -1, EntryIndex: -1}
+	if !opts.AllowMissingFileHeader {
+		header := f.Header
+		header.validateOpts = opts
+		report.addRecord(file.at("FileHeader", header.SourceLine()), &header)
+	}
+	for i, b := range f.Batches {
+		report.validateBatch(i, b)
+	}
+	for i := range f.IATBatches {
+		report.validateIATBatch(i, &f.IATBatches[i])
+	}
+
+	isADV := f.IsADV()
+	if isADV {
+		if f.ADVControl.BatchCount != len(f.Batches) {
+			report.add(file.at("ADVFileControl", f.ADVControl.SourceLine()), NewErrFileCalculatedControlEquality("BatchCount", len(f.Batches), f.ADVControl.BatchCount))
+		}
+		if !opts.AllowMissingFileControl {
+			report.addRecord(file.at("ADVFileControl", f.ADVControl.SourceLine()), &f.ADVControl)
+		}
+	} else {
+		if f.Control.BatchCount != (len(f.Batches) + len(f.IATBatches)) {
+			report.add(file.at("FileControl", f.Control.SourceLine()), NewErrFileCalculatedControlEquality("BatchCount", len(f.Batches), f.Control.BatchCount))
+		}
+		if !opts.AllowMissingFileControl {
+			report.addRecord(file.at("FileControl", f.Control.SourceLine()), &f.Control)
+		}
+	}
+	report.add(file.at("File", 0), f.isEntryAddendaCount(isADV))
+	report.add(file.at("File", 0), f.isFileAmount(isADV))
+	if !isADV {
+		if !opts.AllowUnorderedBatchNumbers {
+			report.add(file.at("File", 0), f.isSequenceAscending())
+		}
+		if opts.SameDay {
+			report.add(file.at("File", 0), f.validateSameDay())
+		}
+	}
+	report.add(file.at("File", 0), f.isEntryHash(isADV))
+	for _, rule := range fileRules() {
+		report.add(file.at("File", 0), rule.check(f))
+	}
+	return report
+}
+
+// at returns a copy of the finding for record, which was read from line.
+func (f ValidationFinding) at(record string, line int) ValidationFinding {
+	f.Record = record
+	f.Line = line
+	return f
+}
+
+func (r *ValidationReport) validateBatch(index int, b Batcher) {
+	batch := ValidationFinding{BatchIndex: index, EntryIndex: -1}
+	r.addRecord(batch.at("BatchHeader", b.GetHeader().SourceLine()), b.GetHeader())
+
+	for i, entry := range b.GetEntries() {
+		ed := ValidationFinding{BatchIndex: index, EntryIndex: i, TraceNumber: entry.TraceNumber}
+		r.addRecord(ed.at("EntryDetail", entry.SourceLine()), entry)
+		if entry.Addenda02 != nil {
+			r.addRecord(ed.at("Addenda02", entry.Addenda02.SourceLine()), entry.Addenda02)
+		}
+		for _, addenda05 := range entry.Addenda05 {
+			r.addRecord(ed.at("Addenda05", addenda05.SourceLine()), addenda05)
+		}
+		if entry.Addenda98 != nil {
+			r.addRecord(ed.at("Addenda98", entry.Addenda98.SourceLine()), entry.Addenda98)
+		}
+		if entry.Addenda98Refused != nil {
+			r.addRecord(ed.at("Addenda98Refused", entry.Addenda98Refused.SourceLine()), entry.Addenda98Refused)
+		}
+		if entry.Addenda99 != nil {
+			r.addRecord(ed.at("Addenda99", entry.Addenda99.SourceLine()), entry.Addenda99)
+		}
+		if entry.Addenda99Dishonored != nil {
+			r.addRecord(ed.at("Addenda99Dishonored", entry.Addenda99Dishonored.SourceLine()), entry.Addenda99Dishonored)
+		}
+		if entry.Addenda99Contested != nil {
+			r.addRecord(ed.at("Addenda99Contested", entry.Addenda99Contested.SourceLine()), entry.Addenda99Contested)
+		}
+	}
+	for i, entry := range b.GetADVEntries() {
+		ed := ValidationFinding{BatchIndex: index, EntryIndex: i}
+		r.addRecord(ed.at("ADVEntryDetail", entry.SourceLine()), entry)
+		if entry.Addenda99 != nil {
+			r.addRecord(ed.at("Addenda99", entry.Addenda99.SourceLine()), entry.Addenda99)
+		}
+	}
+
+	if b.GetADVControl() != nil && len(b.GetADVEntries()) > 0 {
+		r.addRecord(batch.at("ADVBatchControl", b.GetADVControl().SourceLine()), b.GetADVControl())
+	} else if b.GetControl() != nil {
+		r.addRecord(batch.at("BatchControl", b.GetControl().SourceLine()), b.GetControl())
+	}
+
+	if checker, ok := b.(batchChecker); ok && b.GetHeader() != nil {
+		switch {
+		case len(b.GetEntries()) == 0 && len(b.GetADVEntries()) == 0:
+			// Validate reports the batch has no entries
+		case !checker.hasControl():
+			r.add(batch.at("BatchControl", b.GetHeader().SourceLine()), b.Error("Control", ErrBatchControl))
+		default:
+			for _, check := range checker.checks() {
+				r.add(batch.at("Batch", b.GetHeader().SourceLine()), check())
+			}
+		}
+	}
+	err := b.Validate()
+	if err == nil {
+		return
+	}
+	if !r.has(index, false, err) {
+		r.add(batch.at("Batch", b.GetHeader().SourceLine()), err)
+	}
+	// Validate stops at the first custom rule which fails, so report the others when it got to them
+	if checker, ok := b.(batchChecker); ok {
+		var failed []error
+		for _, check := range checker.customChecks() {
+			if err := check(); err != nil {
+				failed = append(failed, err)
+			}
+		}
+		if len(failed) > 0 && failed[0].Error() == err.Error() {
+			for _, err := range failed[1:] {
+				r.add(batch.at("Batch", b.GetHeader().SourceLine()), err)
+			}
+		}
+	}
+}
+
+func (r *ValidationReport) validateIATBatch(index int, b *IATBatch) {
+	batch := ValidationFinding{BatchIndex: index, IAT: true, EntryIndex: -1}
+	var headerLine int
+	if b.Header != nil {
+		headerLine = b.Header.SourceLine()
+		r.addRecord(batch.at("IATBatchHeader", b.Header.SourceLine()), b.Header)
+	}
+
+	addendaMissing := false
+	for i, entry := range b.Entries {
+		ed := ValidationFinding{BatchIndex: index, IAT: true, EntryIndex: i, TraceNumber: entry.TraceNumber}
+		r.addRecord(ed.at("IATEntryDetail", entry.SourceLine()), entry)
+		if err := b.addendaFieldInclusion(entry); err != nil {
+			r.add(ed.at("IATEntryDetail", entry.SourceLine()), err)
+			addendaMissing = true
+		}
+		if entry.Addenda10 != nil {
+			r.addRecord(ed.at("Addenda10", entry.Addenda10.SourceLine()), entry.Addenda10)
+		}
+		if entry.Addenda11 != nil {
+			r.addRecord(ed.at("Addenda11", entry.Addenda11.SourceLine()), entry.Addenda11)
+		}
+		if entry.Addenda12 != nil {
+			r.addRecord(ed.at("Addenda12", entry.Addenda12.SourceLine()), entry.Addenda12)
+		}
+		if entry.Addenda13 != nil {
+			r.addRecord(ed.at("Addenda13", entry.Addenda13.SourceLine()), entry.Addenda13)
+		}
+		if entry.Addenda14 != nil {
+			r.addRecord(ed.at("Addenda14", entry.Addenda14.SourceLine()), entry.Addenda14)
+		}
+		if entry.Addenda15 != nil {
+			r.addRecord(ed.at("Addenda15", entry.Addenda15.SourceLine()), entry.Addenda15)
+		}
+		if entry.Addenda16 != nil {
+			r.addRecord(ed.at("Addenda16", entry.Addenda16.SourceLine()), entry.Addenda16)
+		}
+		for _, addenda17 := range entry.Addenda17 {
+			r.addRecord(ed.at("Addenda17", addenda17.SourceLine()), addenda17)
+		}
+		for _, addenda18 := range entry.Addenda18 {
+			r.addRecord(ed.at("Addenda18", addenda18.SourceLine()), addenda18)
+		}
+		if entry.Addenda98 != nil {
+			r.addRecord(ed.at("Addenda98", entry.Addenda98.SourceLine()), entry.Addenda98)
+		}
+		if entry.Addenda99 != nil {
+			r.addRecord(ed.at("Addenda99", entry.Addenda99.SourceLine()), entry.Addenda99)
+		}
+	}
+
+	if b.Control != nil {
+		r.addRecord(batch.at("BatchControl", b.Control.SourceLine()), b.Control)
+	}
+	if b.Header != nil && b.Control != nil && !addendaMissing {
+		for _, check := range b.checks() {
+			r.add(batch.at("Batch", headerLine), check())
+		}
+	}
+
+	if err := b.Validate(); err != nil && !r.has(index, true, err) {
+		r.add(batch.at("Batch", headerLine), err)
+	}
+}
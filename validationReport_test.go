@@ -0,0 +1,258 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile__ValidateAll(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	report := file.ValidateAll()
+	require.Empty(t, report.Findings)
+	require.True(t, report.Valid())
+	require.NoError(t, report.Err())
+
+	// break three records and the batch totals
+	file.Header.ImmediateOriginName = "ÄCME"
+	file.Batches[0].GetEntries()[0].IndividualName = "Jöhn"
+	file.Batches[0].GetEntries()[2].DFIAccountNumber = "123®"
+	file.Batches[0].GetControl().TotalDebitEntryDollarAmount += 1
+	file.Batches[0].GetControl().EntryHash += 1
+
+	report = file.ValidateAll()
+	require.False(t, report.Valid())
+	require.Len(t, report.Findings, 7)
+
+	findings := report.Findings
+	require.Equal(t, "FileHeader", findings[0].Record)
+	require.Equal(t, "ImmediateOriginName", findings[0].FieldName)
+	require.Equal(t, -1, findings[0].BatchIndex)
+	require.Equal(t, SeverityError, findings[0].Severity)
+
+	require.Equal(t, "EntryDetail", findings[1].Record)
+	require.Equal(t, "IndividualName", findings[1].FieldName)
+	require.Equal(t, 0, findings[1].BatchIndex)
+	require.Equal(t, 0, findings[1].EntryIndex)
+	require.Equal(t, "121042880000001", findings[1].TraceNumber)
+	require.ErrorIs(t, findings[1], ErrNonAlphanumeric)
+
+	// findings cite the line the record was read from
+	require.Equal(t, 1, findings[0].Line)
+	require.Equal(t, 3, findings[1].Line)
+	require.True(t, strings.HasPrefix(findings[1].Error(), "line 3 batch 0 entry 0 (trace number 121042880000001) EntryDetail: "))
+
+	require.Equal(t, "EntryDetail", findings[2].Record)
+	require.Equal(t, "DFIAccountNumber", findings[2].FieldName)
+	require.Equal(t, 2, findings[2].EntryIndex)
+	require.Equal(t, "121042880000003", findings[2].TraceNumber)
+
+	// every batch total is checked, even with invalid records
+	require.Equal(t, "Batch", findings[3].Record)
+	require.Equal(t, "TotalDebitEntryDollarAmount", findings[3].FieldName)
+	require.Equal(t, -1, findings[3].EntryIndex)
+	require.Equal(t, 2, findings[3].Line)
+	require.Equal(t, "Batch", findings[4].Record)
+	require.Equal(t, "EntryHash", findings[4].FieldName)
+
+	// the file totals don't match the changed batch control
+	require.Equal(t, "File", findings[5].Record)
+	require.Equal(t, "TotalDebitEntryDollarAmountInFile", findings[5].FieldName)
+	require.Zero(t, findings[5].Line)
+	require.Equal(t, "EntryHash", findings[6].FieldName)
+
+	var el base.ErrorList
+	require.True(t, errors.As(report.Err(), &el))
+	require.Len(t, el, 7)
+	require.True(t, base.Has(report.Err(), ErrNonAlphanumeric))
+
+	bs, err := json.Marshal(report)
+	require.NoError(t, err)
+	require.Contains(t, string(bs), `"record":"EntryDetail","batchIndex":0,"entryIndex":0,"traceNumber":"121042880000001","fieldName":"IndividualName"`)
+	require.Contains(t, string(bs), `"severity":"error"`)
+	require.Contains(t, string(bs), `"fieldName":"IndividualName","line":3,`)
+
+	// SkipAll reports nothing
+	require.Empty(t, file.ValidateAllWith(&ValidateOpts{SkipAll: true}).Findings)
+}
+
+func TestFile__ValidateAllIAT(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "iat-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+	require.Empty(t, file.ValidateAll().Findings)
+
+	file.IATBatches[0].Entries[0].Addenda10.Name = "Jöhn"
+	file.IATBatches[0].Entries[1].Addenda11.OriginatorName = "Bäd"
+
+	report := file.ValidateAll()
+	require.Len(t, report.Findings, 2)
+	require.Equal(t, "Addenda10", report.Findings[0].Record)
+	require.True(t, report.Findings[0].IAT)
+	require.Equal(t, 0, report.Findings[0].EntryIndex)
+	require.Equal(t, "Addenda11", report.Findings[1].Record)
+	require.Equal(t, 1, report.Findings[1].EntryIndex)
+	require.Equal(t, "OriginatorName", report.Findings[1].FieldName)
+}
+
+func TestFile__ValidateAllADV(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "flattenADVBatchesOneBatchHeader.ach"))
+	require.NoError(t, err)
+	require.Empty(t, file.ValidateAll().Findings)
+
+	file.ADVControl.BatchCount = 5
+	report := file.ValidateAll()
+	require.Len(t, report.Findings, 1)
+	require.Equal(t, "ADVFileControl", report.Findings[0].Record)
+	require.Equal(t, "BatchCount", report.Findings[0].FieldName)
+}
+
+func TestFile__ValidateAllMissingControl(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	batch := file.Batches[0].(*BatchPPD)
+	batch.Control = nil
+	require.ErrorIs(t, batch.Validate(), ErrBatchControl)
+
+	report := file.ValidateAll()
+	require.False(t, report.Valid())
+	require.NotEmpty(t, report.Findings)
+	require.Equal(t, "BatchControl", report.Findings[0].Record)
+	require.Equal(t, 0, report.Findings[0].BatchIndex)
+	require.ErrorIs(t, report.Findings[0], ErrBatchControl)
+
+	opts := &ValidateOpts{
+		Severities: map[string]Severity{
+			"Batch.TraceNumber": SeverityWarning,
+		},
+	}
+	batch.Control = nil
+	require.ErrorIs(t, file.ValidateWith(opts), ErrBatchControl)
+}
+
+func TestFile__ValidateAllSeverities(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-mixedDebitCredit.ach"))
+	require.NoError(t, err)
+
+	// trace numbers are no longer ascending
+	file.Batches[0].GetEntries()[1].TraceNumber = "121042880000005"
+	require.ErrorContains(t, file.Validate(), "must be in ascending order")
+
+	opts := &ValidateOpts{
+		Severities: map[string]Severity{
+			"Batch.TraceNumber": SeverityWarning,
+		},
+	}
+	require.NoError(t, file.ValidateWith(opts))
+
+	report := file.ValidateAllWith(opts)
+	require.True(t, report.Valid())
+	require.Len(t, report.Warnings(), 1)
+	require.Equal(t, "Batch", report.Warnings()[0].Record)
+	require.Equal(t, "TraceNumber", report.Warnings()[0].FieldName)
+
+	// other problems are still errors
+	file.Batches[0].GetEntries()[0].IndividualName = "Jöhn"
+	err = file.ValidateWith(opts)
+	require.ErrorIs(t, err, ErrNonAlphanumeric)
+	require.Len(t, file.ValidateAllWith(opts).Warnings(), 1)
+
+	// rules can be named by the field only
+	opts.Severities["IndividualName"] = SeverityWarning
+	require.NoError(t, file.ValidateWith(opts))
+	require.Len(t, file.ValidateAllWith(opts).Warnings(), 2)
+
+	// unknown severities are errors
+	opts.Severities["IndividualName"] = "warn"
+	require.Error(t, file.ValidateWith(opts))
+}
+
+func TestFile__ValidateAllSeveritiesSameRecord(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	// both routing numbers have the wrong check digit
+	file.Header.ImmediateOrigin = "123456789"
+	file.Header.ImmediateDestination = "987654321"
+
+	opts := &ValidateOpts{
+		RequireABAOrigin: true,
+		Severities: map[string]Severity{
+			"ImmediateOrigin": SeverityWarning,
+		},
+	}
+	err = file.ValidateWith(opts)
+	require.ErrorContains(t, err, "ImmediateDestination")
+
+	report := file.ValidateAllWith(opts)
+	require.Len(t, report.Findings, 2)
+	require.Equal(t, "ImmediateOrigin", report.Findings[0].FieldName)
+	require.Equal(t, SeverityWarning, report.Findings[0].Severity)
+	require.Equal(t, "ImmediateDestination", report.Findings[1].FieldName)
+	require.Equal(t, SeverityError, report.Findings[1].Severity)
+
+	// validateAll starts with the problem Validate finds
+	header := file.Header
+	header.SetValidation(opts)
+	errs := header.validateAll()
+	require.Len(t, errs, 2)
+	require.Equal(t, header.Validate(), errs[0])
+
+	opts.Severities["ImmediateDestination"] = SeverityWarning
+	require.NoError(t, file.ValidateWith(opts))
+	require.Len(t, file.ValidateAllWith(opts).Warnings(), 2)
+}
+
+func TestValidateOpts__mergeSeverities(t *testing.T) {
+	var opts ValidateOpts
+	require.NoError(t, json.Unmarshal([]byte(`{"severities": {"TraceNumber": "warning", "IndividualName": "warning"}}`), &opts))
+
+	merged := opts.merge(&ValidateOpts{
+		Severities: map[string]Severity{"IndividualName": SeverityError},
+	})
+	require.Equal(t, map[string]Severity{
+		"TraceNumber":    SeverityWarning,
+		"IndividualName": SeverityError,
+	}, merged.Severities)
+	require.Equal(t, SeverityWarning, opts.Severities["IndividualName"])
+}
+
+func TestValidationReport__has(t *testing.T) {
+	report := &ValidationReport{}
+	report.add(ValidationFinding{Record: "EntryDetail", Line: 3, EntryIndex: 0}, fieldError("IndividualName", ErrNonAlphanumeric, "Jöhn"))
+
+	batch := &Batch{Header: NewBatchHeader()}
+	reported := batch.Error("FieldError", atLine(fieldError("IndividualName", ErrNonAlphanumeric, "Jöhn"), 3))
+	require.True(t, report.has(0, false, reported))
+
+	// the same message from another record or about another problem isn't the same finding
+	other := batch.Error("FieldError", atLine(fieldError("IndividualName", ErrNonAlphanumeric, "Jöhn"), 4))
+	require.Equal(t, reported.Error(), other.Error())
+	require.False(t, report.has(0, false, other))
+	require.False(t, report.has(0, false, atLine(fieldError("IndividualName", ErrUpperAlpha, "Jöhn"), 3)))
+	require.False(t, report.has(1, false, reported))
+}
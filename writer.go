@@ -21,6 +21,7 @@ import (
 	"bufio"
 	"errors"
 	"io"
+	"math"
 	"strings"
 )
 
@@ -30,9 +31,16 @@ type Writer struct {
 	w *bufio.Writer
 
 	lineNum    int    //current line being written
+	line       []byte // buffer of the record being written
 	LineEnding string // configurable line ending to support different consumer requirements
 	// BypassValidation can be set to skip file validation and will allow non-compliant Nacha files to be written.
 	BypassValidation bool
+
+	// unknownRecords are the UnknownRecords of the File being written which are left to write
+	unknownRecords []UnknownRecord
+
+	// stream holds the totals of the file started with BeginFile
+	stream *writerStream
 }
 
 // WriteOpts defines options for writing a file.
@@ -54,6 +62,7 @@ func NewWriterWithOpts(w io.Writer, opts *WriteOpts) *Writer {
 	}
 	return &Writer{
 		w:          bufio.NewWriter(w),
+		line:       make([]byte, 0, RecordLength),
 		LineEnding: lineEnding,
 	}
 }
@@ -71,6 +80,11 @@ func (w *Writer) Write(file *File) error {
 	}
 
 	w.lineNum = 0
+	w.unknownRecords = sortedUnknownRecords(file.UnknownRecords)
+	if err := w.writeRawLines(file.LeadingLines); err != nil {
+		return err
+	}
+
 	// Iterate over all records in the file
 	if err := w.writeLine(&file.Header); err != nil {
 		return err
@@ -96,7 +110,24 @@ func (w *Writer) Write(file *File) error {
 		}
 	}
 
-	// pad the final block
+	// unknown records after the last Nacha record
+	if err := w.writeUnknownRecords(math.MaxInt); err != nil {
+		return err
+	}
+
+	if err := w.writePadding(); err != nil {
+		return err
+	}
+
+	if err := w.writeRawLines(file.TrailingLines); err != nil {
+		return err
+	}
+
+	return w.w.Flush()
+}
+
+// writePadding pads the final block of the file with lines of 9s.
+func (w *Writer) writePadding() error {
 	for i := 0; i < (10-(w.lineNum%10)) && w.lineNum%10 != 0; i++ {
 		_, err := w.w.WriteString(paddingLine)
 		if err != nil {
@@ -107,8 +138,7 @@ func (w *Writer) Write(file *File) error {
 			return err
 		}
 	}
-
-	return w.w.Flush()
+	return nil
 }
 
 // Flush writes any buffered data to the underlying io.Writer.
@@ -126,30 +156,7 @@ func (w *Writer) writeBatch(file *File, isADV bool) error {
 		}
 		if !isADV {
 			for _, entry := range batch.GetEntries() {
-				if err := w.writeLine(entry); err != nil {
-					return err
-				}
-				if err := w.writeLine(entry.Addenda02); err != nil {
-					return err
-				}
-				for _, addenda05 := range entry.Addenda05 {
-					if err := w.writeLine(addenda05); err != nil {
-						return err
-					}
-				}
-				if err := w.writeLine(entry.Addenda98); err != nil {
-					return err
-				}
-				if err := w.writeLine(entry.Addenda98Refused); err != nil {
-					return err
-				}
-				if err := w.writeLine(entry.Addenda99); err != nil {
-					return err
-				}
-				if err := w.writeLine(entry.Addenda99Dishonored); err != nil {
-					return err
-				}
-				if err := w.writeLine(entry.Addenda99Contested); err != nil {
+				if err := w.writeEntryDetail(entry); err != nil {
 					return err
 				}
 			}
@@ -177,6 +184,34 @@ func (w *Writer) writeBatch(file *File, isADV bool) error {
 	return nil
 }
 
+// writeEntryDetail writes entry followed by its addenda records.
+func (w *Writer) writeEntryDetail(entry *EntryDetail) error {
+	if err := w.writeLine(entry); err != nil {
+		return err
+	}
+	if err := w.writeLine(entry.Addenda02); err != nil {
+		return err
+	}
+	for _, addenda05 := range entry.Addenda05 {
+		if err := w.writeLine(addenda05); err != nil {
+			return err
+		}
+	}
+	if err := w.writeLine(entry.Addenda98); err != nil {
+		return err
+	}
+	if err := w.writeLine(entry.Addenda98Refused); err != nil {
+		return err
+	}
+	if err := w.writeLine(entry.Addenda99); err != nil {
+		return err
+	}
+	if err := w.writeLine(entry.Addenda99Dishonored); err != nil {
+		return err
+	}
+	return w.writeLine(entry.Addenda99Contested)
+}
+
 func (w *Writer) writeIATBatch(file *File) error {
 	for _, iatBatch := range file.IATBatches {
 		if err := w.writeLine(iatBatch.GetHeader()); err != nil {
@@ -236,7 +271,7 @@ func (w *Writer) writeIATBatch(file *File) error {
 }
 
 type writeEntry interface {
-	String() string
+	AppendTo(dst []byte) []byte
 }
 
 func (w *Writer) writeLine(entry writeEntry) error {
@@ -244,12 +279,16 @@ func (w *Writer) writeLine(entry writeEntry) error {
 		return nil
 	}
 
-	line := entry.String()
-	if line == "" {
+	// records are appended to the same buffer to avoid allocating a string for each line
+	w.line = entry.AppendTo(w.line[:0])
+	if len(w.line) == 0 {
 		return nil
 	}
 
-	_, err := w.w.WriteString(line)
+	if err := w.writeUnknownRecords(w.lineNum); err != nil {
+		return err
+	}
+	_, err := w.w.Write(w.line)
 	if err != nil {
 		return err
 	}
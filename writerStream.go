@@ -0,0 +1,247 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// writerStream keeps the running totals of a file written with BeginFile.
+type writerStream struct {
+	converters
+
+	opts *ValidateOpts
+
+	// totals of the batches written so far
+	batchCount        int
+	entryAddendaCount int
+	entryHash         int
+	totalDebit        int
+	totalCredit       int
+
+	// batch is the header of the open batch, or nil between batches
+	batch                  *BatchHeader
+	batchEntries           int
+	batchEntryAddendaCount int
+	batchEntryHash         int
+	batchDebit             int
+	batchCredit            int
+	lastTraceNumber        string
+
+	// check validates each entry as the only entry of the open batch, category is the category of its first entry
+	check    streamBatch
+	category string
+}
+
+// BeginFile starts writing a file record by record instead of from a File held in memory.
+// Batches are written with BeginBatch, WriteEntry and EndBatch, and the file is finished with EndFile.
+//
+// The BatchControl and FileControl records are calculated as records are written. Records are validated as
+// they're written unless BypassValidation is enabled, using the ValidateOpts set on header. Each entry is also
+// validated with the rules of its batch's Standard Entry Class Code, as if it were the only entry of the batch.
+// ADV and IAT batches are not supported.
+func (w *Writer) BeginFile(header FileHeader) error {
+	if w.stream != nil {
+		return fmt.Errorf("BeginFile: file already started: %w", ErrFileStreamOrder)
+	}
+	if !w.BypassValidation {
+		if err := maybeValidate(&header, header.validateOpts); err != nil {
+			return err
+		}
+	}
+
+	w.lineNum = 0
+	w.unknownRecords = nil
+	w.stream = &writerStream{opts: header.validateOpts}
+	return w.writeLine(&header)
+}
+
+// BeginBatch starts a batch in the file started with BeginFile. A BatchNumber of 0 or 1 is replaced
+// with the batch's position in the file.
+func (w *Writer) BeginBatch(bh *BatchHeader) error {
+	s := w.stream
+	switch {
+	case s == nil:
+		return fmt.Errorf("BeginBatch: no file started: %w", ErrFileStreamOrder)
+	case s.batch != nil:
+		return fmt.Errorf("BeginBatch: batch %d not ended: %w", s.batch.BatchNumber, ErrFileStreamOrder)
+	case bh == nil:
+		return fmt.Errorf("BeginBatch: nil BatchHeader")
+	case bh.StandardEntryClassCode == ADV || bh.StandardEntryClassCode == IAT:
+		return fieldError("StandardEntryClassCode", ErrFileStreamSEC, bh.StandardEntryClassCode)
+	}
+
+	if bh.BatchNumber <= 1 {
+		bh.BatchNumber = s.batchCount + 1
+	}
+	if s.opts != nil {
+		bh.SetValidation(s.opts)
+	}
+	s.check = nil
+	if !w.BypassValidation {
+		if err := maybeValidate(bh, s.opts); err != nil {
+			return err
+		}
+		b, err := NewBatch(bh)
+		if err != nil {
+			return err
+		}
+		check, ok := b.(streamBatch)
+		if !ok {
+			return b.Error("Batch", fmt.Errorf("%T can't be streamed", b))
+		}
+		check.SetValidation(s.opts)
+		s.check = check
+	}
+
+	s.batch = bh
+	s.batchEntries = 0
+	s.batchEntryAddendaCount = 0
+	s.batchEntryHash = 0
+	s.batchDebit, s.batchCredit = 0, 0
+	s.lastTraceNumber = ""
+	return w.writeLine(bh)
+}
+
+// WriteEntry writes entry and its addenda records to the batch started with BeginBatch.
+// Trace numbers must be in ascending order unless ValidateOpts.CustomTraceNumbers is set.
+func (w *Writer) WriteEntry(entry *EntryDetail) error {
+	s := w.stream
+	if s == nil || s.batch == nil {
+		return fmt.Errorf("WriteEntry: %w", ErrFileEntryOutsideBatch)
+	}
+	if entry == nil {
+		return fmt.Errorf("WriteEntry: nil EntryDetail")
+	}
+
+	if s.opts != nil {
+		entry.SetValidation(s.opts)
+	}
+	// sequence the Addenda05 records as Batch.Create does
+	for i, addenda05 := range entry.Addenda05 {
+		addenda05.SequenceNumber = i + 1
+		addenda05.EntryDetailSequenceNumber = s.parseNumField(entry.TraceNumberField()[8:])
+	}
+
+	if !w.BypassValidation {
+		if err := maybeValidate(entry, s.opts); err != nil {
+			return err
+		}
+		if s.batchEntries > 0 {
+			if (s.opts == nil || !s.opts.CustomTraceNumbers) && entry.TraceNumber <= s.lastTraceNumber {
+				return s.check.Error("TraceNumber", NewErrBatchAscending(s.lastTraceNumber, entry.TraceNumber))
+			}
+			if entry.Category != CategoryNOC && entry.Category != s.category {
+				return s.check.Error("Category", NewErrBatchCategory(entry.Category, s.category))
+			}
+		} else {
+			s.category = entry.Category
+		}
+		s.check.streamEntry(entry)
+		if err := s.check.Validate(); err != nil {
+			return err
+		}
+	}
+	s.lastTraceNumber = entry.TraceNumber
+
+	rdfi, _ := strconv.Atoi(aba8(entry.RDFIIdentification))
+	credit, debit := entryAmounts(entry)
+
+	s.batchEntries++
+	s.batchEntryAddendaCount += 1 + entry.addendaCount()
+	s.batchEntryHash += rdfi
+	s.batchCredit += credit
+	s.batchDebit += debit
+
+	return w.writeEntryDetail(entry)
+}
+
+// EndBatch writes the BatchControl of the batch started with BeginBatch.
+func (w *Writer) EndBatch() error {
+	s := w.stream
+	if s == nil || s.batch == nil {
+		return fmt.Errorf("EndBatch: %w", ErrFileBatchControlOutsideBatch)
+	}
+	bh := s.batch
+	if s.batchEntries == 0 && !w.BypassValidation {
+		return &BatchError{
+			BatchNumber: bh.BatchNumber,
+			BatchType:   bh.StandardEntryClassCode,
+			FieldName:   "entries",
+			Err:         ErrBatchNoEntries,
+		}
+	}
+
+	bc := NewBatchControl()
+	bc.ServiceClassCode = bh.ServiceClassCode
+	bc.CompanyIdentification = bh.CompanyIdentification
+	bc.ODFIIdentification = bh.ODFIIdentification
+	bc.BatchNumber = bh.BatchNumber
+	bc.EntryAddendaCount = s.batchEntryAddendaCount
+	bc.EntryHash = s.leastSignificantDigits(s.batchEntryHash, 10)
+	bc.TotalCreditEntryDollarAmount = s.batchCredit
+	bc.TotalDebitEntryDollarAmount = s.batchDebit
+	if err := w.writeLine(bc); err != nil {
+		return err
+	}
+
+	s.batchCount++
+	s.entryAddendaCount += bc.EntryAddendaCount
+	s.entryHash += bc.EntryHash
+	s.totalCredit += bc.TotalCreditEntryDollarAmount
+	s.totalDebit += bc.TotalDebitEntryDollarAmount
+	s.batch = nil
+	return nil
+}
+
+// EndFile writes the FileControl of the file started with BeginFile, pads the final block and flushes the output.
+func (w *Writer) EndFile() error {
+	s := w.stream
+	switch {
+	case s == nil:
+		return fmt.Errorf("EndFile: no file started: %w", ErrFileStreamOrder)
+	case s.batch != nil:
+		return fmt.Errorf("EndFile: batch %d not ended: %w", s.batch.BatchNumber, ErrFileStreamOrder)
+	}
+	if s.batchCount == 0 && !w.BypassValidation && (s.opts == nil || !s.opts.AllowZeroBatches) {
+		return ErrFileNoBatches
+	}
+
+	fc := NewFileControl()
+	fc.BatchCount = s.batchCount
+	// the FileControl is the last record of the file
+	records := w.lineNum + 1
+	fc.BlockCount = records / 10
+	if records%10 != 0 {
+		fc.BlockCount++
+	}
+	fc.EntryAddendaCount = s.entryAddendaCount
+	fc.EntryHash = s.leastSignificantDigits(s.entryHash, 10)
+	fc.TotalDebitEntryDollarAmountInFile = s.totalDebit
+	fc.TotalCreditEntryDollarAmountInFile = s.totalCredit
+	if err := w.writeLine(&fc); err != nil {
+		return err
+	}
+	if err := w.writePadding(); err != nil {
+		return err
+	}
+
+	w.stream = nil
+	return w.w.Flush()
+}
@@ -0,0 +1,177 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func streamTestFile(t *testing.T) *File {
+	t.Helper()
+
+	company := Company{
+		Name:               "Acme Corp",
+		Identification:     "121042882",
+		EntryDescription:   "PAYROLL",
+		EffectiveEntryDate: time.Date(2023, time.December, 26, 0, 0, 0, 0, time.UTC),
+	}
+	file, err := NewBuilder("121042882", "231380104").
+		Names("My Bank", "Federal Reserve Bank").
+		CreatedAt(time.Date(2023, time.December, 21, 10, 30, 0, 0, time.UTC)).
+		Batch(PPD, company).
+		Credit("231380104", "12345678", 100000, "Jane Doe").
+		Credit("23138010", "87654321", 2500, "John Doe", WithSavingsAccount(), WithAddenda05("bonus")).
+		Debit("231380104", "55555", 1500, "Acme Corp").
+		Batch(CCD, company).
+		Debit("231380104", "55555", 5000, "Widgets Inc", WithIdentificationNumber("INV-1")).
+		Build()
+	require.NoError(t, err)
+	return file
+}
+
+func TestWriter__Stream(t *testing.T) {
+	file := streamTestFile(t)
+
+	var expected bytes.Buffer
+	require.NoError(t, NewWriter(&expected).Write(file))
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	require.NoError(t, w.BeginFile(file.Header))
+	for _, batch := range file.Batches {
+		require.NoError(t, w.BeginBatch(batch.GetHeader()))
+		for _, entry := range batch.GetEntries() {
+			require.NoError(t, w.WriteEntry(entry))
+		}
+		require.NoError(t, w.EndBatch())
+	}
+	require.NoError(t, w.EndFile())
+
+	require.Equal(t, expected.String(), buf.String())
+
+	// the streamed file reads back with matching controls
+	read, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+	require.NoError(t, read.Validate())
+}
+
+func TestWriter__StreamPadding(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	require.NoError(t, w.BeginFile(file.Header))
+	require.NoError(t, w.BeginBatch(file.Batches[0].GetHeader()))
+	require.NoError(t, w.WriteEntry(file.Batches[0].GetEntries()[0]))
+	require.NoError(t, w.EndBatch())
+	require.NoError(t, w.EndFile())
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 10)
+	for _, line := range lines[5:] {
+		require.Equal(t, paddingLine, line)
+	}
+	require.Equal(t, "9000001000001000000010023138010000100000000000000000000", strings.TrimSpace(lines[4]))
+}
+
+func TestWriter__StreamTraceNumbers(t *testing.T) {
+	file := streamTestFile(t)
+	entries := file.Batches[0].GetEntries()
+
+	w := NewWriter(&bytes.Buffer{})
+	require.NoError(t, w.BeginFile(file.Header))
+	require.NoError(t, w.BeginBatch(file.Batches[0].GetHeader()))
+	require.NoError(t, w.WriteEntry(entries[1]))
+
+	err := w.WriteEntry(entries[0])
+	var be *BatchError
+	require.ErrorAs(t, err, &be)
+	require.Equal(t, "TraceNumber", be.FieldName)
+	require.IsType(t, ErrBatchAscending{}, be.Err)
+
+	// custom trace numbers aren't checked
+	opts := &ValidateOpts{CustomTraceNumbers: true}
+	file.Header.SetValidation(opts)
+	w = NewWriter(&bytes.Buffer{})
+	require.NoError(t, w.BeginFile(file.Header))
+	require.NoError(t, w.BeginBatch(file.Batches[0].GetHeader()))
+	require.NoError(t, w.WriteEntry(entries[1]))
+	require.NoError(t, w.WriteEntry(entries[0]))
+}
+
+func TestWriter__StreamOrder(t *testing.T) {
+	file := streamTestFile(t)
+	bh := file.Batches[0].GetHeader()
+	entry := file.Batches[0].GetEntries()[0]
+
+	w := NewWriter(&bytes.Buffer{})
+	require.ErrorIs(t, w.BeginBatch(bh), ErrFileStreamOrder)
+	require.ErrorIs(t, w.WriteEntry(entry), ErrFileEntryOutsideBatch)
+	require.ErrorIs(t, w.EndBatch(), ErrFileBatchControlOutsideBatch)
+	require.ErrorIs(t, w.EndFile(), ErrFileStreamOrder)
+
+	require.NoError(t, w.BeginFile(file.Header))
+	require.ErrorIs(t, w.BeginFile(file.Header), ErrFileStreamOrder)
+	require.ErrorIs(t, w.EndFile(), ErrFileNoBatches)
+
+	iat := NewBatchHeader()
+	iat.StandardEntryClassCode = IAT
+	require.ErrorIs(t, w.BeginBatch(iat), ErrFileStreamSEC)
+
+	require.NoError(t, w.BeginBatch(bh))
+	require.ErrorIs(t, w.BeginBatch(bh), ErrFileStreamOrder)
+	require.ErrorIs(t, w.EndFile(), ErrFileStreamOrder)
+	require.ErrorIs(t, w.EndBatch(), ErrBatchNoEntries)
+}
+
+func TestWriter__StreamBatchRules(t *testing.T) {
+	file := streamTestFile(t)
+
+	bh := *file.Batches[0].GetHeader()
+	bh.ServiceClassCode = CreditsOnly
+	entry := *file.Batches[0].GetEntries()[2]
+	entry.AddendaRecordIndicator = 1
+
+	w := NewWriter(&bytes.Buffer{})
+	require.NoError(t, w.BeginFile(file.Header))
+	require.NoError(t, w.BeginBatch(&bh))
+
+	err := w.WriteEntry(&entry)
+	var be *BatchError
+	require.ErrorAs(t, err, &be)
+
+	// mixed categories within a batch are rejected
+	bh.ServiceClassCode = MixedDebitsAndCredits
+	w = NewWriter(&bytes.Buffer{})
+	require.NoError(t, w.BeginFile(file.Header))
+	require.NoError(t, w.BeginBatch(&bh))
+	require.NoError(t, w.WriteEntry(file.Batches[0].GetEntries()[0]))
+
+	entry = *file.Batches[0].GetEntries()[1]
+	entry.Category = CategoryReturn
+	err = w.WriteEntry(&entry)
+	require.ErrorAs(t, err, &be)
+	require.Equal(t, "Category", be.FieldName)
+}
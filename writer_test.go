@@ -76,6 +76,21 @@ func TestPPDWrite(t *testing.T) {
 	testPPDWrite(t)
 }
 
+func TestWriter__Allocations(t *testing.T) {
+	file, err := readACHFilepath(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	populateFileWithMockBatches(t, 100, file)
+	require.NoError(t, file.Create())
+
+	// records are appended to the Writer's buffer rather than allocating a string each
+	allocs := testing.AllocsPerRun(10, func() {
+		w := NewWriter(io.Discard)
+		w.BypassValidation = true
+		require.NoError(t, w.Write(file))
+	})
+	require.Less(t, allocs, float64(20))
+}
+
 // BenchmarkPPDWrite benchmarks validating writing a PPD ACH file
 func BenchmarkPPDWrite(b *testing.B) {
 	b.ReportAllocs()